@@ -0,0 +1,301 @@
+package postgres
+
+import (
+	"backend/internal/repository"
+	"backend/internal/services/content"
+	"backend/internal/services/utils"
+	blog "backend/modules/blog/models"
+	category "backend/modules/category/models"
+	categoryRepo "backend/modules/category/repository"
+	item "backend/modules/item/models"
+	property "backend/modules/property/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log"
+	"strings"
+	"time"
+)
+
+// columnType повертає тип колонки з information_schema або "" якщо колонки немає
+func columnType(db *gorm.DB, table string, column string) (string, error) {
+	var dataType string
+	err := db.Raw(`SELECT data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`, table, column).
+		Scan(&dataType).Error
+	return dataType, err
+}
+
+// migrateItemPricesToMinorUnits переводить items.price з float у цілі мінімальні одиниці (грош)
+func migrateItemPricesToMinorUnits(db *gorm.DB) error {
+	dataType, err := columnType(db, "items", "price")
+	if err != nil {
+		return err
+	}
+	if dataType != "double precision" && dataType != "real" && dataType != "numeric" {
+		return nil
+	}
+
+	log.Println("Migrating items.price to integer minor units")
+	return db.Exec(`ALTER TABLE items ALTER COLUMN price TYPE bigint USING ROUND(price::numeric * 100)::bigint`).Error
+}
+
+// migrateItemCategories переносить текстові items.category у таблицю categories і видаляє стару колонку
+func migrateItemCategories(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("items", "category") {
+		return nil
+	}
+
+	log.Println("Migrating items.category strings to the categories table")
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []struct {
+			Category string
+			Language string
+		}
+		err := tx.Raw(`SELECT DISTINCT TRIM(category) AS category, language FROM items
+			WHERE category IS NOT NULL AND TRIM(category) <> ''`).Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			slug, fallback := categoryRepo.LegacyCategorySlug(row.Category)
+			if fallback {
+				log.Printf("Category %q (%s) has no latin slug, using %q", row.Category, row.Language, slug)
+			}
+
+			var existing category.Category
+			err = tx.Where("slug = ?", slug).First(&existing).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				existing = category.Category{Slug: slug}
+				err = tx.Create(&existing).Error
+			}
+			if err != nil {
+				return err
+			}
+
+			// Рядок з категорією стає її назвою для мови товару
+			err = tx.Exec(`INSERT INTO category_names (id, category_id, language, name) VALUES (?, ?, ?, ?)
+				ON CONFLICT (category_id, language) DO NOTHING`,
+				uuid.New(), existing.ID, row.Language, row.Category).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Exec(`UPDATE items SET category_id = ? WHERE TRIM(category) = ? AND category_id IS NULL`,
+				existing.ID, row.Category).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Колонку видаляємо, лише якщо кожен товар з категорією отримав category_id
+		var unmapped int64
+		err = tx.Raw(`SELECT COUNT(*) FROM items
+			WHERE category IS NOT NULL AND TRIM(category) <> '' AND category_id IS NULL`).Scan(&unmapped).Error
+		if err != nil {
+			return err
+		}
+		if unmapped > 0 {
+			return fmt.Errorf("%d items still have an unmapped category, items.category is kept", unmapped)
+		}
+
+		return tx.Migrator().DropColumn("items", "category")
+	})
+}
+
+// backfillTranslationGroups робить кожен наявний запис окремою групою перекладів
+func backfillTranslationGroups(db *gorm.DB) error {
+	for _, table := range []string{"items", "blogs"} {
+		err := db.Exec("UPDATE " + table + " SET translation_group_id = id WHERE translation_group_id IS NULL").Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateLegacyProperties переносить фіксовані колонки properties у типізовані атрибути та значення
+func migrateLegacyProperties(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("properties", "height") {
+		return nil
+	}
+
+	log.Println("Migrating fixed property columns to attributes")
+	legacyColumns := []string{"height", "width", "weight", "color", "material", "brand", "size", "motif", "style"}
+	numericColumns := map[string]bool{"height": true, "width": true, "weight": true}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for position, column := range legacyColumns {
+			attributeType := property.AttributeText
+			if numericColumns[column] {
+				attributeType = property.AttributeNumber
+			}
+
+			var attribute property.Attribute
+			err := tx.Where("code = ? AND category_id IS NULL", column).First(&attribute).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				attribute = property.Attribute{
+					Code:       column,
+					Name:       strings.ToUpper(column[:1]) + column[1:],
+					Type:       attributeType,
+					Filterable: true,
+					Position:   position,
+				}
+				err = tx.Create(&attribute).Error
+			}
+			if err != nil {
+				return err
+			}
+
+			if attribute.Type != property.AttributeNumber {
+				err = tx.Exec(`INSERT INTO property_values (id, content_id, attribute_id, text_value)
+					SELECT gen_random_uuid(), content_id, ?, TRIM(`+column+`) FROM properties
+					WHERE content_id IS NOT NULL AND `+column+` IS NOT NULL AND TRIM(`+column+`) <> ''
+					ON CONFLICT (content_id, attribute_id) DO NOTHING`, attribute.ID).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Число береться з початку рядка ("12,5 cm" → 12.5); решта значень лишається в legacy_properties
+			number := `SUBSTRING(REPLACE(TRIM(` + column + `), ',', '.') FROM '^-?[0-9]+(?:\.[0-9]+)?')`
+			err = tx.Exec(`INSERT INTO property_values (id, content_id, attribute_id, number_value)
+				SELECT gen_random_uuid(), content_id, ?, `+number+`::double precision FROM properties
+				WHERE content_id IS NOT NULL AND `+number+` IS NOT NULL
+				ON CONFLICT (content_id, attribute_id) DO NOTHING`, attribute.ID).Error
+			if err != nil {
+				return err
+			}
+
+			var skipped int64
+			err = tx.Raw(`SELECT COUNT(*) FROM properties
+				WHERE ` + column + ` IS NOT NULL AND TRIM(` + column + `) <> '' AND ` + number + ` IS NULL`).Scan(&skipped).Error
+			if err != nil {
+				return err
+			}
+			if skipped > 0 {
+				log.Printf("%d non-numeric values of properties.%s were not migrated, they remain in legacy_properties", skipped, column)
+			}
+		}
+
+		// Стару таблицю зберігаємо під іншим ім'ям на випадок відкату
+		return tx.Migrator().RenameTable("properties", "legacy_properties")
+	})
+}
+
+// migrateAttributeCodeIndex замінює глобальну унікальність коду атрибута на унікальність у межах категорії.
+// Глобальні атрибути (category_id IS NULL) теж не можуть мати однакових кодів
+func migrateAttributeCodeIndex(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&property.Attribute{}, "idx_attributes_code") {
+		var unique bool
+		err := db.Raw(`SELECT indisunique FROM pg_index WHERE indexrelid = 'idx_attributes_code'::regclass`).Scan(&unique).Error
+		if err != nil {
+			return err
+		}
+		if unique {
+			if err = db.Exec(`DROP INDEX idx_attributes_code`).Error; err != nil {
+				return err
+			}
+			if err = db.Exec(`CREATE INDEX idx_attributes_code ON attributes (code)`).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_attributes_category_code
+		ON attributes (COALESCE(category_id, '00000000-0000-0000-0000-000000000000'::uuid), code)`).Error
+}
+
+// failInterruptedImportJobs позначає імпорти, перервані перезапуском сервера
+func failInterruptedImportJobs(db *gorm.DB) error {
+	return db.Model(&item.ImportJob{}).
+		Where("status IN ?", []item.ImportStatus{item.ImportPending, item.ImportRunning}).
+		Updates(map[string]interface{}{
+			"status":      item.ImportFailed,
+			"error":       "interrupted by server restart",
+			"finished_at": time.Now(),
+		}).Error
+}
+
+// migratePublicationState переносить старий прапорець status у стан публікації
+func migratePublicationState(db *gorm.DB, table string) error {
+	dataType, err := columnType(db, table, "status")
+	if err != nil || dataType != "boolean" {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE ` + table + ` SET state = 'published', publish_at = COALESCE(publish_at, created_at)
+			WHERE status = true AND state = 'draft'`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`ALTER TABLE ` + table + ` DROP COLUMN status`).Error
+	})
+}
+
+// renderBlogContent санітизує наявні тексти блогів, збережені до появи content_html
+func renderBlogContent(db *gorm.DB) error {
+	var blogs []blog.Blog
+	err := db.Select("id", "content", "content_format").
+		Where("content_html = '' AND content <> ''").
+		Find(&blogs).Error
+	if err != nil || len(blogs) == 0 {
+		return err
+	}
+
+	log.Printf("Rendering content of %d blogs", len(blogs))
+	for _, b := range blogs {
+		document, err := content.Render(b.Content, b.ContentFormat)
+		if err != nil {
+			return err
+		}
+		err = db.Model(&blog.Blog{}).Where("id = ?", b.ID).Updates(map[string]interface{}{
+			"content_html": document.HTML,
+			"excerpt":      document.Excerpt,
+			"reading_time": document.ReadingTime,
+			"toc":          document.TOC,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillSlugs генерує slug із заголовків для записів, створених до появи slug
+func backfillSlugs(db *gorm.DB) error {
+	if err := backfillTableSlugs[item.Items](db); err != nil {
+		return err
+	}
+	return backfillTableSlugs[blog.Blog](db)
+}
+
+func backfillTableSlugs[T any](db *gorm.DB) error {
+	var rows []struct {
+		ID       uuid.UUID
+		Title    string
+		Language string
+	}
+	err := db.Model(new(T)).Select("id", "title", "language").
+		Where("slug IS NULL").
+		Order("created_at ASC").
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+
+	log.Printf("Generating slugs for %d records", len(rows))
+	for _, row := range rows {
+		slug, err := repository.UniqueSlug[T](db, utils.Slugify(row.Title), row.Language, row.ID)
+		if err != nil {
+			return err
+		}
+		if err = db.Model(new(T)).Where("id = ?", row.ID).Update("slug", slug).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,10 +4,20 @@ import (
 	"backend/internal/entities"
 	blog "backend/modules/blog/models"
 	calendar "backend/modules/calendar/models"
+	category "backend/modules/category/models"
+	comment "backend/modules/comment/models"
+	contact "backend/modules/contact/models"
+	document "backend/modules/document/models"
 	item "backend/modules/item/models"
 	media "backend/modules/media/models"
+	notification "backend/modules/notification/models"
+	order "backend/modules/order/models"
+	payment "backend/modules/payment/models"
 	property "backend/modules/property/models"
+	tag "backend/modules/tag/models"
+	task "backend/modules/task/models"
 	user "backend/modules/user/models"
+	webhook "backend/modules/webhook/models"
 
 	"fmt"
 	"log"
@@ -25,16 +35,81 @@ func InitAdminDB() {
 	log.Println("Successfully connected to the database")
 	db := GetDB()
 
+	// Ручні міграції, які мають виконатися до AutoMigrate
+	err = migrateItemPricesToMinorUnits(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate item prices: %v", err)
+	}
+
 	// Виконання міграцій для таблиць
 	err = db.AutoMigrate(
 		&user.User{},
 		&calendar.Calendar{},
 		&blog.Blog{},
 		&media.Media{},
+		&category.Category{},
+		&category.CategoryName{},
 		&item.Items{},
-		&property.Property{}, &entities.LoginAttempt{})
+		&item.StockMovement{},
+		&item.ItemPrice{},
+		&item.ImportJob{},
+		&property.Attribute{},
+		&property.PropertyValue{}, &entities.LoginAttempt{}, &entities.Revision{}, &entities.SlugRedirect{},
+		&tag.Tag{}, &tag.ContentTag{}, &comment.Comment{},
+		&order.Cart{}, &order.CartLine{}, &order.Order{}, &order.OrderLine{}, &order.OrderEvent{},
+		&payment.Payment{}, &payment.PaymentRefund{}, &payment.PaymentEvent{},
+		&document.Document{}, &document.DocumentSequence{}, &document.CompanySettings{},
+		&contact.Contact{}, &contact.ContactAddress{}, &contact.ContactNote{}, &contact.ContactEvent{}, &contact.ContactOrder{},
+		&task.TaskBoard{}, &task.TaskBoardMember{}, &task.TaskColumn{}, &task.TaskCard{}, &task.TaskCardAssignee{},
+		&task.TaskLabel{}, &task.TaskCardLabel{}, &task.TaskChecklistItem{}, &task.TaskCardEvent{},
+		&notification.Notification{}, &notification.NotificationPreference{},
+		&webhook.WebhookEndpoint{}, &webhook.WebhookDelivery{}, &entities.OutboxEvent{})
 	if err != nil {
 		log.Fatalf("Failed to migrate: %v", err)
 	}
+
+	// Міграції даних, які потребують нових таблиць
+	err = migrateItemCategories(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate item categories: %v", err)
+	}
+
+	err = migrateLegacyProperties(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate properties: %v", err)
+	}
+
+	err = migrateAttributeCodeIndex(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate attribute code index: %v", err)
+	}
+
+	err = backfillTranslationGroups(db)
+	if err != nil {
+		log.Fatalf("Failed to backfill translation groups: %v", err)
+	}
+
+	for _, table := range []string{"items", "blogs"} {
+		err = migratePublicationState(db, table)
+		if err != nil {
+			log.Fatalf("Failed to migrate publication state of %s: %v", table, err)
+		}
+	}
+
+	err = failInterruptedImportJobs(db)
+	if err != nil {
+		log.Fatalf("Failed to update import jobs: %v", err)
+	}
+
+	err = renderBlogContent(db)
+	if err != nil {
+		log.Fatalf("Failed to render blog content: %v", err)
+	}
+
+	err = backfillSlugs(db)
+	if err != nil {
+		log.Fatalf("Failed to generate slugs: %v", err)
+	}
+
 	fmt.Println("Successfully migrated the database")
 }
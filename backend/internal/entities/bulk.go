@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math/big"
+	"slices"
+)
+
+type BulkOperation string
+
+const (
+	BulkSetState    BulkOperation = "set_state"
+	BulkSetCategory BulkOperation = "set_category"
+	BulkSetLanguage BulkOperation = "set_language"
+	BulkDelete      BulkOperation = "delete"
+	BulkShiftPrice  BulkOperation = "shift_price"
+)
+
+// Максимальна кількість записів в одному масовому запиті
+const BulkMaxItems = 1000
+
+// BulkFilter вибирає записи замість явного списку ID
+type BulkFilter struct {
+	Language   string              `json:"language"`
+	CategoryID *uuid.UUID          `json:"category_id"`
+	State      PublicationState    `json:"state"`
+	Attributes map[string][]string `json:"attributes"`
+}
+
+type BulkRequest struct {
+	IDs       []uuid.UUID   `json:"ids"`
+	Filter    *BulkFilter   `json:"filter"`
+	Operation BulkOperation `json:"operation" binding:"required"`
+	// Параметри операцій
+	State      *PublicationState `json:"state"`
+	CategoryID *uuid.UUID        `json:"category_id"`
+	Language   string            `json:"language"`
+	Percent    json.Number       `json:"percent"`
+	// Atomic — будь-яка помилка скасовує весь запит
+	Atomic bool `json:"atomic"`
+}
+
+type BulkResult struct {
+	ID      uuid.UUID `json:"id"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	Results   []BulkResult `json:"results"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+}
+
+// Add записує результат для одного ID
+func (r *BulkResponse) Add(id uuid.UUID, err error) {
+	result := BulkResult{ID: id, Success: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+		r.Failed++
+	} else {
+		r.Succeeded++
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Validate перевіряє, що операція підтримується і має потрібні параметри
+func (r *BulkRequest) Validate(supported ...BulkOperation) error {
+	if !slices.Contains(supported, r.Operation) {
+		return fmt.Errorf("unsupported operation %q", r.Operation)
+	}
+	if len(r.IDs) == 0 && r.Filter == nil {
+		return errors.New("either ids or filter is required")
+	}
+	if len(r.IDs) > 0 && r.Filter != nil {
+		return errors.New("use either ids or filter, not both")
+	}
+	if len(r.IDs) > BulkMaxItems {
+		return fmt.Errorf("at most %d ids are allowed", BulkMaxItems)
+	}
+
+	switch r.Operation {
+	case BulkSetState:
+		if r.State == nil || !r.State.Valid() {
+			return errors.New("a valid state is required")
+		}
+	case BulkSetCategory:
+		if r.CategoryID == nil {
+			return errors.New("category_id is required")
+		}
+	case BulkSetLanguage:
+		if r.Language == "" {
+			return errors.New("language is required")
+		}
+	case BulkShiftPrice:
+		if _, err := r.PriceFactor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PriceFactor повертає множник ціни: percent = -10 → 0.9
+func (r *BulkRequest) PriceFactor() (*big.Rat, error) {
+	percent, ok := new(big.Rat).SetString(string(r.Percent))
+	if !ok {
+		return nil, errors.New("percent must be a number")
+	}
+	factor := new(big.Rat).Add(big.NewRat(1, 1), percent.Quo(percent, big.NewRat(100, 1)))
+	if factor.Sign() <= 0 {
+		return nil, errors.New("percent must be greater than -100")
+	}
+	return factor, nil
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// ContentFormat — формат вихідного тексту контенту
+type ContentFormat string
+
+const (
+	ContentMarkdown ContentFormat = "markdown"
+	ContentHTML     ContentFormat = "html"
+)
+
+func (f ContentFormat) Valid() bool {
+	return f == ContentMarkdown || f == ContentHTML
+}
+
+// Heading — пункт змісту, побудований із заголовків тексту
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// Headings — зміст, що зберігається в колонці jsonb
+type Headings []Heading
+
+func (h Headings) Value() (driver.Value, error) {
+	if h == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]Heading(h))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (h *Headings) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]Heading)(h))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]Heading)(h))
+	}
+	return errors.New("unsupported type for Headings column")
+}
+
+func (Headings) GormDataType() string {
+	return "jsonb"
+}
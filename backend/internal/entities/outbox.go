@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxDone    OutboxStatus = "done"
+	OutboxFailed  OutboxStatus = "failed"
+)
+
+// OutboxEvent — доменна подія для одного асинхронного обробника. Рядок пишеться в тій самій транзакції,
+// що й зміна, тож обробник спрацює лише після коміту і принаймні один раз
+type OutboxEvent struct {
+	ID            uuid.UUID    `gorm:"type:uuid;primaryKey" json:"id"`
+	Name          string       `gorm:"type:varchar(100);not null" json:"name"`
+	Handler       string       `gorm:"type:varchar(100);not null" json:"handler"`
+	Payload       JSON         `gorm:"type:jsonb;not null" json:"payload"`
+	Status        OutboxStatus `gorm:"type:varchar(16);not null;index:idx_outbox_events_due,priority:1" json:"status"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time    `gorm:"not null;index:idx_outbox_events_due,priority:2" json:"next_attempt_at"`
+	LastError     string       `gorm:"type:text" json:"last_error"`
+	ProcessedAt   *time.Time   `gorm:"index" json:"processed_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+func (event *OutboxEvent) BeforeCreate(*gorm.DB) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	return nil
+}
@@ -1,14 +1,30 @@
 package entities
 
+import "github.com/google/uuid"
+
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
 type Parameters struct {
-	Language string
-	Skip     int
-	Limit    int
+	Language   string
+	Skip       int
+	Limit      int
+	CategoryID uuid.UUID
+	State      PublicationState
+	Attributes map[string][]string
+	// Діапазон ціни в мінорних одиницях валюти Currency
+	MinPrice *int64
+	MaxPrice *int64
+	Currency string
+	// Коди атрибутів, для яких рахуються фасети, та межі цінових діапазонів
+	WithFacets   bool
+	Facets       []string
+	PriceBuckets []int64
+	// Slug тегів; без TagsMatchAny запис має містити всі теги
+	Tags         []string
+	TagsMatchAny bool
 }
 
 type EmailConfig struct {
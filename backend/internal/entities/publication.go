@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type PublicationState string
+
+const (
+	StateDraft     PublicationState = "draft"
+	StateScheduled PublicationState = "scheduled"
+	StatePublished PublicationState = "published"
+	StateArchived  PublicationState = "archived"
+)
+
+// Дозволені переходи між станами публікації
+var publicationTransitions = map[PublicationState][]PublicationState{
+	StateDraft:     {StateScheduled, StatePublished, StateArchived},
+	StateScheduled: {StateDraft, StatePublished, StateArchived},
+	StatePublished: {StateDraft, StateArchived},
+	StateArchived:  {StateDraft},
+}
+
+var ErrInvalidTransition = errors.New("invalid publication state transition")
+
+func (s PublicationState) Valid() bool {
+	_, ok := publicationTransitions[s]
+	return ok
+}
+
+func (s PublicationState) CanTransition(to PublicationState) bool {
+	return s == to || slices.Contains(publicationTransitions[s], to)
+}
+
+// Publication — стан публікації контенту; вбудовується в моделі блогів і товарів
+type Publication struct {
+	State       PublicationState `gorm:"type:varchar(16);not null;default:'draft';index" json:"state"`
+	PublishAt   *time.Time       `gorm:"index" json:"publish_at"`
+	UnpublishAt *time.Time       `gorm:"index" json:"unpublish_at"`
+}
+
+// PublicationUpdate — зміна стану та розкладу; відсутні поля не змінюються
+type PublicationUpdate struct {
+	State       *PublicationState `json:"state"`
+	PublishAt   *time.Time        `json:"publish_at"`
+	UnpublishAt *time.Time        `json:"unpublish_at"`
+}
+
+func (p *Publication) IsPublished() bool {
+	return p.State == StatePublished
+}
+
+// Init перевіряє стан нового запису; без стану запис стає чернеткою
+func (p *Publication) Init(now time.Time) error {
+	state := p.State
+	if state == "" {
+		state = StateDraft
+	}
+	p.State = StateDraft
+	return p.Apply(&PublicationUpdate{State: &state}, now)
+}
+
+// Apply змінює розклад і стан з перевіркою переходу
+func (p *Publication) Apply(update *PublicationUpdate, now time.Time) error {
+	next := *p
+	if update.PublishAt != nil {
+		next.PublishAt = update.PublishAt
+	}
+	if update.UnpublishAt != nil {
+		next.UnpublishAt = update.UnpublishAt
+	}
+
+	if update.State != nil {
+		to := *update.State
+		if !to.Valid() {
+			return fmt.Errorf("unknown publication state %q", to)
+		}
+		if !p.State.CanTransition(to) {
+			return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, p.State, to)
+		}
+		next.State = to
+	}
+
+	switch next.State {
+	case StateScheduled:
+		if next.PublishAt == nil || !next.PublishAt.After(now) {
+			return errors.New("scheduled publication requires publish_at in the future")
+		}
+	case StatePublished:
+		// Запис, опублікований вручну, отримує фактичну дату публікації
+		if p.State != StatePublished && (next.PublishAt == nil || next.PublishAt.After(now)) {
+			next.PublishAt = &now
+		}
+	case StateDraft:
+		// Повернення в чернетку скасовує розклад
+		if p.State != StateDraft {
+			next.PublishAt = nil
+			next.UnpublishAt = nil
+		}
+	}
+
+	if next.PublishAt != nil && next.UnpublishAt != nil && !next.UnpublishAt.After(*next.PublishAt) {
+		return errors.New("unpublish_at must be after publish_at")
+	}
+
+	*p = next
+	return nil
+}
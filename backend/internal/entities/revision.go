@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Revision — знімок стану запису після збереження
+type Revision struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	EntityType    string     `gorm:"type:varchar(32);not null;uniqueIndex:idx_revisions_entity_number" json:"entity_type"`
+	EntityID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_revisions_entity_number" json:"entity_id"`
+	Number        int        `gorm:"not null;uniqueIndex:idx_revisions_entity_number" json:"number"`
+	Snapshot      JSON       `gorm:"not null" json:"snapshot"`
+	ChangedFields StringList `json:"changed_fields"`
+	Comment       string     `json:"comment"`
+	AuthorID      uuid.UUID  `gorm:"type:uuid;index" json:"author_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (revision *Revision) BeforeCreate(*gorm.DB) error {
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+	return nil
+}
+
+// RevisionGet — ревізія без знімка для списку історії
+type RevisionGet struct {
+	Number        int        `json:"number"`
+	ChangedFields StringList `json:"changed_fields"`
+	Comment       string     `json:"comment"`
+	AuthorID      uuid.UUID  `json:"author_id"`
+	AuthorName    string     `json:"author_name"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// FieldChange — різниця одного поля між двома ревізіями
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MetaTitleMaxLength       = 255
+	MetaDescriptionMaxLength = 500
+)
+
+// SEO — метадані сторінки; вбудовується в моделі блогів і товарів
+type SEO struct {
+	MetaTitle       string `gorm:"type:varchar(255);not null;default:''" json:"meta_title"`
+	MetaDescription string `gorm:"type:varchar(500);not null;default:''" json:"meta_description"`
+	OGImage         string `gorm:"not null;default:''" json:"og_image"`
+}
+
+// SEOUpdate — зміна метаданих; відсутні поля не змінюються, порожній рядок очищає поле
+type SEOUpdate struct {
+	MetaTitle       *string `json:"meta_title"`
+	MetaDescription *string `json:"meta_description"`
+	OGImage         *string `json:"og_image"`
+}
+
+func (s *SEO) Validate() error {
+	s.MetaTitle = strings.TrimSpace(s.MetaTitle)
+	s.MetaDescription = strings.TrimSpace(s.MetaDescription)
+	s.OGImage = strings.TrimSpace(s.OGImage)
+
+	if utf8.RuneCountInString(s.MetaTitle) > MetaTitleMaxLength {
+		return errors.New("meta_title is too long")
+	}
+	if utf8.RuneCountInString(s.MetaDescription) > MetaDescriptionMaxLength {
+		return errors.New("meta_description is too long")
+	}
+	if s.OGImage != "" {
+		parsed, err := url.Parse(s.OGImage)
+		if err != nil || parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Host == "" {
+			return errors.New("og_image must be an absolute http(s) URL")
+		}
+	}
+	return nil
+}
+
+func (s *SEO) Apply(update *SEOUpdate) error {
+	next := *s
+	if update.MetaTitle != nil {
+		next.MetaTitle = *update.MetaTitle
+	}
+	if update.MetaDescription != nil {
+		next.MetaDescription = *update.MetaDescription
+	}
+	if update.OGImage != nil {
+		next.OGImage = *update.OGImage
+	}
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	*s = next
+	return nil
+}
+
+// SlugRedirect — попередній slug запису; старі посилання ведуть на актуальну адресу
+type SlugRedirect struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	EntityTable string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_slug_redirects_slug" json:"entity_table"`
+	Language    string    `gorm:"not null;uniqueIndex:idx_slug_redirects_slug" json:"language"`
+	Slug        string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_slug_redirects_slug" json:"slug"`
+	EntityID    uuid.UUID `gorm:"type:uuid;not null;index" json:"entity_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (redirect *SlugRedirect) BeforeCreate(*gorm.DB) error {
+	if redirect.ID == uuid.Nil {
+		redirect.ID = uuid.New()
+	}
+	return nil
+}
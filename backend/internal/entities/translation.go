@@ -0,0 +1,27 @@
+package entities
+
+import "github.com/google/uuid"
+
+type TranslationGet struct {
+	ID       uuid.UUID        `json:"id"`
+	Language string           `json:"language"`
+	Title    string           `json:"title"`
+	State    PublicationState `json:"state"`
+}
+
+type TranslationPost struct {
+	Language string `json:"language" binding:"required"`
+	Title    string `json:"title" binding:"required"`
+	Content  string `json:"content"`
+	Position *int   `json:"position"`
+	// Без стану переклад створюється чернеткою
+	State PublicationState `json:"state"`
+}
+
+// MissingTranslation — група контенту, якій бракує перекладів на увімкнені мови
+type MissingTranslation struct {
+	TranslationGroupID uuid.UUID `json:"translation_group_id"`
+	Title              string    `json:"title"`
+	Languages          []string  `json:"languages"`
+	Missing            []string  `json:"missing"`
+}
@@ -29,6 +29,59 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 		c.Set("id", claims.ID)
 		c.Set("email", claims.Email)
+		touchPresence(claims.ID)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware впізнає користувача за токеном, якщо він є, і пропускає анонімні запити
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		claims, err := utils.ParseJWTToken(tokenString)
+		if tokenString == authHeader || err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+		c.Set("id", claims.ID)
+		c.Set("email", claims.Email)
+		touchPresence(claims.ID)
+		c.Next()
+	}
+}
+
+// StreamAuthMiddleware — як AuthMiddleware, але приймає токен і з параметра token:
+// EventSource у браузері не вміє надсилати заголовок Authorization
+func StreamAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.Query("token")
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			tokenString = strings.TrimPrefix(authHeader, "Bearer ")
+			if tokenString == authHeader {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header must start with Bearer"})
+				c.Abort()
+				return
+			}
+		}
+		if tokenString == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization token"})
+			c.Abort()
+			return
+		}
+		claims, err := utils.ParseJWTToken(tokenString)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+		c.Set("id", claims.ID)
+		c.Set("email", claims.Email)
 		c.Next()
 	}
 }
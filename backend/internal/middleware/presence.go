@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/presence"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+// touchPresence відмічає активність користувача; LastSeenAt у базі оновлюється з обмеженням частоти,
+// у фоні, щоб не затримувати запит
+func touchPresence(userId uuid.UUID) {
+	if !presence.Default.Touch(userId) {
+		return
+	}
+	go func() {
+		err := postgres.DB.Table("users").Where("id = ?", userId).UpdateColumn("last_seen_at", time.Now()).Error
+		if err != nil {
+			log.Printf("⚠️ Cannot update last_seen_at for %s: %v", userId, err)
+		}
+	}()
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type rateWindow struct {
+	count int
+	reset time.Time
+}
+
+// RateLimiter обмежує кількість запитів з однієї IP-адреси за фіксоване вікно.
+// Лічильники зберігаються в пам'яті, тож ліміт діє окремо для кожного інстансу.
+func RateLimiter(limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	windows := make(map[string]*rateWindow)
+	nextSweep := time.Now().Add(window)
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// Періодично прибираємо завершені вікна, щоб мапа не росла
+		if now.After(nextSweep) {
+			for key, w := range windows {
+				if now.After(w.reset) {
+					delete(windows, key)
+				}
+			}
+			nextSweep = now.Add(window)
+		}
+
+		w, ok := windows[ip]
+		if !ok || now.After(w.reset) {
+			w = &rateWindow{reset: now.Add(window)}
+			windows[ip] = w
+		}
+		w.count++
+		count, reset := w.count, w.reset
+		mu.Unlock()
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(max(limit-count, 0)))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if count > limit {
+			retryAfter := int(math.Ceil(reset.Sub(now).Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+		c.Next()
+	}
+}
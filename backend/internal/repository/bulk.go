@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrBulkAccessDenied = errors.New("access denied")
+
+// errBulkRollback скасовує атомарний масовий запит, якщо хоча б один ID не оброблено
+var errBulkRollback = errors.New("bulk operation rolled back")
+
+// RunBulk виконує масову операцію в одній транзакції; кожен ID — окрема точка збереження.
+// resolve вибирає ID за фільтром, якщо їх не передано явно.
+func RunBulk(db *gorm.DB, request *entities.BulkRequest, resolve func(tx *gorm.DB) ([]uuid.UUID, error), apply func(tx *gorm.DB, id uuid.UUID) error) (*entities.BulkResponse, error) {
+	response := &entities.BulkResponse{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		ids := request.IDs
+		if request.Filter != nil {
+			var err error
+			if ids, err = resolve(tx); err != nil {
+				return err
+			}
+		}
+
+		for _, id := range ids {
+			err := tx.Transaction(func(itemTx *gorm.DB) error {
+				return apply(itemTx, id)
+			})
+			response.Add(id, err)
+		}
+
+		if request.Atomic && response.Failed > 0 {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if errors.Is(err, errBulkRollback) {
+		// Звіт показує, які саме ID завадили виконанню
+		for i := range response.Results {
+			if response.Results[i].Success {
+				response.Results[i].Success = false
+				response.Results[i].Error = errBulkRollback.Error()
+			}
+		}
+		response.Failed += response.Succeeded
+		response.Succeeded = 0
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// LimitBulkIDs перевіряє, що фільтр не вибрав забагато записів (запит має брати BulkMaxItems+1)
+func LimitBulkIDs(ids []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) > entities.BulkMaxItems {
+		return nil, errors.New("the filter matches too many records")
+	}
+	return ids, nil
+}
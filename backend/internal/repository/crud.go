@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"reflect"
 )
 
+var ErrInvalidPosition = errors.New("position cannot be negative")
+
 func CreateEssence[T any](db *gorm.DB, model *T) error {
 	if idField := reflect.ValueOf(model).Elem().FieldByName("ID"); idField.IsValid() && idField.CanSet() {
 		if idField.Interface() == uuid.Nil {
@@ -86,10 +89,18 @@ func DeleteContentByID[T any](db *gorm.DB, id uuid.UUID, model *T) error {
 
 // ShiftPositions зміщує всі записи вперед, якщо нова позиція вже зайнята
 func ShiftPositions[T any](db *gorm.DB, newPosition int, language string) error {
+	return ShiftPositionsBy[T](db, newPosition, "language", language)
+}
+
+// ShiftPositionsBy — те саме для записів, упорядкованих у межах іншої групи (колонки, дошки);
+// column задається кодом, а не користувачем
+func ShiftPositionsBy[T any](db *gorm.DB, newPosition int, column string, value any) error {
 	var items []T
+	// Окрема сесія: інакше умови вибірки потрапляють у збереження, якщо db — ланцюжок (tx.Omit(...))
+	db = db.Session(&gorm.Session{})
 
-	// Вибираємо елементи з позицією >= newPosition та з конкретною мовою
-	err := db.Where("position >= ? AND language = ?", newPosition, language).
+	// Вибираємо елементи з позицією >= newPosition у тій самій групі
+	err := db.Where("position >= ? AND "+column+" = ?", newPosition, value).
 		Order("position ASC").
 		Find(&items).Error
 	if err != nil {
@@ -111,7 +122,7 @@ func ShiftPositions[T any](db *gorm.DB, newPosition int, language string) error
 		}
 	}
 
-	// Оновлюємо позиції лише вибраних товарів конкретної мови
+	// Оновлюємо позиції лише вибраних записів групи
 	for _, item := range items {
 		if err := db.Save(&item).Error; err != nil {
 			return fmt.Errorf("failed to update position: %v", err)
@@ -120,3 +131,22 @@ func ShiftPositions[T any](db *gorm.DB, newPosition int, language string) error
 
 	return nil
 }
+
+// PlaceBy повертає позицію нового чи переміщеного запису в групі: задану — зі зсувом наступних
+// записів, як у ShiftPositions, — або наступну після останньої
+func PlaceBy[T any](tx *gorm.DB, position *int, column string, value any) (int, error) {
+	if position == nil {
+		var last *int
+		if err := tx.Model(new(T)).Where(column+" = ?", value).Select("MAX(position)").Scan(&last).Error; err != nil {
+			return 0, err
+		}
+		if last == nil {
+			return 0, nil
+		}
+		return *last + 1, nil
+	}
+	if *position < 0 {
+		return 0, ErrInvalidPosition
+	}
+	return *position, ShiftPositionsBy[T](tx.Omit(clause.Associations), *position, column, value)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// IsUniqueViolation повідомляє, що запис не збережено через унікальний індекс
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+const maxSlugLength = 200
+
+var (
+	ErrSlugTaken   = errors.New("slug is already taken")
+	ErrInvalidSlug = errors.New("slug must contain letters or digits")
+)
+
+// CheckSlugAvailable перевіряє, що заданий користувачем slug (вже після utils.Slugify) вільний у мові
+func CheckSlugAvailable[T any](db *gorm.DB, slug string, language string, exceptId uuid.UUID) (string, error) {
+	slug = truncateSlug(slug)
+	if slug == "" {
+		return "", ErrInvalidSlug
+	}
+	taken, err := slugTaken[T](db, slug, language, exceptId)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return "", ErrSlugTaken
+	}
+	return slug, nil
+}
+
+// UniqueSlug повертає вільний slug на основі base, додаючи суфікс -2, -3... при збігу
+func UniqueSlug[T any](db *gorm.DB, base string, language string, exceptId uuid.UUID) (string, error) {
+	base = truncateSlug(base)
+	if base == "" {
+		base = "untitled"
+	}
+
+	for i := 1; ; i++ {
+		slug := base
+		if i > 1 {
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken, err := slugTaken[T](db, slug, language, exceptId)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+	}
+}
+
+// SaveSlugRedirect запам'ятовує попередній slug запису
+func SaveSlugRedirect[T any](db *gorm.DB, entityId uuid.UUID, language string, slug string) error {
+	if slug == "" {
+		return nil
+	}
+	table, err := tableName[T](db)
+	if err != nil {
+		return err
+	}
+
+	redirect := &entities.SlugRedirect{EntityTable: table, Language: language, Slug: slug, EntityID: entityId}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_table"}, {Name: "language"}, {Name: "slug"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"entity_id": entityId, "created_at": time.Now()}),
+	}).Create(redirect).Error
+}
+
+// FindBySlug шукає запис за актуальним slug, а потім за історією.
+// Повертає true, якщо запис знайдено за старим slug і клієнта треба перенаправити.
+func FindBySlug[T any](db *gorm.DB, language string, slug string, out *T) (bool, error) {
+	err := db.Where("slug = ? AND language = ?", slug, language).First(out).Error
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	table, err := tableName[T](db)
+	if err != nil {
+		return false, err
+	}
+	var redirect entities.SlugRedirect
+	err = db.Where("entity_table = ? AND language = ? AND slug = ?", table, language, slug).First(&redirect).Error
+	if err != nil {
+		return false, err
+	}
+	if err = db.Where("id = ?", redirect.EntityID).First(out).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func DeleteSlugRedirects[T any](db *gorm.DB, entityId uuid.UUID) error {
+	table, err := tableName[T](db)
+	if err != nil {
+		return err
+	}
+	return db.Where("entity_table = ? AND entity_id = ?", table, entityId).Delete(&entities.SlugRedirect{}).Error
+}
+
+func slugTaken[T any](db *gorm.DB, slug string, language string, exceptId uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(new(T)).
+		Where("slug = ? AND language = ? AND id <> ?", slug, language, exceptId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func tableName[T any](db *gorm.DB) (string, error) {
+	statement := &gorm.Statement{DB: db}
+	if err := statement.Parse(new(T)); err != nil {
+		return "", err
+	}
+	return statement.Table, nil
+}
+
+// truncateSlug обрізає slug по дефісу, щоб лишилось місце для суфікса
+func truncateSlug(slug string) string {
+	if len(slug) <= maxSlugLength {
+		return slug
+	}
+	slug = slug[:maxSlugLength]
+	if dash := strings.LastIndexByte(slug, '-'); dash > 0 {
+		slug = slug[:dash]
+	}
+	return slug
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+)
+
+var ErrTranslationExists = errors.New("a translation for this language already exists")
+
+// GetTranslations повертає всі мовні версії з однієї групи перекладів
+func GetTranslations[T any](db *gorm.DB, groupId uuid.UUID) ([]entities.TranslationGet, error) {
+	var translations []entities.TranslationGet
+	err := db.Model(new(T)).
+		Select("id, language, title, state").
+		Where("translation_group_id = ?", groupId).
+		Order("language ASC").
+		Scan(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// CheckTranslationAvailable перевіряє, що в групі ще немає версії для мови (крім exceptId)
+func CheckTranslationAvailable[T any](db *gorm.DB, groupId uuid.UUID, language string, exceptId uuid.UUID) error {
+	var count int64
+	err := db.Model(new(T)).
+		Where("translation_group_id = ? AND language = ? AND id <> ?", groupId, language, exceptId).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTranslationExists
+	}
+	return nil
+}
+
+// CountTranslations рахує інші мовні версії в групі
+func CountTranslations[T any](db *gorm.DB, groupId uuid.UUID, exceptId uuid.UUID) (int64, error) {
+	var count int64
+	err := db.Model(new(T)).
+		Where("translation_group_id = ? AND id <> ?", groupId, exceptId).
+		Count(&count).Error
+	return count, err
+}
+
+// FindMissingTranslations будує звіт про групи без перекладів на увімкнені мови.
+// query має бути вже обмежений моделлю та, за потреби, власником.
+func FindMissingTranslations(query *gorm.DB, languages []string) ([]entities.MissingTranslation, error) {
+	var rows []struct {
+		TranslationGroupID uuid.UUID
+		Language           string
+		Title              string
+	}
+	err := query.Select("translation_group_id, language, title").
+		Order("translation_group_id, language").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[uuid.UUID]*entities.MissingTranslation)
+	var order []uuid.UUID
+	for _, row := range rows {
+		group, ok := groups[row.TranslationGroupID]
+		if !ok {
+			group = &entities.MissingTranslation{TranslationGroupID: row.TranslationGroupID, Title: row.Title}
+			groups[row.TranslationGroupID] = group
+			order = append(order, row.TranslationGroupID)
+		}
+		group.Languages = append(group.Languages, row.Language)
+	}
+
+	report := make([]entities.MissingTranslation, 0)
+	for _, groupId := range order {
+		group := groups[groupId]
+		sort.Strings(group.Languages)
+		group.Missing = MissingLanguages(group.Languages, languages)
+		if len(group.Missing) > 0 {
+			report = append(report, *group)
+		}
+	}
+	return report, nil
+}
+
+// MissingLanguages повертає мови з languages, яких немає серед наявних перекладів, у порядку languages
+func MissingLanguages(present []string, languages []string) []string {
+	var missing []string
+	for _, language := range languages {
+		if !contains(present, language) {
+			missing = append(missing, language)
+		}
+	}
+	return missing
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"backend/internal/services/events"
+	"gorm.io/gorm"
+	"log"
+)
+
+// Довші тіла подій (наприклад, повний товар) у журналі обрізаються
+const maxPayload = 2048
+
+// Register записує в журнал кожну доменну подію після коміту транзакції, що її опублікувала
+func Register(bus *events.Bus) {
+	bus.Observe("audit.log", func(db *gorm.DB, name string, payload []byte) error {
+		if len(payload) > maxPayload {
+			payload = append(payload[:maxPayload:maxPayload], "…"...)
+		}
+		log.Printf("📝 [audit] %s %s", name, payload)
+		return nil
+	})
+}
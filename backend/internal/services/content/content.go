@@ -0,0 +1,185 @@
+package content
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/utils"
+	"fmt"
+	"golang.org/x/net/html"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	excerptLength  = 200
+	wordsPerMinute = 200
+)
+
+// Document — результат обробки тексту, готовий до збереження
+type Document struct {
+	HTML        string
+	Excerpt     string
+	ReadingTime int
+	TOC         entities.Headings
+}
+
+// ParseFormat повертає формат тексту; порожнє значення означає HTML
+func ParseFormat(value string) (entities.ContentFormat, error) {
+	format := entities.ContentFormat(strings.ToLower(strings.TrimSpace(value)))
+	if format == "" {
+		return entities.ContentHTML, nil
+	}
+	if !format.Valid() {
+		return "", fmt.Errorf("unsupported content format %q", value)
+	}
+	return format, nil
+}
+
+// Render перетворює Markdown або HTML на безпечний HTML і рахує витяг, час читання та зміст
+func Render(source string, format entities.ContentFormat) (*Document, error) {
+	if format == entities.ContentMarkdown {
+		source = Markdown(source)
+	} else if format != entities.ContentHTML {
+		return nil, fmt.Errorf("unsupported content format %q", format)
+	}
+
+	nodes, err := sanitize(source)
+	if err != nil {
+		return nil, err
+	}
+	toc := anchorHeadings(nodes)
+
+	var rendered strings.Builder
+	for _, node := range nodes {
+		if err = html.Render(&rendered, node); err != nil {
+			return nil, err
+		}
+	}
+
+	var all, body strings.Builder
+	for _, node := range nodes {
+		collectText(node, &all, &body, false)
+	}
+
+	return &Document{
+		HTML:        rendered.String(),
+		Excerpt:     excerpt(body.String(), excerptLength),
+		ReadingTime: readingTime(all.String()),
+		TOC:         toc,
+	}, nil
+}
+
+// anchorHeadings додає заголовкам унікальні id і повертає зміст у порядку появи
+func anchorHeadings(nodes []*html.Node) entities.Headings {
+	toc := entities.Headings{}
+	used := make(map[string]bool)
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if level := headingLevel(node); level > 0 {
+			text := strings.Join(strings.Fields(textOf(node)), " ")
+			if text == "" {
+				return
+			}
+			id := utils.Slugify(text)
+			if id == "" {
+				id = "section"
+			}
+			base := id
+			for i := 2; used[id]; i++ {
+				id = fmt.Sprintf("%s-%d", base, i)
+			}
+			used[id] = true
+
+			node.Attr = append(node.Attr, html.Attribute{Key: "id", Val: id})
+			toc = append(toc, entities.Heading{Level: level, ID: id, Text: text})
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return toc
+}
+
+func headingLevel(node *html.Node) int {
+	if node.Type != html.ElementNode || len(node.Data) != 2 || node.Data[0] != 'h' {
+		return 0
+	}
+	if level := int(node.Data[1] - '0'); level >= 1 && level <= 6 {
+		return level
+	}
+	return 0
+}
+
+func textOf(node *html.Node) string {
+	var b strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return b.String()
+}
+
+// collectText збирає весь текст для часу читання та текст без заголовків і коду для витягу
+func collectText(node *html.Node, all *strings.Builder, body *strings.Builder, skip bool) {
+	switch node.Type {
+	case html.TextNode:
+		all.WriteString(node.Data)
+		if !skip {
+			body.WriteString(node.Data)
+		}
+		return
+	case html.ElementNode:
+		if headingLevel(node) > 0 || node.Data == "pre" {
+			skip = true
+		}
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		collectText(child, all, body, skip)
+	}
+	// Блокові елементи розділяють слова
+	if node.Type == html.ElementNode && blockElements[node.Data] {
+		all.WriteByte(' ')
+		if !skip {
+			body.WriteByte(' ')
+		}
+	}
+}
+
+// excerpt обрізає текст до limit символів по межі слова
+func excerpt(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := limit
+	for cut > 0 && runes[cut] != ' ' {
+		cut--
+	}
+	if cut == 0 {
+		cut = limit
+	}
+	return strings.TrimRight(string(runes[:cut]), " ,.;:") + "…"
+}
+
+// readingTime повертає час читання у хвилинах; для непорожнього тексту щонайменше одна хвилина
+func readingTime(text string) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(words) / wordsPerMinute))
+}
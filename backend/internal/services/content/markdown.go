@@ -0,0 +1,277 @@
+package content
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Підтримується базовий Markdown: заголовки, абзаци, списки, цитати, блоки коду,
+// розділювачі, виділення, код, посилання та зображення. Сирий HTML у Markdown екранується.
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rulePattern        = regexp.MustCompile(`^ {0,3}((\*\s*){3,}|(-\s*){3,}|(_\s*){3,})$`)
+	unorderedPattern   = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedPattern     = regexp.MustCompile(`^\s{0,3}(\d{1,9})[.)]\s+(.*)$`)
+	fencePattern       = regexp.MustCompile("^\\s{0,3}(```|~~~)\\s*([\\w+-]*)")
+	blockquotePattern  = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	continuationIndent = regexp.MustCompile(`^(\s{2,}|\t)\S`)
+)
+
+// Markdown перетворює Markdown на HTML; результат потрібно пропускати через санітайзер
+func Markdown(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	renderBlocks(lines, &b)
+	return b.String()
+}
+
+func renderBlocks(lines []string, b *strings.Builder) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + inline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code")
+			if match[2] != "" {
+				b.WriteString(` class="language-` + html.EscapeString(match[2]) + `"`)
+			}
+			b.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+			continue
+		}
+
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			level := string(rune('0' + len(match[1])))
+			b.WriteString("<h" + level + ">" + inline(match[2]) + "</h" + level + ">\n")
+			continue
+		}
+
+		if rulePattern.MatchString(line) {
+			flush()
+			b.WriteString("<hr>\n")
+			continue
+		}
+
+		if blockquotePattern.MatchString(line) {
+			flush()
+			var quote []string
+			for ; i < len(lines); i++ {
+				match := blockquotePattern.FindStringSubmatch(lines[i])
+				if match == nil {
+					break
+				}
+				quote = append(quote, match[1])
+			}
+			i--
+			b.WriteString("<blockquote>\n")
+			renderBlocks(quote, b)
+			b.WriteString("</blockquote>\n")
+			continue
+		}
+
+		if unorderedPattern.MatchString(line) || orderedPattern.MatchString(line) {
+			flush()
+			i = renderList(lines, i, b) - 1
+			continue
+		}
+
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+	flush()
+}
+
+// renderList виводить список, що починається з рядка start, і повертає індекс першого рядка після нього
+func renderList(lines []string, start int, b *strings.Builder) int {
+	ordered := orderedPattern.MatchString(lines[start]) && !unorderedPattern.MatchString(lines[start])
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if number := orderedPattern.FindStringSubmatch(lines[start])[1]; strings.TrimLeft(number, "0") != "1" {
+			b.WriteString(`<ol start="` + strings.TrimLeft(number, "0") + `">` + "\n")
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+
+	var item []string
+	flush := func() {
+		if item != nil {
+			b.WriteString("<li>" + inline(strings.Join(item, "\n")) + "</li>\n")
+			item = nil
+		}
+	}
+
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		var text string
+		var isItem bool
+		if ordered {
+			if match := orderedPattern.FindStringSubmatch(line); match != nil {
+				text, isItem = match[2], true
+			}
+		} else if match := unorderedPattern.FindStringSubmatch(line); match != nil {
+			text, isItem = match[1], true
+		}
+
+		switch {
+		case isItem:
+			flush()
+			item = []string{strings.TrimSpace(text)}
+		case item != nil && continuationIndent.MatchString(line):
+			item = append(item, strings.TrimSpace(line))
+		default:
+			flush()
+			b.WriteString("</" + tag + ">\n")
+			return i
+		}
+	}
+	flush()
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// inline обробляє виділення, код, посилання та зображення в межах блоку
+func inline(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_[]()#+-.!>~", text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '\n':
+			b.WriteByte('\n')
+			i++
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case c == '!' && strings.HasPrefix(text[i+1:], "["):
+			if label, target, next, ok := parseLink(text, i+1); ok {
+				b.WriteString(`<img src="` + html.EscapeString(target) + `" alt="` + html.EscapeString(label) + `">`)
+				i = next
+				continue
+			}
+
+		case c == '[':
+			if label, target, next, ok := parseLink(text, i); ok {
+				b.WriteString(`<a href="` + html.EscapeString(target) + `">` + inline(label) + "</a>")
+				i = next
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if rendered, next, ok := emphasis(text, i); ok {
+				b.WriteString(rendered)
+				i = next
+				continue
+			}
+		}
+
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// emphasis розпізнає **жирний**, *курсив* і ~~закреслений~~ текст, що починається з позиції i
+func emphasis(text string, i int) (string, int, bool) {
+	c := text[i]
+	double := i+1 < len(text) && text[i+1] == c
+	if c == '~' && !double {
+		return "", 0, false
+	}
+	// Підкреслення всередині слова (snake_case) не вважається виділенням
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		return "", 0, false
+	}
+
+	delimiter := string(c)
+	tag := "em"
+	if double {
+		delimiter += string(c)
+		tag = "strong"
+		if c == '~' {
+			tag = "del"
+		}
+	}
+
+	start := i + len(delimiter)
+	if start >= len(text) || text[start] == ' ' {
+		return "", 0, false
+	}
+	end := strings.Index(text[start:], delimiter)
+	if end <= 0 || text[start+end-1] == ' ' {
+		return "", 0, false
+	}
+	end += start
+	if c == '_' && end+len(delimiter) < len(text) && isWordByte(text[end+len(delimiter)]) {
+		return "", 0, false
+	}
+
+	return "<" + tag + ">" + inline(text[start:end]) + "</" + tag + ">", end + len(delimiter), true
+}
+
+// parseLink читає [текст](адреса) з позиції відкривальної дужки
+func parseLink(text string, i int) (string, string, int, bool) {
+	depth := 0
+	closing := -1
+	for j := i; j < len(text); j++ {
+		if text[j] == '[' {
+			depth++
+		} else if text[j] == ']' {
+			depth--
+			if depth == 0 {
+				closing = j
+				break
+			}
+		}
+	}
+	if closing < 0 || closing+1 >= len(text) || text[closing+1] != '(' {
+		return "", "", 0, false
+	}
+	end := strings.IndexByte(text[closing+2:], ')')
+	if end < 0 {
+		return "", "", 0, false
+	}
+
+	target := strings.TrimSpace(text[closing+2 : closing+2+end])
+	// Необов'язковий заголовок посилання "title" відкидаємо
+	if space := strings.IndexAny(target, " \t"); space >= 0 {
+		target = target[:space]
+	}
+	target = strings.Trim(target, "<>")
+	return text[i+1 : closing], target, closing + 3 + end, true
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
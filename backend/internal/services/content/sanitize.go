@@ -0,0 +1,183 @@
+package content
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Дозволені теги та їхні атрибути; решта тегів розгортається до вмісту
+var allowedElements = map[string][]string{
+	"a":          {"href", "title"},
+	"b":          nil,
+	"blockquote": nil,
+	"br":         nil,
+	"code":       {"class"},
+	"del":        nil,
+	"em":         nil,
+	"figcaption": nil,
+	"figure":     nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"li":         nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"pre":        nil,
+	"s":          nil,
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"colspan", "rowspan"},
+	"th":         {"colspan", "rowspan"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+// Теги, які видаляються разом із вмістом
+var droppedElements = map[string]bool{
+	"button": true, "embed": true, "form": true, "frame": true, "frameset": true, "head": true,
+	"iframe": true, "input": true, "link": true, "math": true, "meta": true, "noscript": true,
+	"object": true, "script": true, "select": true, "style": true, "svg": true, "template": true,
+	"textarea": true, "title": true,
+}
+
+var blockElements = map[string]bool{
+	"blockquote": true, "br": true, "figcaption": true, "figure": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "hr": true, "li": true, "p": true, "pre": true,
+	"td": true, "th": true, "tr": true,
+}
+
+// Схеми посилань; відносні URL дозволені завжди
+var (
+	linkSchemes  = map[string]bool{"http": true, "https": true, "mailto": true}
+	imageSchemes = map[string]bool{"http": true, "https": true}
+)
+
+// sanitize розбирає HTML-фрагмент і залишає лише дозволені теги та атрибути
+func sanitize(source string) ([]*html.Node, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(source), context)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*html.Node
+	for _, node := range nodes {
+		result = append(result, clean(node)...)
+	}
+	return result, nil
+}
+
+// clean повертає очищений вузол або вміст непідтримуваного тегу
+func clean(node *html.Node) []*html.Node {
+	switch node.Type {
+	case html.TextNode:
+		return []*html.Node{{Type: html.TextNode, Data: node.Data}}
+	case html.ElementNode:
+	default:
+		return nil
+	}
+
+	tag := strings.ToLower(node.Data)
+	if droppedElements[tag] {
+		return nil
+	}
+
+	var children []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, clean(child)...)
+	}
+
+	attributes, ok := allowedElements[tag]
+	if !ok {
+		return children
+	}
+
+	cleaned := &html.Node{Type: html.ElementNode, Data: tag, DataAtom: atom.Lookup([]byte(tag))}
+	for _, attr := range node.Attr {
+		if attr.Namespace != "" || !slices.Contains(attributes, attr.Key) {
+			continue
+		}
+		value, ok := cleanAttribute(tag, attr.Key, attr.Val)
+		if ok {
+			cleaned.Attr = append(cleaned.Attr, html.Attribute{Key: attr.Key, Val: value})
+		}
+	}
+
+	switch tag {
+	case "a":
+		cleaned.Attr = append(cleaned.Attr, html.Attribute{Key: "rel", Val: "nofollow noopener"})
+	case "img":
+		if !hasAttribute(cleaned, "src") {
+			return nil
+		}
+	}
+
+	for _, child := range children {
+		cleaned.AppendChild(child)
+	}
+	return []*html.Node{cleaned}
+}
+
+func cleanAttribute(tag string, key string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	switch key {
+	case "href":
+		return cleanURL(value, linkSchemes)
+	case "src":
+		return cleanURL(value, imageSchemes)
+	case "class":
+		// Для коду зберігаємо лише мову підсвічування
+		if tag == "code" && strings.HasPrefix(value, "language-") && !strings.ContainsAny(value, " \t\n") {
+			return value, true
+		}
+		return "", false
+	case "width", "height", "colspan", "rowspan", "start":
+		for _, r := range value {
+			if r < '0' || r > '9' {
+				return "", false
+			}
+		}
+		return value, value != ""
+	}
+	return value, true
+}
+
+// cleanURL пропускає відносні URL і абсолютні з дозволеною схемою
+func cleanURL(value string, schemes map[string]bool) (string, bool) {
+	for _, r := range value {
+		if r < ' ' || r == 0x7f {
+			return "", false
+		}
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", false
+	}
+	if parsed.Scheme == "" {
+		return value, value != ""
+	}
+	return value, schemes[strings.ToLower(parsed.Scheme)]
+}
+
+func hasAttribute(node *html.Node, key string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"backend/internal/entities"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+	"log"
+	"sync"
+	"time"
+)
+
+// Event — доменна подія. EventName має бути сталим: за ним обробники знаходять подію в outbox.
+// Події — це структури-значення (не вказівники), що серіалізуються в JSON
+type Event interface {
+	EventName() string
+}
+
+type syncHandler func(tx *gorm.DB, event Event) error
+
+type asyncHandler struct {
+	decode func(payload []byte) (Event, error)
+	handle func(db *gorm.DB, event Event) error
+}
+
+// Observer отримує кожну подію в сирому вигляді — для наскрізних речей на кшталт аудиту
+type Observer func(db *gorm.DB, name string, payload []byte) error
+
+// Bus — шина доменних подій у межах процесу.
+// Синхронні обробники виконуються в транзакції того, хто публікує, і їхня помилка відкочує зміну.
+// Асинхронні записуються в outbox у тій самій транзакції й виконуються воркером після коміту з повторами
+type Bus struct {
+	mu        sync.RWMutex
+	sync      map[string][]syncHandler
+	async     map[string]map[string]asyncHandler
+	observers map[string]Observer
+	wake      chan struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		sync:      make(map[string][]syncHandler),
+		async:     make(map[string]map[string]asyncHandler),
+		observers: make(map[string]Observer),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Default — спільна шина застосунку
+var Default = NewBus()
+
+// Subscribe додає синхронний обробник події T
+func Subscribe[T Event](bus *Bus, handler func(tx *gorm.DB, event T) error) {
+	var zero T
+	name := zero.EventName()
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.sync[name] = append(bus.sync[name], func(tx *gorm.DB, event Event) error {
+		return handler(tx, event.(T))
+	})
+}
+
+// SubscribeAsync додає асинхронний обробник події T. Назва обробника зберігається в outbox,
+// тому має бути унікальною для події й не змінюватися між релізами
+func SubscribeAsync[T Event](bus *Bus, handlerName string, handler func(db *gorm.DB, event T) error) {
+	var zero T
+	name := zero.EventName()
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if bus.async[name] == nil {
+		bus.async[name] = make(map[string]asyncHandler)
+	}
+	if _, exists := bus.async[name][handlerName]; exists {
+		panic(fmt.Sprintf("events: handler %q is already subscribed to %q", handlerName, name))
+	}
+	bus.async[name][handlerName] = asyncHandler{
+		decode: func(payload []byte) (Event, error) {
+			var event T
+			err := json.Unmarshal(payload, &event)
+			return event, err
+		},
+		handle: func(db *gorm.DB, event Event) error {
+			return handler(db, event.(T))
+		},
+	}
+}
+
+// Observe додає асинхронного спостерігача всіх подій
+func (bus *Bus) Observe(handlerName string, observer Observer) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.observers[handlerName] = observer
+}
+
+// Publish виконує синхронні обробники з db і ставить асинхронні в outbox через той самий db.
+// Якщо db — транзакція, асинхронні обробники побачать подію лише після її коміту
+func (bus *Bus) Publish(db *gorm.DB, event Event) error {
+	name := event.EventName()
+
+	bus.mu.RLock()
+	syncHandlers := bus.sync[name]
+	var handlerNames []string
+	for handlerName := range bus.async[name] {
+		handlerNames = append(handlerNames, handlerName)
+	}
+	for handlerName := range bus.observers {
+		handlerNames = append(handlerNames, handlerName)
+	}
+	bus.mu.RUnlock()
+
+	for _, handler := range syncHandlers {
+		if err := handler(db, event); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if len(handlerNames) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	records := make([]entities.OutboxEvent, 0, len(handlerNames))
+	for _, handlerName := range handlerNames {
+		records = append(records, entities.OutboxEvent{
+			Name:          name,
+			Handler:       handlerName,
+			Payload:       entities.JSON(payload),
+			Status:        entities.OutboxPending,
+			NextAttemptAt: now,
+		})
+	}
+	if err = db.Create(&records).Error; err != nil {
+		return err
+	}
+
+	select {
+	case bus.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Handle виконує асинхронний обробник для запису outbox
+func (bus *Bus) Handle(db *gorm.DB, record *entities.OutboxEvent) error {
+	bus.mu.RLock()
+	handler, ok := bus.async[record.Name][record.Handler]
+	observer, observed := bus.observers[record.Handler]
+	bus.mu.RUnlock()
+
+	switch {
+	case ok:
+		event, err := handler.decode(record.Payload)
+		if err != nil {
+			return err
+		}
+		return handler.handle(db, event)
+	case observed:
+		return observer(db, record.Name, record.Payload)
+	default:
+		return fmt.Errorf("no handler %q for event %q", record.Handler, record.Name)
+	}
+}
+
+// Publish публікує подію в спільну шину
+func Publish(db *gorm.DB, event Event) error {
+	return Default.Publish(db, event)
+}
+
+// Dispatch — Publish поза транзакцією: помилка лише логується, бо зміна вже збережена
+func Dispatch(db *gorm.DB, event Event) {
+	if err := Publish(db, event); err != nil {
+		log.Printf("❌ Cannot publish event %s: %v", event.EventName(), err)
+	}
+}
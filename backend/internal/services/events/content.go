@@ -0,0 +1,36 @@
+package events
+
+import (
+	"github.com/google/uuid"
+)
+
+// Сутності з мовними версіями та медіа
+const (
+	EntityItems = "items"
+	EntityBlogs = "blogs"
+)
+
+// ContentDeleted — видалено мовну версію товару чи блогу. Якщо це була остання версія,
+// разом з нею зникають і спільні дані групи перекладів
+type ContentDeleted struct {
+	Entity             string    `json:"entity"`
+	ID                 uuid.UUID `json:"id"`
+	TranslationGroupID uuid.UUID `json:"translation_group_id"`
+	LastTranslation    bool      `json:"last_translation"`
+	ActorID            uuid.UUID `json:"actor_id,omitempty"`
+}
+
+func (ContentDeleted) EventName() string { return "content.deleted" }
+
+// ContentIDs повертає content_id, прив'язані саме до видаленої версії:
+// власні дані версії — завжди, спільні дані групи — лише з останньою версією
+func (event ContentDeleted) ContentIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	if event.ID != event.TranslationGroupID {
+		ids = append(ids, event.ID)
+	}
+	if event.LastTranslation {
+		ids = append(ids, event.TranslationGroupID)
+	}
+	return ids
+}
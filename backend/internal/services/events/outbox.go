@@ -0,0 +1,131 @@
+package events
+
+import (
+	"backend/internal/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"time"
+)
+
+const (
+	// Скільки записів воркер забирає за прохід
+	batchSize = 50
+	// Час, на який забраний запис ховається від інших воркерів; після падіння воркера запис повториться
+	claimLease = 5 * time.Minute
+	// Після MaxAttempts невдалих спроб запис позначається failed і лишається для розбору
+	MaxAttempts     = 10
+	firstRetryDelay = 10 * time.Second
+	maxRetryDelay   = time.Hour
+	// Скільки зберігаються оброблені записи
+	retention = 7 * 24 * time.Hour
+)
+
+// RetryDelay — пауза після невдалої спроби attempt (з 1): 10s, 20s, 40s… але не більше години
+func RetryDelay(attempt int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
+
+// StartWorker обробляє outbox: за розкладом і одразу після публікації в цьому процесі.
+// Кілька інстансів можуть працювати одночасно — записи розподіляє FOR UPDATE SKIP LOCKED
+func (bus *Bus) StartWorker(db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastCleanup := time.Time{}
+
+	for {
+		for {
+			processed, err := bus.ProcessDue(db, time.Now())
+			if err != nil {
+				log.Printf("❌ Outbox worker failed: %v", err)
+			}
+			if processed < batchSize {
+				break
+			}
+		}
+		if time.Since(lastCleanup) > time.Hour {
+			if err := cleanup(db, time.Now().Add(-retention)); err != nil {
+				log.Printf("❌ Outbox cleanup failed: %v", err)
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-bus.wake:
+			// Подію опубліковано, але транзакція могла ще не закомітитись — решту підхопить наступний тік
+		}
+	}
+}
+
+// ProcessDue забирає записи, час яких настав, виконує обробники й повертає кількість забраних
+func (bus *Bus) ProcessDue(db *gorm.DB, now time.Time) (int, error) {
+	records, err := claim(db, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range records {
+		record := &records[i]
+		record.Attempts++
+		err := bus.Handle(db, record)
+		finished := time.Now()
+		switch {
+		case err == nil:
+			record.Status = entities.OutboxDone
+			record.ProcessedAt = &finished
+			record.LastError = ""
+		case record.Attempts >= MaxAttempts:
+			record.Status = entities.OutboxFailed
+			record.ProcessedAt = &finished
+			record.LastError = err.Error()
+			log.Printf("❌ Event %s (%s) failed after %d attempts: %v", record.Name, record.Handler, record.Attempts, err)
+		default:
+			record.NextAttemptAt = finished.Add(RetryDelay(record.Attempts))
+			record.LastError = err.Error()
+		}
+
+		err = db.Model(record).
+			Select("status", "attempts", "next_attempt_at", "last_error", "processed_at").
+			Updates(record).Error
+		if err != nil {
+			return len(records), err
+		}
+	}
+	return len(records), nil
+}
+
+func claim(db *gorm.DB, now time.Time) ([]entities.OutboxEvent, error) {
+	var records []entities.OutboxEvent
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.OutboxPending, now).
+			Order("next_attempt_at, created_at").
+			Limit(batchSize).
+			Find(&records).Error
+		if err != nil || len(records) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+		return tx.Model(&entities.OutboxEvent{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(claimLease)).Error
+	})
+	return records, err
+}
+
+func cleanup(db *gorm.DB, before time.Time) error {
+	return db.Where("status = ? AND processed_at < ?", entities.OutboxDone, before).
+		Delete(&entities.OutboxEvent{}).Error
+}
@@ -0,0 +1,38 @@
+package feed
+
+import "sync"
+
+// Cache зберігає згенеровані документи, доки не зміниться версія контенту
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	version string
+	body    []byte
+}
+
+func NewCache() *Cache {
+	return &Cache{entries: make(map[string]cacheEntry)}
+}
+
+// Get повертає документ з кешу, якщо його версія збігається, інакше генерує та запам'ятовує новий
+func (c *Cache) Get(key, version string, build func() ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && entry.version == version {
+		return entry.body, nil
+	}
+
+	body, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{version: version, body: body}
+	c.mu.Unlock()
+	return body, nil
+}
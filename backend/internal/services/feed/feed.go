@@ -0,0 +1,201 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Channel — опис стрічки
+type Channel struct {
+	Title       string
+	Link        string
+	SelfLink    string
+	Description string
+	Language    string
+	Updated     time.Time
+}
+
+type Enclosure struct {
+	URL  string
+	Type string
+}
+
+// Entry — запис стрічки; Content містить уже санітизований HTML
+type Entry struct {
+	ID         string
+	Title      string
+	Link       string
+	Author     string
+	Summary    string
+	Content    string
+	Published  time.Time
+	Updated    time.Time
+	Enclosures []Enclosure
+}
+
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link"`
+	GUID        rssGUID        `xml:"guid"`
+	Author      string         `xml:"dc:creator,omitempty"`
+	Description string         `xml:"description"`
+	Content     cdata          `xml:"content:encoded"`
+	PubDate     string         `xml:"pubDate"`
+	Enclosures  []rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Links     []atomLink  `xml:"link"`
+	Author    atomAuthor  `xml:"author"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Summary   string      `xml:"summary,omitempty"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// RSS формує стрічку RSS 2.0 з повним текстом у content:encoded і зображеннями як enclosure
+func RSS(channel Channel, entries []Entry) ([]byte, error) {
+	feed := rss{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       channel.Title,
+			Link:        channel.Link,
+			AtomLink:    atomLink{Href: channel.SelfLink, Rel: "self", Type: "application/rss+xml"},
+			Description: channel.Description,
+			Language:    channel.Language,
+		},
+	}
+	if !channel.Updated.IsZero() {
+		feed.Channel.LastBuildDate = channel.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, entry := range entries {
+		item := rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			GUID:        rssGUID{Value: entry.ID},
+			Author:      entry.Author,
+			Description: entry.Summary,
+			Content:     cdata{Value: entry.Content},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+		}
+		for _, enclosure := range entry.Enclosures {
+			item.Enclosures = append(item.Enclosures, rssEnclosure{URL: enclosure.URL, Type: enclosure.Type})
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return marshal(feed)
+}
+
+// Atom формує стрічку Atom 1.0; зображення додаються як посилання rel="enclosure"
+func Atom(channel Channel, entries []Entry) ([]byte, error) {
+	feed := atomFeed{
+		Lang:     channel.Language,
+		ID:       channel.SelfLink,
+		Title:    channel.Title,
+		Subtitle: channel.Description,
+		Updated:  channel.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: channel.Link, Rel: "alternate", Type: "text/html"},
+			{Href: channel.SelfLink, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+
+	for _, entry := range entries {
+		// Atom вимагає автора; без нього підставляємо назву стрічки
+		author := entry.Author
+		if author == "" {
+			author = channel.Title
+		}
+		item := atomEntry{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			Links:     []atomLink{{Href: entry.Link, Rel: "alternate", Type: "text/html"}},
+			Author:    atomAuthor{Name: author},
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Summary:   entry.Summary,
+			Content:   atomContent{Type: "html", Value: entry.Content},
+		}
+		for _, enclosure := range entry.Enclosures {
+			item.Links = append(item.Links, atomLink{Href: enclosure.URL, Rel: "enclosure", Type: enclosure.Type})
+		}
+		feed.Entries = append(feed.Entries, item)
+	}
+
+	return marshal(feed)
+}
+
+func marshal(value any) ([]byte, error) {
+	body, err := xml.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
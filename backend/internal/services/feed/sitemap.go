@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Alternate — мовна версія сторінки для hreflang
+type Alternate struct {
+	Language string
+	Href     string
+}
+
+type URL struct {
+	Loc        string
+	LastMod    time.Time
+	Alternates []Alternate
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	XHTMLNS string       `xml:"xmlns:xhtml,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string         `xml:"loc"`
+	LastMod    string         `xml:"lastmod,omitempty"`
+	Alternates []sitemapXHTML `xml:"xhtml:link"`
+}
+
+type sitemapXHTML struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// Sitemap формує sitemap.xml; альтернативи виводяться лише для сторінок з кількома мовними версіями
+func Sitemap(urls []URL) ([]byte, error) {
+	set := urlSet{
+		NS:      "http://www.sitemaps.org/schemas/sitemap/0.9",
+		XHTMLNS: "http://www.w3.org/1999/xhtml",
+	}
+
+	for _, url := range urls {
+		entry := sitemapURL{Loc: url.Loc}
+		if !url.LastMod.IsZero() {
+			entry.LastMod = url.LastMod.UTC().Format(time.RFC3339)
+		}
+		if len(url.Alternates) > 1 {
+			for _, alternate := range url.Alternates {
+				entry.Alternates = append(entry.Alternates, sitemapXHTML{Rel: "alternate", HrefLang: alternate.Language, Href: alternate.Href})
+			}
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+
+	return marshal(set)
+}
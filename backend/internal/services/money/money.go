@@ -0,0 +1,107 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Money — сума в мінімальних одиницях валюти (грошах, центах, копійках)
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+const DefaultCurrency = "PLN"
+
+// Кількість знаків після коми для підтримуваних валют (ISO 4217)
+var exponents = map[string]int{
+	"PLN": 2,
+	"EUR": 2,
+	"UAH": 2,
+	"USD": 2,
+	"GBP": 2,
+	"CZK": 2,
+	"CHF": 2,
+}
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: NormalizeCurrency(currency)}
+}
+
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+func IsSupported(currency string) bool {
+	_, ok := exponents[NormalizeCurrency(currency)]
+	return ok
+}
+
+func (m Money) Validate() error {
+	if !IsSupported(m.Currency) {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, m.Currency)
+	}
+	return nil
+}
+
+// Parse перетворює десятковий рядок ("12.99", "12,99") на мінімальні одиниці без похибок float
+func Parse(value string, currency string) (Money, error) {
+	currency = NormalizeCurrency(currency)
+	exp, ok := exponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	r.Mul(r, new(big.Rat).SetInt(pow10(exp)))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places", value, exp)
+	}
+	if !r.Num().IsInt64() {
+		return Money{}, fmt.Errorf("amount %q is out of range", value)
+	}
+	return Money{Amount: r.Num().Int64(), Currency: currency}, nil
+}
+
+// Decimal повертає суму у вигляді десяткового рядка, наприклад "12.99"
+func (m Money) Decimal() string {
+	exp := exponents[NormalizeCurrency(m.Currency)]
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(exp)).FloatString(exp)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// MulRat множить суму на раціональний коефіцієнт з округленням до найближчої одиниці (half away from zero)
+func (m Money) MulRat(factor *big.Rat) Money {
+	r := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), factor)
+	return Money{Amount: round(r), Currency: m.Currency}
+}
+
+func round(r *big.Rat) int64 {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+	neg := num.Sign() < 0
+	num.Abs(num)
+
+	// (2*num + den) / (2*den) — округлення половини від нуля
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	q := new(big.Int).Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+	if neg {
+		q.Neg(q)
+	}
+	return q.Int64()
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
@@ -0,0 +1,201 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateSource повертає курс: скільки одиниць валюти to коштує одна одиниця валюти from
+type RateSource interface {
+	Rate(from, to string) (*big.Rat, error)
+}
+
+// StaticRates — таблиця курсів відносно базової валюти (1 одиниця валюти = Rates[валюта] одиниць Base)
+type StaticRates struct {
+	Base  string
+	Rates map[string]*big.Rat
+}
+
+func (s *StaticRates) Rate(from, to string) (*big.Rat, error) {
+	from, to = NormalizeCurrency(from), NormalizeCurrency(to)
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	fromRate, err := s.toBase(from)
+	if err != nil {
+		return nil, err
+	}
+	toRate, err := s.toBase(to)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Quo(fromRate, toRate), nil
+}
+
+func (s *StaticRates) toBase(currency string) (*big.Rat, error) {
+	if currency == NormalizeCurrency(s.Base) {
+		return big.NewRat(1, 1), nil
+	}
+	rate, ok := s.Rates[currency]
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("no exchange rate for %s", currency)
+	}
+	return rate, nil
+}
+
+// ParseStaticRates розбирає рядок виду "EUR=4.30,UAH=0.098"
+func ParseStaticRates(base string, spec string) (*StaticRates, error) {
+	rates := &StaticRates{Base: NormalizeCurrency(base), Rates: map[string]*big.Rat{}}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid exchange rate %q", pair)
+		}
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(parts[1]))
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %q", pair)
+		}
+		rates.Rates[NormalizeCurrency(parts[0])] = rate
+	}
+	return rates, nil
+}
+
+// FileRates читає курси з JSON-файлу {"base":"PLN","rates":{"EUR":"4.30"}} і перечитує його при зміні
+type FileRates struct {
+	Path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rates   *StaticRates
+}
+
+type rateFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+func (f *FileRates) Rate(from, to string) (*big.Rat, error) {
+	rates, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return rates.Rate(from, to)
+}
+
+func (f *FileRates) load() (*StaticRates, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rate file: %v", err)
+	}
+	if f.rates != nil && info.ModTime().Equal(f.modTime) {
+		return f.rates, nil
+	}
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rate file: %v", err)
+	}
+	var file rateFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("exchange rate file: %v", err)
+	}
+
+	rates := &StaticRates{Base: NormalizeCurrency(file.Base), Rates: map[string]*big.Rat{}}
+	for currency, value := range file.Rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok {
+			return nil, fmt.Errorf("exchange rate file: invalid rate %q for %s", value, currency)
+		}
+		rates.Rates[NormalizeCurrency(currency)] = rate
+	}
+
+	f.rates = rates
+	f.modTime = info.ModTime()
+	return rates, nil
+}
+
+var (
+	defaultRates     RateSource
+	defaultRatesErr  error
+	defaultRatesOnce sync.Once
+)
+
+// DefaultRates повертає джерело курсів з налаштувань: EXCHANGE_RATES_FILE або EXCHANGE_RATES.
+// Якщо налаштування з помилкою, таблиця курсів порожня — помилку повертає CheckDefaultRates
+func DefaultRates() RateSource {
+	defaultRatesOnce.Do(func() {
+		defaultRates, defaultRatesErr = loadDefaultRates()
+		if defaultRatesErr != nil {
+			log.Printf("❌ Invalid exchange rates: %v", defaultRatesErr)
+		}
+	})
+	return defaultRates
+}
+
+// CheckDefaultRates перевіряє налаштування курсів; викликається під час запуску
+func CheckDefaultRates() error {
+	DefaultRates()
+	return defaultRatesErr
+}
+
+// BaseCurrency повертає базову валюту магазину з EXCHANGE_RATES_BASE
+func BaseCurrency() string {
+	if base := NormalizeCurrency(os.Getenv("EXCHANGE_RATES_BASE")); base != "" {
+		return base
+	}
+	return DefaultCurrency
+}
+
+func loadDefaultRates() (RateSource, error) {
+	if path := os.Getenv("EXCHANGE_RATES_FILE"); path != "" {
+		rates := &FileRates{Path: path}
+		_, err := rates.load()
+		return rates, err
+	}
+
+	base := BaseCurrency()
+	rates, err := ParseStaticRates(base, os.Getenv("EXCHANGE_RATES"))
+	if err != nil {
+		return &StaticRates{Base: NormalizeCurrency(base), Rates: map[string]*big.Rat{}}, fmt.Errorf("EXCHANGE_RATES: %w", err)
+	}
+	return rates, nil
+}
+
+// Convert переводить суму в іншу валюту з точним округленням до мінімальної одиниці
+func Convert(m Money, to string, source RateSource) (Money, error) {
+	to = NormalizeCurrency(to)
+	if !IsSupported(to) {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, to)
+	}
+	if NormalizeCurrency(m.Currency) == to {
+		return m, nil
+	}
+
+	rate, err := source.Rate(m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// Враховуємо різну кількість знаків після коми у валютах
+	factor := new(big.Rat).Mul(rate, new(big.Rat).SetFrac(
+		pow10(exponents[to]),
+		pow10(exponents[NormalizeCurrency(m.Currency)]),
+	))
+	converted := m.MulRat(factor)
+	converted.Currency = to
+	return converted, nil
+}
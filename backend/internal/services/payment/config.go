@@ -0,0 +1,34 @@
+package payment
+
+import (
+	"fmt"
+	"os"
+)
+
+// FromEnv створює провайдера з PAYMENT_PROVIDER (fake або http), PAYMENT_API_URL,
+// PAYMENT_API_KEY та PAYMENT_WEBHOOK_SECRET. Без PAYMENT_PROVIDER використовується fake.
+// Секрет вебхука обов'язковий для будь-якого провайдера: інакше підпис можна підробити
+func FromEnv() (Provider, error) {
+	secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("PAYMENT_WEBHOOK_SECRET is required")
+	}
+
+	switch kind := os.Getenv("PAYMENT_PROVIDER"); kind {
+	case "", "fake":
+		return NewFake(secret), nil
+	case "http":
+		baseURL := os.Getenv("PAYMENT_API_URL")
+		if baseURL == "" {
+			return nil, fmt.Errorf("PAYMENT_API_URL is required for the http payment provider")
+		}
+		return &HTTP{
+			ProviderName:  os.Getenv("PAYMENT_PROVIDER_NAME"),
+			BaseURL:       baseURL,
+			APIKey:        os.Getenv("PAYMENT_API_KEY"),
+			WebhookSecret: secret,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown PAYMENT_PROVIDER %q", kind)
+	}
+}
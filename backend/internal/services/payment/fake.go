@@ -0,0 +1,74 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// Fake — локальний провайдер для розробки й тестів. Нічого не надсилає назовні,
+// а події вебхука створює метод Simulate з тим самим підписом, що й у справжніх провайдерів.
+type Fake struct {
+	Secret string
+	Now    func() time.Time
+}
+
+func NewFake(secret string) *Fake {
+	return &Fake{Secret: secret, Now: time.Now}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) CreatePayment(_ context.Context, request PaymentRequest) (*Session, error) {
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("invalid amount %d", request.Amount)
+	}
+
+	redirect := request.ReturnURL
+	if redirect == "" {
+		redirect = "about:blank"
+	}
+	return &Session{PaymentID: "fake_pay_" + uuid.NewString(), Status: StatusPending, RedirectURL: redirect}, nil
+}
+
+func (f *Fake) VerifyWebhook(payload []byte, header http.Header) (*Event, error) {
+	if err := VerifySignature(f.Secret, payload, header.Get(SignatureHeader), f.Now()); err != nil {
+		return nil, err
+	}
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return &event, nil
+}
+
+// Refund одразу підтверджує повернення; суми перевіряє сторона, що викликає
+func (f *Fake) Refund(_ context.Context, request RefundRequest) (*Refund, error) {
+	if request.PaymentID == "" {
+		return nil, ErrUnknownPayment
+	}
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("invalid refund amount %d", request.Amount)
+	}
+	return &Refund{RefundID: "fake_ref_" + uuid.NewString(), Status: StatusSucceeded}, nil
+}
+
+// Simulate створює підписану подію вебхука, ніби її надіслав провайдер
+func (f *Fake) Simulate(event Event) ([]byte, http.Header, error) {
+	if event.ID == "" {
+		event.ID = "fake_evt_" + uuid.NewString()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	header.Set(SignatureHeader, Sign(f.Secret, payload, f.Now()))
+	return payload, header, nil
+}
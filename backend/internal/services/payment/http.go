@@ -0,0 +1,114 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTP — адаптер до платіжного API у стилі Stripe/Przelewy24:
+// POST {BaseURL}/payments і POST {BaseURL}/refunds з Bearer-ключем, вебхуки підписані HMAC.
+// BaseURL можна спрямувати на тестовий сервер.
+type HTTP struct {
+	ProviderName  string
+	BaseURL       string
+	APIKey        string
+	WebhookSecret string
+	Client        *http.Client
+	Now           func() time.Time
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func (p *HTTP) Name() string {
+	if p.ProviderName != "" {
+		return p.ProviderName
+	}
+	return "http"
+}
+
+func (p *HTTP) CreatePayment(ctx context.Context, request PaymentRequest) (*Session, error) {
+	var session Session
+	// Повтор запиту з тим самим Reference не створить у провайдера другий платіж
+	if err := p.post(ctx, "/payments", request.Reference, request, &session); err != nil {
+		return nil, err
+	}
+	if session.PaymentID == "" {
+		return nil, fmt.Errorf("payment provider returned no payment id")
+	}
+	if session.Status == "" {
+		session.Status = StatusPending
+	}
+	return &session, nil
+}
+
+func (p *HTTP) VerifyWebhook(payload []byte, header http.Header) (*Event, error) {
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	if err := VerifySignature(p.WebhookSecret, payload, header.Get(SignatureHeader), now()); err != nil {
+		return nil, err
+	}
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return &event, nil
+}
+
+func (p *HTTP) Refund(ctx context.Context, request RefundRequest) (*Refund, error) {
+	var refund Refund
+	if err := p.post(ctx, "/refunds", request.Reference, request, &refund); err != nil {
+		return nil, err
+	}
+	if refund.Status == "" {
+		refund.Status = StatusPending
+	}
+	return &refund, nil
+}
+
+func (p *HTTP) post(ctx context.Context, path, idempotencyKey string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.BaseURL, "/")+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("payment provider: %s (%d)", apiErr.Error, resp.StatusCode)
+		}
+		return fmt.Errorf("payment provider responded with %d", resp.StatusCode)
+	}
+	return json.Unmarshal(data, out)
+}
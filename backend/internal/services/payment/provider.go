@@ -0,0 +1,76 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Типи подій вебхука
+const (
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+	EventRefundSucceeded  = "refund.succeeded"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrUnknownPayment   = errors.New("unknown payment")
+)
+
+// Provider — платіжна система: створення платежу, перевірка підписаних вебхуків і повернення коштів
+type Provider interface {
+	Name() string
+	CreatePayment(ctx context.Context, request PaymentRequest) (*Session, error)
+	VerifyWebhook(payload []byte, header http.Header) (*Event, error)
+	Refund(ctx context.Context, request RefundRequest) (*Refund, error)
+}
+
+// PaymentRequest — сума в мінімальних одиницях валюти; Reference використовується як ключ ідемпотентності
+type PaymentRequest struct {
+	Reference   string `json:"reference"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+	ReturnURL   string `json:"return_url"`
+}
+
+type Session struct {
+	PaymentID   string `json:"id"`
+	Status      Status `json:"status"`
+	RedirectURL string `json:"redirect_url"`
+}
+
+// Event — перевірена подія вебхука; ID унікальний у межах провайдера,
+// Reference повторює Reference запиту, з якого почався платіж або повернення
+type Event struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	PaymentID string `json:"payment_id"`
+	RefundID  string `json:"refund_id,omitempty"`
+	Reference string `json:"reference,omitempty"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+}
+
+// RefundRequest — Reference ідентифікує повернення в нашій системі й слугує ключем ідемпотентності
+type RefundRequest struct {
+	Reference string `json:"reference"`
+	PaymentID string `json:"payment_id"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason"`
+}
+
+type Refund struct {
+	RefundID string `json:"id"`
+	Status   Status `json:"status"`
+}
@@ -0,0 +1,348 @@
+package pdf
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	margin      = 48.0
+	bodySize    = 9.0
+	headingSize = 11.0
+	titleSize   = 16.0
+	leading     = 1.35
+	cellPadding = 4.0
+	// Допуск для порівняння ширин з плаваючою комою
+	tolerance = 0.01
+)
+
+// Render верстає текстову розмітку на сторінки A4. Розмітка рядкова, схожа на Markdown:
+//
+//	# Заголовок документа
+//	## Підзаголовок
+//	**Жирний рядок**
+//	>> Рядок, вирівняний праворуч (можна поєднувати з **...**)
+//	---                    горизонтальна лінія
+//	| A | B |              рядок таблиці
+//	|:--|--:|              роздільник: попередній рядок стає заголовком, двокрапки задають вирівнювання
+//	| **A** | B |          жирна клітинка
+//
+// Порожній рядок додає відступ, решта рядків — абзаци з переносом слів.
+// Рядок, що починається з "\", виводиться дослівно — так вставляють довільний текст користувача.
+func Render(title string, markup string) ([]byte, error) {
+	l := &layout{doc: New(title)}
+	l.newPage()
+
+	lines := strings.Split(strings.ReplaceAll(markup, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			l.y -= bodySize * 0.6
+		case strings.HasPrefix(line, "\\"):
+			l.paragraph(line[1:], Regular, bodySize, false)
+		case line == "---":
+			l.rule()
+		case strings.HasPrefix(line, "|"):
+			end := i
+			for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "|") {
+				end++
+			}
+			l.table(lines[i:end])
+			i = end - 1
+		case strings.HasPrefix(line, "## "):
+			l.y -= headingSize * 0.4
+			l.paragraph(strings.TrimPrefix(line, "## "), Bold, headingSize, false)
+		case strings.HasPrefix(line, "# "):
+			l.paragraph(strings.TrimPrefix(line, "# "), Bold, titleSize, false)
+			l.y -= titleSize * 0.3
+		default:
+			right := false
+			if rest, found := strings.CutPrefix(line, ">>"); found {
+				line, right = strings.TrimSpace(rest), true
+			}
+			line, font := emphasis(line, Regular)
+			l.paragraph(line, font, bodySize, right)
+		}
+	}
+	return l.doc.Bytes()
+}
+
+type layout struct {
+	doc *Document
+	y   float64
+}
+
+func (l *layout) newPage() {
+	l.doc.AddPage()
+	l.y = PageHeight - margin
+}
+
+// need переносить вміст на нову сторінку, якщо висота height не вміщається
+func (l *layout) need(height float64) bool {
+	if l.y-height < margin {
+		l.newPage()
+		return true
+	}
+	return false
+}
+
+func (l *layout) paragraph(text string, font Font, size float64, right bool) {
+	width := PageWidth - 2*margin
+	for _, line := range l.wrap(font, size, text, width) {
+		l.need(size * leading)
+		l.y -= size * leading
+		x := margin
+		if right {
+			x = PageWidth - margin - l.doc.TextWidth(font, size, line)
+		}
+		l.doc.Text(x, l.y+size*0.25, font, size, line)
+	}
+}
+
+func (l *layout) rule() {
+	l.need(bodySize)
+	l.y -= bodySize * 0.5
+	l.doc.Line(margin, l.y, PageWidth-margin, l.y, 0.5)
+	l.y -= bodySize * 0.5
+}
+
+type alignment int
+
+const (
+	alignLeft alignment = iota
+	alignRight
+	alignCenter
+)
+
+// table розбирає блок рядків таблиці, підбирає ширини колонок і виводить рядки;
+// після перенесення на нову сторінку заголовок повторюється
+func (l *layout) table(source []string) {
+	var rows [][]string
+	var aligns []alignment
+	headerRows := 0
+	for _, line := range source {
+		cells := splitRow(line)
+		if isSeparator(cells) {
+			aligns = parseAlignments(cells)
+			headerRows = len(rows)
+			continue
+		}
+		rows = append(rows, cells)
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return
+	}
+	for len(aligns) < columns {
+		aligns = append(aligns, alignLeft)
+	}
+
+	widths := l.columnWidths(rows, aligns, columns, headerRows)
+	var header [][]string
+	if headerRows > 0 {
+		header = rows[:headerRows]
+	}
+
+	for i, row := range rows {
+		font := Regular
+		if i < headerRows {
+			font = Bold
+		}
+		cells, height := l.layoutRow(row, widths, font)
+		if l.need(height) && i >= headerRows {
+			for _, headerRow := range header {
+				headerCells, headerHeight := l.layoutRow(headerRow, widths, Bold)
+				l.drawRow(headerCells, widths, aligns, headerHeight, true)
+			}
+		}
+		l.drawRow(cells, widths, aligns, height, i == headerRows-1)
+	}
+	l.y -= bodySize * 0.4
+}
+
+type cell struct {
+	lines []string
+	font  Font
+}
+
+func (l *layout) layoutRow(row []string, widths []float64, font Font) ([]cell, float64) {
+	cells := make([]cell, len(widths))
+	lines := 1
+	for column := range widths {
+		value, cellFont := "", font
+		if column < len(row) {
+			value, cellFont = emphasis(row[column], font)
+		}
+		cells[column] = cell{lines: l.wrap(cellFont, bodySize, value, widths[column]-2*cellPadding), font: cellFont}
+		lines = max(lines, len(cells[column].lines))
+	}
+	return cells, float64(lines)*bodySize*leading + cellPadding
+}
+
+func (l *layout) drawRow(cells []cell, widths []float64, aligns []alignment, height float64, underline bool) {
+	x := margin
+	for column, cell := range cells {
+		font := cell.font
+		for n, line := range cell.lines {
+			y := l.y - float64(n+1)*bodySize*leading + bodySize*0.25
+			textX := x + cellPadding
+			switch aligns[column] {
+			case alignRight:
+				textX = x + widths[column] - cellPadding - l.doc.TextWidth(font, bodySize, line)
+			case alignCenter:
+				textX = x + (widths[column]-l.doc.TextWidth(font, bodySize, line))/2
+			}
+			l.doc.Text(textX, y, font, bodySize, line)
+		}
+		x += widths[column]
+	}
+	l.y -= height
+	if underline {
+		l.doc.Line(margin, l.y+cellPadding/2, PageWidth-margin, l.y+cellPadding/2, 0.5)
+	}
+}
+
+// columnWidths дає колонкам природну ширину; якщо таблиця ширша за сторінку,
+// стискаються колонки з вирівнюванням ліворуч (описи), а числа лишаються в один рядок.
+// Вільне місце віддається першій колонці з вирівнюванням ліворуч, а якщо всі колонки
+// текстові (наприклад, продавець і покупець поруч) — ділиться порівну.
+func (l *layout) columnWidths(rows [][]string, aligns []alignment, columns int, headerRows int) []float64 {
+	available := PageWidth - 2*margin
+	widths := make([]float64, columns)
+	for i, row := range rows {
+		font := Regular
+		if i < headerRows {
+			font = Bold
+		}
+		for column, value := range row {
+			value, cellFont := emphasis(value, font)
+			widths[column] = max(widths[column], l.doc.TextWidth(cellFont, bodySize, value)+2*cellPadding)
+		}
+	}
+
+	total, flexible := 0.0, 0.0
+	for column, width := range widths {
+		total += width
+		if aligns[column] == alignLeft {
+			flexible += width
+		}
+	}
+
+	switch {
+	case total > available && flexible > 0:
+		fixed := total - flexible
+		scale := max(available-fixed, 0) / flexible
+		for column := range widths {
+			if aligns[column] == alignLeft {
+				widths[column] = max(widths[column]*scale, 4*cellPadding)
+			}
+		}
+	case total > available:
+		for column := range widths {
+			widths[column] *= available / total
+		}
+	case flexible < total:
+		for column := range widths {
+			if aligns[column] == alignLeft {
+				widths[column] += available - total
+				break
+			}
+		}
+	default:
+		for column := range widths {
+			widths[column] += (available - total) / float64(columns)
+		}
+	}
+	return widths
+}
+
+// emphasis прибирає обрамлення **...** і повертає жирний шрифт
+func emphasis(text string, font Font) (string, Font) {
+	if len(text) > 4 && strings.HasPrefix(text, "**") && strings.HasSuffix(text, "**") {
+		return text[2 : len(text)-2], Bold
+	}
+	return text, font
+}
+
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+func isSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(cell, ":-") != "" || !strings.Contains(cell, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+func parseAlignments(cells []string) []alignment {
+	aligns := make([]alignment, len(cells))
+	for i, cell := range cells {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns[i] = alignCenter
+		case right:
+			aligns[i] = alignRight
+		}
+	}
+	return aligns
+}
+
+// wrap розбиває текст на рядки не ширші за width; задовге слово розрізається посимвольно.
+// Нерозривний пробіл (наприклад, у сумах "1 234,56") не є місцем переносу.
+func (l *layout) wrap(font Font, size float64, text string, width float64) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == '\t'
+	})
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	current := ""
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if l.doc.TextWidth(font, size, candidate) <= width+tolerance {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = word
+		for l.doc.TextWidth(font, size, current) > width+tolerance {
+			cut := l.fitRunes(font, size, current, width)
+			lines = append(lines, current[:cut])
+			current = current[cut:]
+		}
+	}
+	return append(lines, current)
+}
+
+// fitRunes повертає довжину в байтах найдовшого префікса, що вміщується в width (щонайменше один символ)
+func (l *layout) fitRunes(font Font, size float64, text string, width float64) int {
+	for i, r := range text {
+		if i > 0 && l.doc.TextWidth(font, size, text[:i+utf8.RuneLen(r)]) > width+tolerance {
+			return i
+		}
+	}
+	return len(text)
+}
@@ -0,0 +1,96 @@
+package pdf
+
+import (
+	"bytes"
+	_ "embed"
+	"github.com/go-pdf/fpdf"
+)
+
+// Розмір сторінки A4 у пунктах
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Шрифт DejaVu Sans вбудовується в документ (лише використані гліфи), тож кирилиця,
+// польські та інші літери відображаються без шрифтів у системі читача
+var (
+	//go:embed fonts/DejaVuSans.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSans-Bold.ttf
+	boldFont []byte
+)
+
+const fontFamily = "DejaVuSans"
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+func (f Font) style() string {
+	if f == Bold {
+		return "B"
+	}
+	return ""
+}
+
+// Document — обгортка над fpdf з координатами від лівого нижнього кута сторінки в пунктах
+type Document struct {
+	pdf *fpdf.Fpdf
+	// Поточний шрифт: fpdf записує кожну зміну в сторінку, тож повтори пропускаються
+	font Font
+	size float64
+}
+
+func New(title string) *Document {
+	doc := fpdf.New("P", "pt", "A4", "")
+	doc.SetAutoPageBreak(false, 0)
+	doc.SetMargins(0, 0, 0)
+	doc.SetTitle(title, true)
+	doc.AddUTF8FontFromBytes(fontFamily, Regular.style(), regularFont)
+	doc.AddUTF8FontFromBytes(fontFamily, Bold.style(), boldFont)
+	doc.SetFont(fontFamily, Regular.style(), bodySize)
+	return &Document{pdf: doc, font: Regular, size: bodySize}
+}
+
+// AddPage починає нову сторінку; наступні виклики малюють на ній
+func (d *Document) AddPage() {
+	d.pdf.AddPage()
+}
+
+// Text виводить рядок, де (x, y) — початок базової лінії від лівого нижнього кута сторінки
+func (d *Document) Text(x, y float64, font Font, size float64, text string) {
+	d.setFont(font, size)
+	d.pdf.Text(x, PageHeight-y, text)
+}
+
+// Line малює відрізок заданої товщини
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	d.pdf.SetLineWidth(width)
+	d.pdf.Line(x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// TextWidth — ширина рядка в пунктах
+func (d *Document) TextWidth(font Font, size float64, text string) float64 {
+	d.setFont(font, d.size)
+	return float64(d.pdf.GetStringSymbolWidth(text)) * size / 1000
+}
+
+func (d *Document) setFont(font Font, size float64) {
+	if font != d.font || size != d.size {
+		d.pdf.SetFont(fontFamily, font.style(), size)
+		d.font, d.size = font, size
+	}
+}
+
+// Bytes збирає файл PDF; перша помилка fpdf повертається тут
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
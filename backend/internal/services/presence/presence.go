@@ -0,0 +1,210 @@
+package presence
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	Online  Status = "online"
+	Away    Status = "away"
+	Offline Status = "offline"
+)
+
+const (
+	// Без активності довше за AwayAfter користувач вважається відійшовшим
+	AwayAfter = 5 * time.Minute
+	// Без активності й відкритих з'єднань довше за OfflineAfter — офлайн
+	OfflineAfter = 15 * time.Minute
+	// LastSeenAt у базі оновлюється не частіше, ніж раз на PersistInterval
+	PersistInterval = time.Minute
+)
+
+// Resolve визначає статус за кількістю відкритих з'єднань і часом від останньої активності.
+// Відкрита вкладка без дій користувача лишає його "away", але не "offline"
+func Resolve(connections int, idle time.Duration) Status {
+	switch {
+	case idle < AwayAfter:
+		return Online
+	case connections > 0 || idle < OfflineAfter:
+		return Away
+	default:
+		return Offline
+	}
+}
+
+// Change — зміна статусу, яку розсилають підключеним клієнтам
+type Change struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Status     Status    `json:"status"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+type entry struct {
+	connections int
+	lastActive  time.Time
+	persistedAt time.Time
+	status      Status
+}
+
+// Tracker зберігає присутність користувачів у пам'яті процесу: з'єднання реального часу та час останньої активності
+type Tracker struct {
+	Now func() time.Time
+
+	mu        sync.Mutex
+	users     map[uuid.UUID]*entry
+	listeners []func(Change)
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{Now: time.Now, users: make(map[uuid.UUID]*entry)}
+}
+
+// Default — спільний трекер застосунку
+var Default = NewTracker()
+
+// Listen додає обробник змін статусу; обробник викликається поза блокуванням трекера
+func (t *Tracker) Listen(listener func(Change)) {
+	t.mu.Lock()
+	t.listeners = append(t.listeners, listener)
+	t.mu.Unlock()
+}
+
+// Touch фіксує активність користувача (запит до API або heartbeat) і повідомляє,
+// чи час зберегти LastSeenAt у базі
+func (t *Tracker) Touch(userId uuid.UUID) (persist bool) {
+	now := t.Now()
+	t.mu.Lock()
+	e := t.entry(userId)
+	e.lastActive = now
+	if now.Sub(e.persistedAt) >= PersistInterval {
+		e.persistedAt = now
+		persist = true
+	}
+	change := t.update(userId, e, now)
+	t.mu.Unlock()
+
+	t.notify(change)
+	return persist
+}
+
+// Connect реєструє з'єднання реального часу; повернену функцію треба викликати після його закриття
+func (t *Tracker) Connect(userId uuid.UUID) func() {
+	now := t.Now()
+	t.mu.Lock()
+	e := t.entry(userId)
+	e.connections++
+	e.lastActive = now
+	change := t.update(userId, e, now)
+	t.mu.Unlock()
+	t.notify(change)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			now := t.Now()
+			t.mu.Lock()
+			e := t.entry(userId)
+			e.connections--
+			change := t.update(userId, e, now)
+			t.mu.Unlock()
+			t.notify(change)
+		})
+	}
+}
+
+// Status повертає поточний статус; для користувачів, яких трекер ще не бачив
+// (наприклад, після перезапуску), статус обчислюється з LastSeenAt у базі
+func (t *Tracker) Status(userId uuid.UUID, lastSeenAt *time.Time) Status {
+	now := t.Now()
+	t.mu.Lock()
+	e, ok := t.users[userId]
+	var status Status
+	if ok {
+		status = Resolve(e.connections, now.Sub(e.lastActive))
+	}
+	t.mu.Unlock()
+
+	if ok {
+		return status
+	}
+	if lastSeenAt == nil {
+		return Offline
+	}
+	return Resolve(0, now.Sub(*lastSeenAt))
+}
+
+// LastSeen повертає час останньої активності: з пам'яті, якщо він новіший за збережений у базі
+func (t *Tracker) LastSeen(userId uuid.UUID, lastSeenAt *time.Time) *time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.users[userId]; ok && !e.lastActive.IsZero() && (lastSeenAt == nil || e.lastActive.After(*lastSeenAt)) {
+		lastActive := e.lastActive
+		return &lastActive
+	}
+	return lastSeenAt
+}
+
+// Sweep перераховує статуси, що змінюються з плином часу (online → away → offline),
+// розсилає зміни й забуває офлайн-користувачів без з'єднань
+func (t *Tracker) Sweep() {
+	now := t.Now()
+	var changes []*Change
+	t.mu.Lock()
+	for userId, e := range t.users {
+		if change := t.update(userId, e, now); change != nil {
+			changes = append(changes, change)
+		}
+		if e.status == Offline && e.connections == 0 {
+			delete(t.users, userId)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, change := range changes {
+		t.notify(change)
+	}
+}
+
+// StartSweeper викликає Sweep з інтервалом interval
+func (t *Tracker) StartSweeper(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		t.Sweep()
+	}
+}
+
+func (t *Tracker) entry(userId uuid.UUID) *entry {
+	e, ok := t.users[userId]
+	if !ok {
+		e = &entry{status: Offline}
+		t.users[userId] = e
+	}
+	return e
+}
+
+// update перераховує статус запису і повертає зміну, якщо він став іншим
+func (t *Tracker) update(userId uuid.UUID, e *entry, now time.Time) *Change {
+	status := Resolve(e.connections, now.Sub(e.lastActive))
+	if status == e.status {
+		return nil
+	}
+	e.status = status
+	return &Change{UserID: userId, Status: status, LastSeenAt: e.lastActive}
+}
+
+func (t *Tracker) notify(change *Change) {
+	if change == nil {
+		return
+	}
+	t.mu.Lock()
+	listeners := t.listeners
+	t.mu.Unlock()
+	for _, listener := range listeners {
+		listener(*change)
+	}
+}
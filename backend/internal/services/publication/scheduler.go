@@ -0,0 +1,123 @@
+package publication
+
+import (
+	"backend/internal/entities"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hash/fnv"
+	"log"
+	"os"
+	"time"
+)
+
+const defaultInterval = time.Minute
+
+// Transition — запис, стан якого змінив планувальник
+type Transition struct {
+	Table string
+	ID    uuid.UUID
+	State entities.PublicationState
+}
+
+// Hook обробляє перехід у транзакції планувальника; помилка відкочує весь прохід,
+// тож подія не втрачається і не публікується для незбереженого переходу
+type Hook func(tx *gorm.DB, transition Transition) error
+
+// Interval читає період перевірки з PUBLICATION_SCHEDULER_INTERVAL (наприклад, 30s)
+func Interval() time.Duration {
+	value := os.Getenv("PUBLICATION_SCHEDULER_INTERVAL")
+	if value == "" {
+		return defaultInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Printf("⚠️ Invalid PUBLICATION_SCHEDULER_INTERVAL %q, using %s", value, defaultInterval)
+		return defaultInterval
+	}
+	return interval
+}
+
+// StartScheduler періодично публікує та знімає з публікації записи у вказаних таблицях.
+// onTransition (може бути nil) викликається для кожного переходу в тій самій транзакції
+func StartScheduler(db *gorm.DB, interval time.Duration, onTransition Hook, tables ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		transitions, err := RunOnce(db, time.Now(), onTransition, tables...)
+		if err != nil {
+			log.Printf("❌ Publication scheduler failed: %v", err)
+		}
+		for _, transition := range transitions {
+			log.Printf("🕒 %s %s → %s", transition.Table, transition.ID, transition.State)
+		}
+		<-ticker.C
+	}
+}
+
+// RunOnce виконує один прохід планувальника.
+// Транзакційний advisory lock гарантує, що при кількох інстансах прохід виконує лише один,
+// а умовні UPDATE не дають застосувати перехід двічі навіть без блокування.
+// onTransition (може бути nil) викликається для кожного переходу до коміту.
+func RunOnce(db *gorm.DB, now time.Time, onTransition Hook, tables ...string) ([]Transition, error) {
+	var transitions []Transition
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", lockKey()).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		for _, table := range tables {
+			published, err := transition(tx, table, entities.StateScheduled, entities.StatePublished, "publish_at", now)
+			if err != nil {
+				return err
+			}
+			archived, err := transition(tx, table, entities.StatePublished, entities.StateArchived, "unpublish_at", now)
+			if err != nil {
+				return err
+			}
+			transitions = append(transitions, published...)
+			transitions = append(transitions, archived...)
+		}
+
+		if onTransition != nil {
+			for _, transition := range transitions {
+				if err := onTransition(tx, transition); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+func transition(tx *gorm.DB, table string, from, to entities.PublicationState, column string, now time.Time) ([]Transition, error) {
+	var ids []uuid.UUID
+	sql := fmt.Sprintf(`UPDATE %s SET state = ?, updated_at = ?
+		WHERE state = ? AND %s IS NOT NULL AND %s <= ?
+		RETURNING id`, table, column, column)
+	if err := tx.Raw(sql, to, now, from, now).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+
+	transitions := make([]Transition, 0, len(ids))
+	for _, id := range ids {
+		transitions = append(transitions, Transition{Table: table, ID: id, State: to})
+	}
+	return transitions, nil
+}
+
+func lockKey() int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("publication-scheduler"))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,165 @@
+package revision
+
+import (
+	"backend/internal/entities"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"reflect"
+	"sort"
+)
+
+// Типи записів, для яких ведеться історія
+const (
+	EntityBlog = "blog"
+	EntityItem = "item"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Record зберігає знімок після зміни запису; якщо знімок не відрізняється від останнього, нова ревізія не створюється
+func Record(tx *gorm.DB, entityType string, entityId uuid.UUID, authorId uuid.UUID, snapshot interface{}, comment string) (*entities.Revision, error) {
+	data, err := entities.NewJSON(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var last entities.Revision
+	err = tx.Where("entity_type = ? AND entity_id = ?", entityType, entityId).
+		Order("number DESC").
+		First(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var changed []string
+	if err == nil {
+		changes, err := Diff(last.Snapshot, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) == 0 && comment == "" {
+			return &last, nil
+		}
+		for _, change := range changes {
+			changed = append(changed, change.Field)
+		}
+	}
+
+	revision := &entities.Revision{
+		EntityType:    entityType,
+		EntityID:      entityId,
+		Number:        last.Number + 1,
+		Snapshot:      data,
+		ChangedFields: changed,
+		Comment:       comment,
+		AuthorID:      authorId,
+	}
+	if err = tx.Create(revision).Error; err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// List повертає історію запису від найновішої ревізії
+func List(db *gorm.DB, entityType string, entityId uuid.UUID) ([]entities.RevisionGet, error) {
+	var revisions []entities.RevisionGet
+	err := db.Table("revisions r").
+		Select("r.number, r.changed_fields, r.comment, r.author_id, r.created_at, COALESCE(u.full_name, '') AS author_name").
+		Joins("LEFT JOIN users u ON u.id = r.author_id").
+		Where("r.entity_type = ? AND r.entity_id = ?", entityType, entityId).
+		Order("r.number DESC").
+		Scan(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Get повертає ревізію з номером; number <= 0 означає останню
+func Get(db *gorm.DB, entityType string, entityId uuid.UUID, number int) (*entities.Revision, error) {
+	var revision entities.Revision
+	query := db.Where("entity_type = ? AND entity_id = ?", entityType, entityId)
+	if number > 0 {
+		query = query.Where("number = ?", number)
+	}
+	err := query.Order("number DESC").First(&revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// Compare будує різницю між двома ревізіями запису
+func Compare(db *gorm.DB, entityType string, entityId uuid.UUID, from int, to int) (*entities.RevisionDiff, error) {
+	fromRevision, err := Get(db, entityType, entityId, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := Get(db, entityType, entityId, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := Diff(fromRevision.Snapshot, toRevision.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.RevisionDiff{From: fromRevision.Number, To: toRevision.Number, Changes: changes}, nil
+}
+
+// Restore розбирає знімок ревізії у структуру запису
+func Restore(db *gorm.DB, entityType string, entityId uuid.UUID, number int, out interface{}) (*entities.Revision, error) {
+	revision, err := Get(db, entityType, entityId, number)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(revision.Snapshot, out); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func DeleteAll(db *gorm.DB, entityType string, entityId uuid.UUID) error {
+	return db.Where("entity_type = ? AND entity_id = ?", entityType, entityId).Delete(&entities.Revision{}).Error
+}
+
+// Diff порівнює два JSON-знімки поле за полем верхнього рівня
+func Diff(from entities.JSON, to entities.JSON) ([]entities.FieldChange, error) {
+	var before, after map[string]interface{}
+	if len(from) > 0 {
+		if err := json.Unmarshal(from, &before); err != nil {
+			return nil, err
+		}
+	}
+	if len(to) > 0 {
+		if err := json.Unmarshal(to, &after); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	changes := []entities.FieldChange{}
+	for _, field := range names {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, entities.FieldChange{Field: field, From: before[field], To: after[field]})
+		}
+	}
+	return changes, nil
+}
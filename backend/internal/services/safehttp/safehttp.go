@@ -0,0 +1,121 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Кількість переспрямувань, яку проходить клієнт
+const maxRedirects = 5
+
+var ErrForbiddenAddress = errors.New("address is not allowed")
+
+// Мережі, яких немає серед перевірок net.IP: "ця мережа" 0.0.0.0/8 і CGNAT 100.64.0.0/10,
+// через які в хмарах часто доступні внутрішні сервіси
+var deniedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+// AllowedIP перевіряє, що адреса публічна: не loopback, не приватна, не link-local і не порожня
+func AllowedIP(ip net.IP) bool {
+	if ip == nil ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range deniedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDR(value string) *net.IPNet {
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// Dialer перевіряє кожну адресу після DNS-резолюції, безпосередньо перед з'єднанням,
+// тому підміна DNS між перевіркою і запитом нічого не дає
+func Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !AllowedIP(net.ParseIP(host)) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+}
+
+// NewClient повертає HTTP-клієнт, що не з'єднується з внутрішніми адресами.
+// Кожне переспрямування проходить ту саму перевірку URL і адреси.
+func NewClient(timeout time.Duration) *http.Client {
+	transport := &http.Transport{
+		// Проксі з оточення обійшов би перевірку адрес
+		Proxy:               nil,
+		DialContext:         Dialer(10 * time.Second).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkScheme(req.URL)
+		},
+	}
+}
+
+// ValidateURL перевіряє схему та всі адреси хоста; використовується для раннього відхилення
+func ValidateURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if err = checkScheme(parsed); err != nil {
+		return err
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if !AllowedIP(address.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenAddress, parsed.Hostname(), address.IP)
+		}
+	}
+	return nil
+}
+
+func checkScheme(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: %q", ErrForbiddenAddress, u.String())
+	}
+	return nil
+}
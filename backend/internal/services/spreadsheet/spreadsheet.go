@@ -0,0 +1,115 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// ParseFormat приймає назву формату або ім'я файлу з розширенням
+func ParseFormat(value string) (Format, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if ext := filepath.Ext(value); ext != "" {
+		value = ext[1:]
+	}
+	switch Format(value) {
+	case FormatCSV, FormatXLSX:
+		return Format(value), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, value)
+}
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Write записує таблицю у вибраному форматі; перший рядок — заголовок
+func Write(w io.Writer, format Format, rows [][]string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, rows)
+	case FormatXLSX:
+		return WriteXLSX(w, rows)
+	}
+	return ErrUnsupportedFormat
+}
+
+// Read читає таблицю з файлу у вибраному форматі
+func Read(data []byte, format Format) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(bytes.NewReader(data))
+	case FormatXLSX:
+		return ReadXLSX(data)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// Символи, з яких табличні редактори починають формулу
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeFormula додає апостроф до значення, яке редактор сприйняв би як формулу
+func EscapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// UnescapeFormula знімає апостроф, доданий EscapeFormula, щоб експорт можна було імпортувати назад
+func UnescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func WriteCSV(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, value := range row {
+			escaped[i] = EscapeFormula(value)
+		}
+		if err := writer.Write(escaped); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func ReadCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	// Excel додає BOM на початку UTF-8 файлу
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	for _, row := range rows {
+		for i := range row {
+			row[i] = UnescapeFormula(row[i])
+		}
+	}
+	return rows, nil
+}
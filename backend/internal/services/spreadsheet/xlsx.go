@@ -0,0 +1,229 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Мінімальний набір частин пакета SpreadsheetML з одним аркушем
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+)
+
+// WriteXLSX записує таблицю як книгу з одним аркушем; всі клітинки — рядки, формули екрануються
+func WriteXLSX(w io.Writer, rows [][]string) error {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&buf, `<row r="%d">`, i+1)
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(&buf, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, columnName(j), i+1)
+			if err = xml.EscapeText(&buf, []byte(EscapeFormula(value))); err != nil {
+				return err
+			}
+			buf.WriteString(`</t></is></c>`)
+		}
+		buf.WriteString(`</row>`)
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	if _, err = buf.WriteTo(sheet); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// columnName перетворює індекс колонки на літери: 0 → A, 26 → AA
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// columnIndex повертає індекс колонки з посилання на клітинку: "AB12" → 27
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
+
+type xlsxText struct {
+	Text []string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	var sb strings.Builder
+	for _, text := range t.Text {
+		sb.WriteString(text)
+	}
+	for _, run := range t.Runs {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX читає перший аркуш книги; формули повертаються збереженим значенням
+func ReadXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	var sheetNames []string
+	for _, file := range archive.File {
+		files[file.Name] = file
+		if path.Dir(file.Name) == "xl/worksheets" && path.Ext(file.Name) == ".xml" {
+			sheetNames = append(sheetNames, file.Name)
+		}
+	}
+	if len(sheetNames) == 0 {
+		return nil, errors.New("invalid xlsx file: no worksheets")
+	}
+	sort.Strings(sheetNames)
+	sheetName := sheetNames[0]
+	if _, ok := files["xl/worksheets/sheet1.xml"]; ok {
+		sheetName = "xl/worksheets/sheet1.xml"
+	}
+
+	var sharedStrings []string
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		var table struct {
+			Items []xlsxText `xml:"si"`
+		}
+		if err = decodeXML(file, &table); err != nil {
+			return nil, err
+		}
+		for _, item := range table.Items {
+			sharedStrings = append(sharedStrings, item.String())
+		}
+	}
+
+	var sheet xlsxSheet
+	if err = decodeXML(files[sheetName], &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		// Порожні рядки в аркуші пропускаються, тому вирівнюємо за номером
+		for row.Number > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+		var values []string
+		for position, cell := range row.Cells {
+			index := position
+			if cell.Ref != "" {
+				index = columnIndex(cell.Ref)
+			}
+			if index < 0 {
+				continue
+			}
+			for len(values) <= index {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				var i int
+				if _, err = fmt.Sscan(cell.Value, &i); err != nil || i < 0 || i >= len(sharedStrings) {
+					return nil, fmt.Errorf("invalid xlsx file: bad shared string in %s", cell.Ref)
+				}
+				values[index] = sharedStrings[i]
+			case "inlineStr":
+				values[index] = cell.Inline.String()
+			default:
+				values[index] = cell.Value
+			}
+			values[index] = UnescapeFormula(values[index])
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+func decodeXML(file *zip.File, v interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return xml.NewDecoder(reader).Decode(v)
+}
@@ -6,11 +6,18 @@ import (
 	"fmt"
 	"github.com/joho/godotenv"
 	"gopkg.in/gomail.v2"
+	"io"
 	"log"
 	"os"
 )
 
-func SendEmail(to string, subject string, body string, isHTML bool) error {
+// Attachment — файл, що додається до листа
+type Attachment struct {
+	Name string
+	Data []byte
+}
+
+func SendEmail(to string, subject string, body string, isHTML bool, attachments ...Attachment) error {
 	err := godotenv.Load(".env")
 	if err != nil {
 		return fmt.Errorf("error loading .env file email: %v", err)
@@ -33,6 +40,12 @@ func SendEmail(to string, subject string, body string, isHTML bool) error {
 	} else {
 		mailer.SetBody("text/plain", body)
 	}
+	for _, attachment := range attachments {
+		mailer.Attach(attachment.Name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(attachment.Data)
+			return err
+		}))
+	}
 
 	dialer := gomail.NewDialer(emailConfig.SMTPHost, emailConfig.SMTPPort, emailConfig.Username, emailConfig.Password)
 	dialer.TLSConfig = &tls.Config{ServerName: emailConfig.SMTPHost}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// EnabledLanguages повертає мови контенту з CONTENT_LANGUAGES (за замовчуванням pl,en,uk)
+func EnabledLanguages() []string {
+	if languages := splitLanguages(os.Getenv("CONTENT_LANGUAGES")); len(languages) > 0 {
+		return languages
+	}
+	return []string{"pl", "en", "uk"}
+}
+
+// ParseLanguages розбирає параметр ?languages=pl,en; без мов у параметрі повертає EnabledLanguages
+func ParseLanguages(param string) []string {
+	if languages := splitLanguages(param); len(languages) > 0 {
+		return languages
+	}
+	return EnabledLanguages()
+}
+
+// splitLanguages ділить список через кому, прибираючи пробіли, порожні значення й повтори
+func splitLanguages(value string) []string {
+	var languages []string
+	for _, language := range strings.Split(value, ",") {
+		language = strings.TrimSpace(language)
+		if language != "" && !contains(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
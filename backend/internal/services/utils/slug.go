@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify перетворює довільний рядок на slug: малі літери, цифри та дефіси; кирилиця та польські літери транслітеруються
+func Slugify(value string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range Transliterate(strings.TrimSpace(value)) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimRight(b.String(), "-")
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Транслітерація української за постановою КМУ №55 (2010) та польських діакритик
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ie", 'ж': "zh",
+	'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n",
+	'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "iu", 'я': "ia",
+	'ą': "a", 'ć': "c", 'ę': "e", 'ł': "l", 'ń': "n", 'ó': "o", 'ś': "s", 'ź': "z", 'ż': "z",
+}
+
+// На початку слова йотовані літери передаються інакше
+var wordInitial = map[rune]string{
+	'є': "ye", 'ї': "yi", 'й': "y", 'ю': "yu", 'я': "ya",
+}
+
+var apostrophes = map[rune]bool{'\'': true, '’': true, 'ʼ': true, '`': true}
+
+// Transliterate переводить український і польський текст у латиницю; решта символів не змінюється
+func Transliterate(value string) string {
+	var b strings.Builder
+	runes := []rune(strings.ToLower(value))
+
+	for i, r := range runes {
+		if apostrophes[r] {
+			continue
+		}
+		start := i == 0 || !unicode.IsLetter(runes[i-1]) && !apostrophes[runes[i-1]]
+		if latin, ok := wordInitial[r]; ok && start {
+			b.WriteString(latin)
+			continue
+		}
+		// «зг» передається як zgh, щоб не плутати з «ж»
+		if r == 'г' && i > 0 && runes[i-1] == 'з' {
+			b.WriteString("gh")
+			continue
+		}
+		if latin, ok := transliteration[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -64,3 +64,17 @@ func GetIsSuperUser(db *gorm.DB, id uuid.UUID) (bool, error) {
 
 	return user.IsSuperUser, nil
 }
+
+// RequireAdmin повертає поточного користувача, якщо він адміністратор або суперкористувач;
+// інакше відповідь уже надіслана
+func RequireAdmin(ctx *gin.Context, db *gorm.DB) (*models.User, bool) {
+	user, ok := GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, false
+	}
+	if !user.IsAdmin && !user.IsSuperUser {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return nil, false
+	}
+	return user, true
+}
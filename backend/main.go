@@ -3,13 +3,35 @@ package main
 import (
 	"backend/internal/db/postgres"
 	"backend/internal/middleware"
+	"backend/internal/services/audit"
+	"backend/internal/services/events"
+	"backend/internal/services/money"
+	"backend/internal/services/presence"
+	"backend/internal/services/publication"
 	"backend/modules/blog"
+	blogRepository "backend/modules/blog/repository"
 	"backend/modules/calendar"
+	"backend/modules/category"
+	"backend/modules/comment"
+	"backend/modules/contact"
+	"backend/modules/document"
+	"backend/modules/feed"
 	"backend/modules/item"
 	"backend/modules/media"
+	"backend/modules/notification"
+	notificationService "backend/modules/notification/service"
+	"backend/modules/order"
+	orderService "backend/modules/order/service"
+	"backend/modules/payment"
+	paymentService "backend/modules/payment/service"
 	"backend/modules/property"
+	"backend/modules/public"
+	"backend/modules/tag"
+	"backend/modules/task"
 	"backend/modules/user"
 	"backend/modules/user/handlers"
+	"backend/modules/webhook"
+	webhookService "backend/modules/webhook/service"
 	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -19,6 +41,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"strings"
+	"time"
 )
 
 func init() {
@@ -38,12 +61,47 @@ func main() {
 
 	postgres.InitAdminDB()
 
+	if err := money.CheckDefaultRates(); err != nil {
+		log.Fatalf("Invalid exchange rates: %v", err)
+	}
+	if _, err := paymentService.Provider(); err != nil {
+		log.Fatalf("Invalid payment settings: %v", err)
+	}
+
+	// Підписники доменних подій: побічні дії між модулями без прямих імпортів
+	media.RegisterSubscribers(events.Default)
+	property.RegisterSubscribers(events.Default)
+	webhook.RegisterSubscribers(events.Default)
+	notification.RegisterSubscribers(events.Default)
+	audit.Register(events.Default)
+
+	// Обробка outbox: асинхронні підписники виконуються після коміту з повторами
+	go events.Default.StartWorker(postgres.DB, time.Second)
+
+	// Планувальник публікацій блогів і товарів
+	go publication.StartScheduler(postgres.DB, publication.Interval(), blogRepository.PublicationHook(), "items", "blogs")
+
+	// Скасування неоплачених замовлень зі сплилим резервом
+	go orderService.StartExpiryWorker(postgres.DB, time.Minute, orderService.ReservationTTL())
+
+	// Присутність користувачів: зміни статусу йдуть у потоки сповіщень, застарілі статуси перераховуються
+	notificationService.ForwardPresence(presence.Default)
+	go presence.Default.StartSweeper(30 * time.Second)
+
+	// Надсилання вебхуків з черги з повторами
+	go webhookService.StartWorker(postgres.DB, 5*time.Second)
+
 	port := os.Getenv("APP_RUN_PORT")
 	fmt.Println(port)
 	gin.SetMode(gin.ReleaseMode)
 
 	r := gin.New()
-	r.Use(gin.Logger())
+	// Client IPs from X-Forwarded-For are used only behind proxies listed in TRUSTED_PROXIES
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	// The notification stream URL may carry the token, so it is kept out of the access log
+	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{SkipPaths: []string{"/v1/notifications/stream"}}))
 	r.Use(redirectFromWWW())
 	r.Use(CustomCors())
 
@@ -76,6 +134,21 @@ func main() {
 	//Users
 	r.POST("/v1/users/signup", handlers.CreateUser)
 
+	// RSS/Atom feeds and sitemap
+	feed.RegisterRoutes(r.Group(""))
+
+	// Public read-only API for published content
+	public.RegisterRoutes(r.Group(""))
+
+	// Comments on blog posts are open to visitors; the token is optional
+	comment.RegisterPublicRoutes(r.Group("/v1", middleware.OptionalAuthMiddleware()))
+
+	// Payment provider webhooks are verified by their signature
+	payment.RegisterWebhookRoutes(r.Group("/v1"))
+
+	// Real-time notifications; EventSource passes the token as a query parameter
+	notification.RegisterStreamRoutes(r.Group("/v1", middleware.StreamAuthMiddleware()))
+
 	//Protecting routes with JWT middleware
 	r.Use(middleware.AuthMiddleware())
 
@@ -91,12 +164,42 @@ func main() {
 	// Items routes
 	item.RegisterRoutes(version)
 
+	// Categories routes
+	category.RegisterRoutes(version)
+
 	// Properties routes
 	property.RegisterRoutes(version)
 
+	// Tags routes
+	tag.RegisterRoutes(version)
+
+	// Comments moderation
+	comment.RegisterRoutes(version)
+
+	// Carts and orders
+	order.RegisterRoutes(version)
+
+	// Payments and refunds
+	payment.RegisterRoutes(version)
+
+	// Invoices, order confirmations and company details
+	document.RegisterRoutes(version)
+
 	// Calendar
 	calendar.RegisterRoutes(version)
 
+	// CRM contacts
+	contact.RegisterRoutes(version)
+
+	// Task boards
+	task.RegisterRoutes(version)
+
+	// Notifications and preferences
+	notification.RegisterRoutes(version)
+
+	// Outgoing webhooks and delivery log
+	webhook.RegisterRoutes(version)
+
 	// Download files
 	media.RegisterRoutes(version)
 
@@ -131,5 +234,49 @@ func CustomCors() gin.HandlerFunc {
 		AllowCredentials: true,
 		MaxAge:           12 * 60 * 60}
 
-	return cors.New(config)
+	// The public API is read-only and is called from the site origins
+	publicConfig := cors.Config{
+		AllowOrigins:  publicOrigins(appUrl),
+		AllowMethods:  []string{"GET", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "If-None-Match", "If-Modified-Since"},
+		ExposeHeaders: []string{"Content-Length", "ETag", "Last-Modified", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+		MaxAge:        12 * 60 * 60}
+
+	private := cors.New(config)
+	publicCors := cors.New(publicConfig)
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, "/public/") {
+			publicCors(c)
+			return
+		}
+		private(c)
+	}
+}
+
+// trustedProxies reads TRUSTED_PROXIES (comma separated IPs or CIDRs); by default no proxy is trusted
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// publicOrigins reads PUBLIC_CORS_ORIGINS (comma separated, "*" allows any site) and falls back to SITE_URL and APP_URL
+func publicOrigins(appUrl string) []string {
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("PUBLIC_CORS_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	if len(origins) == 0 {
+		if site := os.Getenv("SITE_URL"); site != "" {
+			origins = append(origins, site)
+		}
+		origins = append(origins, appUrl)
+	}
+	return origins
 }
@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	internalRepo "backend/internal/repository"
 	utils2 "backend/internal/services/utils"
 	"backend/modules/blog/models"
 	"backend/modules/blog/repository"
+	tagRepo "backend/modules/tag/repository"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"strconv"
 )
 
 func CreateBlogHandler(ctx *gin.Context) {
@@ -26,6 +31,10 @@ func CreateBlogHandler(ctx *gin.Context) {
 	blog.OwnerID = userID
 
 	newBlog, err := repository.CreateBlog(db, &blog)
+	if errors.Is(err, internalRepo.ErrSlugTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -45,7 +54,12 @@ func GetAllBlogsHandler(ctx *gin.Context) {
 
 	isSuperUser, _ := utils2.GetIsSuperUser(db, user.ID)
 
-	blogs, err := repository.GetAllBlogs(db, user.ID, isSuperUser)
+	params, ok := ParseBlogParameters(ctx)
+	if !ok {
+		return
+	}
+
+	blogs, err := repository.GetAllBlogs(db, user.ID, isSuperUser, params)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -54,6 +68,30 @@ func GetAllBlogsHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, blogs)
 }
 
+// ParseBlogParameters читає фільтри списку блогів із query; без limit повертаються всі блоги
+func ParseBlogParameters(ctx *gin.Context) (*entities.Parameters, bool) {
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+
+	params := &entities.Parameters{
+		Language:     ctx.Query("language"),
+		Skip:         skip,
+		Limit:        limit,
+		Tags:         tagRepo.ParseTagSlugs(ctx.Query("tags")),
+		TagsMatchAny: ctx.Query("tags_match") == "any",
+	}
+
+	if state := ctx.Query("state"); state != "" {
+		params.State = entities.PublicationState(state)
+		if !params.State.Valid() {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid state"})
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
 func GetBlogByIdHandler(ctx *gin.Context) {
 	db := postgres.DB
 	userID, ok := utils2.GetUserIDFromContext(ctx)
@@ -101,7 +139,11 @@ func UpdateBlogByIdHandler(ctx *gin.Context) {
 		return
 	}
 
-	blog, err := repository.UpdateBlogById(db, id, &update)
+	blog, err := repository.UpdateBlogById(db, id, user.ID, &update)
+	if errors.Is(err, internalRepo.ErrSlugTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
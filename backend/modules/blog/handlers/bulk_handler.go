@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/blog/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"slices"
+)
+
+func BulkBlogsHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	var request entities.BulkRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Категорій і цін у блогів немає
+	err := request.Validate(entities.BulkSetState, entities.BulkSetLanguage, entities.BulkDelete)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.Operation == entities.BulkSetLanguage && !slices.Contains(utils2.EnabledLanguages(), request.Language) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported language"})
+		return
+	}
+
+	response, err := repository.BulkBlogs(db, user.ID, user.IsSuperUser, &request)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/revision"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/blog/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+func GetBlogRevisionsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+
+	revisions, err := repository.GetBlogRevisions(db, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": revisions, "count": len(revisions)})
+}
+
+func GetBlogRevisionHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil || number < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number"})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+
+	rev, err := repository.GetBlogRevision(db, id, number)
+	if errors.Is(err, revision.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rev)
+}
+
+// CompareBlogRevisionsHandler порівнює ревізії ?from= і ?to=; без to береться остання
+func CompareBlogRevisionsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+	from, err := strconv.Atoi(ctx.Query("from"))
+	if err != nil || from < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from"})
+		return
+	}
+	to := 0
+	if value := ctx.Query("to"); value != "" {
+		to, err = strconv.Atoi(value)
+		if err != nil || to < 1 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to"})
+			return
+		}
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+
+	diff, err := repository.CompareBlogRevisions(db, id, from, to)
+	if errors.Is(err, revision.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
+func RestoreBlogRevisionHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil || number < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number"})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	restored, err := repository.RestoreBlogRevision(db, id, number, user.ID)
+	if errors.Is(err, revision.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, restored)
+}
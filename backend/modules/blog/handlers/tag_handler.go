@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	tagModels "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func GetBlogTagsHandler(ctx *gin.Context) {
+	blogId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	blog, ok := getOwnedBlog(ctx, blogId)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": blog.Tags})
+}
+
+// SetBlogTagsHandler замінює теги блогу; теги кожної мовної версії незалежні
+func SetBlogTagsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	blogId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	var put tagModels.TagsPut
+	if err := ctx.ShouldBindJSON(&put); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, blogId); !ok {
+		return
+	}
+
+	tags, err := tagRepo.SetEntityTags(db, tagModels.EntityBlogs, blogId, put.Tags)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	internalRepo "backend/internal/repository"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/blog/models"
+	"backend/modules/blog/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+func GetBlogTranslationsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+
+	translations, err := repository.GetBlogTranslations(db, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+func CreateBlogTranslationHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	var post entities.TranslationPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+
+	translation, err := repository.CreateBlogTranslation(db, id, &post)
+	if errors.Is(err, internalRepo.ErrTranslationExists) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, translation)
+}
+
+func DeleteBlogTranslationHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	if _, ok := getOwnedBlog(ctx, id); !ok {
+		return
+	}
+
+	err = repository.DeleteBlogTranslation(db, id, ctx.Param("language"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Translation deleted"})
+}
+
+func GetMissingBlogTranslationsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	languages := utils2.ParseLanguages(ctx.Query("languages"))
+
+	report, err := repository.GetMissingBlogTranslations(db, user.ID, user.IsSuperUser, languages)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"languages": languages, "data": report, "count": len(report)})
+}
+
+// getOwnedBlog завантажує блог і перевіряє, що поточний користувач має до нього доступ
+func getOwnedBlog(ctx *gin.Context, id uuid.UUID) (*models.BlogGet, bool) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, false
+	}
+
+	blog, err := repository.GetBlogById(db, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return nil, false
+	}
+
+	if blog.OwnerID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Access denied"})
+		return nil, false
+	}
+	return blog, true
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"backend/internal/entities"
 	"backend/modules/user/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -8,19 +9,31 @@ import (
 )
 
 type Blog struct {
-	ID        uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
-	Title     string      `gorm:"not null" json:"title"`
-	Content   string      `gorm:"not null" json:"content"`
-	Position  int         `gorm:"not null" json:"position"`
-	Language  string      `gorm:"not null" json:"language"`
-	Status    bool        `gorm:"default:false" json:"status"`
-	OwnerID   uuid.UUID   `gorm:"not null;index" json:"-"`
-	User      models.User `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 uuid.UUID              `gorm:"type:uuid;primaryKey" json:"id"`
+	Title              string                 `gorm:"not null" json:"title"`
+	Content            string                 `gorm:"not null" json:"content"`
+	ContentFormat      entities.ContentFormat `gorm:"type:varchar(16);not null;default:'html'" json:"content_format"`
+	ContentHTML        string                 `gorm:"type:text;not null;default:''" json:"-"`
+	Excerpt            string                 `gorm:"type:text;not null;default:''" json:"-"`
+	ReadingTime        int                    `gorm:"not null;default:0" json:"-"`
+	TOC                entities.Headings      `json:"-"`
+	Position           int                    `gorm:"not null" json:"position"`
+	Language           string                 `gorm:"not null;uniqueIndex:idx_blogs_slug_language" json:"language"`
+	Slug               *string                `gorm:"type:varchar(255);uniqueIndex:idx_blogs_slug_language" json:"slug"`
+	TranslationGroupID uuid.UUID              `gorm:"type:uuid;index" json:"translation_group_id"`
+	CommentsEnabled    bool                   `gorm:"not null;default:true" json:"comments_enabled"`
+	OwnerID            uuid.UUID              `gorm:"not null;index" json:"-"`
+	User               models.User            `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	entities.SEO
+	entities.Publication
 }
 
 func (blog *Blog) BeforeCreate(*gorm.DB) error {
 	blog.ID = uuid.New()
+	if blog.TranslationGroupID == uuid.Nil {
+		blog.TranslationGroupID = blog.ID
+	}
 	return nil
 }
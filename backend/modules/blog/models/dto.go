@@ -1,36 +1,62 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"backend/internal/entities"
+	tagModels "backend/modules/tag/models"
+	"github.com/google/uuid"
+	"time"
+)
 
 type BlogPost struct {
-	ID       uuid.UUID
-	Title    string    `json:"title"`
-	Content  string    `json:"content"`
-	Position int       `json:"position"`
-	Language string    `json:"language"`
-	Status   bool      `json:"status"`
-	OwnerID  uuid.UUID `json:"owner_id"`
+	ID            uuid.UUID
+	Title         string                 `json:"title"`
+	Content       string                 `json:"content"`
+	ContentFormat entities.ContentFormat `json:"content_format"`
+	ContentHTML   string                 `json:"content_html"`
+	Position      int                    `json:"position"`
+	Language      string                 `json:"language"`
+	Slug          *string                `json:"slug"`
+	OwnerID       uuid.UUID              `json:"owner_id"`
+	entities.SEO
+	entities.Publication
 }
 
 type BlogGet struct {
-	ID       uuid.UUID
-	Title    string    `json:"title"`
-	Content  string    `json:"content"`
-	Position int       `json:"position"`
-	Language string    `json:"language"`
-	Status   bool      `json:"status"`
-	OwnerID  uuid.UUID `json:"owner_id"`
-	Images   []string  `json:"images"`
+	ID                 uuid.UUID
+	Title              string                 `json:"title"`
+	Content            string                 `json:"content"`
+	ContentFormat      entities.ContentFormat `json:"content_format"`
+	ContentHTML        string                 `json:"content_html"`
+	Excerpt            string                 `json:"excerpt"`
+	ReadingTime        int                    `json:"reading_time"`
+	TOC                entities.Headings      `json:"toc"`
+	Position           int                    `json:"position"`
+	Language           string                 `json:"language"`
+	Slug               *string                `json:"slug"`
+	OwnerID            uuid.UUID              `json:"owner_id"`
+	TranslationGroupID uuid.UUID              `json:"translation_group_id"`
+	CommentsEnabled    bool                   `json:"comments_enabled"`
+	Images             []string               `json:"images"`
+	Tags               []tagModels.TagGet     `json:"tags"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	entities.SEO
+	entities.Publication
 }
 
 type BlogUpdate struct {
-	Title    string `json:"title"`
-	Content  string `json:"content"`
-	Position int    `json:"position"`
-	Status   bool   `json:"status"`
+	Title           string                 `json:"title"`
+	Content         string                 `json:"content"`
+	ContentFormat   entities.ContentFormat `json:"content_format"`
+	Position        int                    `json:"position"`
+	Slug            *string                `json:"slug"`
+	CommentsEnabled *bool                  `json:"comments_enabled"`
+	entities.SEOUpdate
+	entities.PublicationUpdate
 }
 
 type BlogGetAll struct {
 	Data  []*BlogGet
 	Count int
+	Total int64
+	Tags  []tagModels.TagGet
 }
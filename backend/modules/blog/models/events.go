@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// BlogPublished — блог опубліковано вручну чи планувальником
+type BlogPublished struct {
+	ID                 uuid.UUID  `json:"id"`
+	Title              string     `json:"title"`
+	Slug               *string    `json:"slug"`
+	Language           string     `json:"language"`
+	Excerpt            string     `json:"excerpt"`
+	OwnerID            uuid.UUID  `json:"owner_id"`
+	TranslationGroupID uuid.UUID  `json:"translation_group_id"`
+	PublishAt          *time.Time `json:"publish_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func (BlogPublished) EventName() string { return "blog.published" }
+
+func NewBlogPublished(blog *Blog) BlogPublished {
+	return BlogPublished{
+		ID:                 blog.ID,
+		Title:              blog.Title,
+		Slug:               blog.Slug,
+		Language:           blog.Language,
+		Excerpt:            blog.Excerpt,
+		OwnerID:            blog.OwnerID,
+		TranslationGroupID: blog.TranslationGroupID,
+		PublishAt:          blog.PublishAt,
+		UpdatedAt:          blog.UpdatedAt,
+	}
+}
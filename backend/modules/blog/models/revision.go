@@ -0,0 +1,12 @@
+package models
+
+import "backend/internal/entities"
+
+// BlogSnapshot — поля блогу, що зберігаються в ревізії
+type BlogSnapshot struct {
+	Title         string                 `json:"title"`
+	Content       string                 `json:"content"`
+	ContentFormat entities.ContentFormat `json:"content_format"`
+	entities.SEO
+	entities.Publication
+}
@@ -1,48 +1,86 @@
 package repository
 
 import (
+	"backend/internal/entities"
 	"backend/internal/repository"
+	"backend/internal/services/content"
+	"backend/internal/services/events"
+	"backend/internal/services/revision"
 	"backend/modules/blog/models"
 	mediaModel "backend/modules/media/models"
-	"backend/modules/media/service"
+	tagModel "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
 	"errors"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 func CreateBlog(db *gorm.DB, b *models.Blog) (*models.BlogPost, error) {
 	if b.Title == "" {
 		return nil, errors.New("the product title cannot be empty")
 	}
-
-	err := repository.GetPosition(db, b.Position, &models.Blog{})
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	if err := b.Publication.Init(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := renderContent(b); err != nil {
 		return nil, err
 	}
+	if err := b.SEO.Validate(); err != nil {
+		return nil, err
+	}
+	requestedSlug := b.Slug
+	b.Slug = nil
 
-	// Якщо позиція існує, зсуваємо всі наступні
-	if err == nil {
-		if shiftErr := repository.ShiftPositions[models.Blog](db, b.Position, b.Language); shiftErr != nil {
-			return nil, shiftErr
+	// Позиції, slug, запис, ревізія та подія публікації зберігаються разом
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := assignBlogSlug(tx, b, requestedSlug, b.Language); err != nil {
+			return err
 		}
-	}
 
-	err = repository.CreateEssence(db, b)
+		err := repository.GetPosition(tx, b.Position, &models.Blog{})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// Якщо позиція існує, зсуваємо всі наступні
+		if err == nil {
+			if shiftErr := repository.ShiftPositions[models.Blog](tx, b.Position, b.Language); shiftErr != nil {
+				return shiftErr
+			}
+		}
+
+		if err = repository.CreateEssence(tx, b); err != nil {
+			return err
+		}
+		if err = recordBlogRevision(tx, b, b.OwnerID, "created"); err != nil {
+			return err
+		}
+		if b.IsPublished() {
+			return publishPublished(tx, b)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 	return &models.BlogPost{
-		ID:       b.ID,
-		Title:    b.Title,
-		Content:  b.Content,
-		Position: b.Position,
-		Language: b.Language,
-		Status:   b.Status,
-		OwnerID:  b.OwnerID,
+		ID:            b.ID,
+		Title:         b.Title,
+		Content:       b.Content,
+		ContentFormat: b.ContentFormat,
+		ContentHTML:   b.ContentHTML,
+		Position:      b.Position,
+		Language:      b.Language,
+		Slug:          b.Slug,
+		SEO:           b.SEO,
+		Publication:   b.Publication,
+		OwnerID:       b.OwnerID,
 	}, nil
 }
 
-func GetAllBlogs(db *gorm.DB, userId uuid.UUID, isSuperUser bool) (*models.BlogGetAll, error) {
+func GetAllBlogs(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *entities.Parameters) (*models.BlogGetAll, error) {
 	var blogs []*models.Blog
 	var media []*mediaModel.Media
 	response := &models.BlogGetAll{}
@@ -52,21 +90,43 @@ func GetAllBlogs(db *gorm.DB, userId uuid.UUID, isSuperUser bool) (*models.BlogG
 	if !isSuperUser {
 		query = query.Where("owner_id = ?", userId)
 	}
+	if parameters.Language != "" {
+		query = query.Where("language = ?", parameters.Language)
+	}
+	if parameters.State != "" {
+		query = query.Where("state = ?", parameters.State)
+	}
+	query = tagRepo.FilterByTags(query, tagModel.EntityBlogs, "blogs.id", parameters.Tags, parameters.TagsMatchAny)
 
-	// Отримуємо блоги
-	err := query.Find(&blogs).Error
+	if err := query.Session(&gorm.Session{}).Count(&response.Total).Error; err != nil {
+		return nil, err
+	}
+
+	// Отримуємо блоги; без ліміту — усі
+	page := query.Session(&gorm.Session{})
+	if parameters.Limit > 0 {
+		page = page.Offset(max(parameters.Skip, 0)).Limit(parameters.Limit)
+	}
+	err := page.Find(&blogs).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Отримуємо пов'язані медіафайли
+	response.Tags, err = tagRepo.CountTags(db, tagModel.EntityBlogs, query.Session(&gorm.Session{}).Select("blogs.id"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Отримуємо пов'язані медіафайли: власні та спільні для групи перекладів
+	var contentIDs []uuid.UUID
 	var blogIDs []uuid.UUID
 	for _, blog := range blogs {
+		contentIDs = append(contentIDs, sharedContentIDs(blog)...)
 		blogIDs = append(blogIDs, blog.ID)
 	}
 
-	if len(blogIDs) > 0 {
-		err = db.Where("content_id IN (?)", blogIDs).Find(&media).Error
+	if len(contentIDs) > 0 {
+		err = db.Where("content_id IN (?)", contentIDs).Find(&media).Error
 		if err != nil {
 			return nil, err
 		}
@@ -78,16 +138,38 @@ func GetAllBlogs(db *gorm.DB, userId uuid.UUID, isSuperUser bool) (*models.BlogG
 		mediaMap[m.ContentId] = append(mediaMap[m.ContentId], m.Url)
 	}
 
+	tagsMap, err := tagRepo.GetEntityTags(db, tagModel.EntityBlogs, blogIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Формуємо відповідь
 	for _, blog := range blogs {
+		var images []string
+		for _, contentId := range sharedContentIDs(blog) {
+			images = append(images, mediaMap[contentId]...)
+		}
+
 		response.Data = append(response.Data, &models.BlogGet{
-			ID:       blog.ID,
-			Title:    blog.Title,
-			Content:  blog.Content,
-			Position: blog.Position,
-			Status:   blog.Status,
-			OwnerID:  blog.OwnerID,
-			Images:   mediaMap[blog.ID],
+			ID:                 blog.ID,
+			Title:              blog.Title,
+			Content:            blog.Content,
+			ContentFormat:      blog.ContentFormat,
+			ContentHTML:        blog.ContentHTML,
+			Excerpt:            blog.Excerpt,
+			ReadingTime:        blog.ReadingTime,
+			TOC:                blog.TOC,
+			Position:           blog.Position,
+			Language:           blog.Language,
+			Slug:               blog.Slug,
+			SEO:                blog.SEO,
+			Publication:        blog.Publication,
+			OwnerID:            blog.OwnerID,
+			TranslationGroupID: translationGroupID(blog),
+			CommentsEnabled:    blog.CommentsEnabled,
+			Images:             images,
+			Tags:               tagsMap[blog.ID],
+			UpdatedAt:          blog.UpdatedAt,
 		})
 	}
 
@@ -104,57 +186,103 @@ func GetBlogById(db *gorm.DB, id uuid.UUID) (*models.BlogGet, error) {
 		return nil, err
 	}
 
-	err = repository.GetAllMediaByID(db, id, &media)
+	err = db.Where("content_id IN (?)", sharedContentIDs(&blog)).Find(&media).Error
 	if err != nil {
 		return nil, err
 	}
 
-	mediaMap := make(map[uuid.UUID][]string)
+	var images []string
 	for _, m := range media {
-		mediaMap[m.ContentId] = append(mediaMap[m.ContentId], m.Url)
+		images = append(images, m.Url)
 	}
 
-	return &models.BlogGet{
-		ID:       blog.ID,
-		Title:    blog.Title,
-		Content:  blog.Content,
-		Position: blog.Position,
-		Status:   blog.Status,
-		OwnerID:  blog.OwnerID,
-		Images:   mediaMap[blog.ID],
-	}, nil
-}
-
-func UpdateBlogById(db *gorm.DB, id uuid.UUID, updateBlog *models.BlogUpdate) (*models.BlogGet, error) {
-	var blog models.Blog
-
-	// Знаходимо блог за ID
-	err := repository.GetByID(db, id, &blog)
+	tagsMap, err := tagRepo.GetEntityTags(db, tagModel.EntityBlogs, []uuid.UUID{blog.ID})
 	if err != nil {
 		return nil, err
 	}
 
-	// Якщо позиція змінилася - зсуваємо інші блоги
-	if updateBlog.Position != blog.Position {
-		err = repository.ShiftPositions[models.Blog](db, updateBlog.Position, blog.Language) // Передаємо тільки число
+	return &models.BlogGet{
+		ID:                 blog.ID,
+		Title:              blog.Title,
+		Content:            blog.Content,
+		ContentFormat:      blog.ContentFormat,
+		ContentHTML:        blog.ContentHTML,
+		Excerpt:            blog.Excerpt,
+		ReadingTime:        blog.ReadingTime,
+		TOC:                blog.TOC,
+		Position:           blog.Position,
+		Language:           blog.Language,
+		Slug:               blog.Slug,
+		SEO:                blog.SEO,
+		Publication:        blog.Publication,
+		OwnerID:            blog.OwnerID,
+		TranslationGroupID: translationGroupID(&blog),
+		CommentsEnabled:    blog.CommentsEnabled,
+		Images:             images,
+		Tags:               tagsMap[blog.ID],
+		UpdatedAt:          blog.UpdatedAt,
+	}, nil
+}
+
+func UpdateBlogById(db *gorm.DB, id uuid.UUID, userId uuid.UUID, updateBlog *models.BlogUpdate) (*models.BlogGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Блокуємо блог до кінця транзакції: паралельні збереження не перезапишуть одне одного
+		// й не отримають однаковий номер ревізії
+		var blog models.Blog
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&blog).Error
 		if err != nil {
-			return nil, err
+			return err
 		}
-		blog.Position = updateBlog.Position
-	}
 
-	// Оновлюємо поля блогу
-	if updateBlog.Title != "" {
-		blog.Title = updateBlog.Title
-	}
-	if updateBlog.Content != "" {
-		blog.Content = updateBlog.Content
-	}
+		// Якщо позиція змінилася - зсуваємо інші блоги
+		if updateBlog.Position != blog.Position {
+			err := repository.ShiftPositions[models.Blog](tx, updateBlog.Position, blog.Language) // Передаємо тільки число
+			if err != nil {
+				return err
+			}
+			blog.Position = updateBlog.Position
+		}
+
+		// Оновлюємо поля блогу
+		if updateBlog.Title != "" {
+			blog.Title = updateBlog.Title
+		}
+		if updateBlog.Content != "" {
+			blog.Content = updateBlog.Content
+		}
+		if updateBlog.ContentFormat != "" {
+			blog.ContentFormat = updateBlog.ContentFormat
+		}
+		if updateBlog.CommentsEnabled != nil {
+			blog.CommentsEnabled = *updateBlog.CommentsEnabled
+		}
+		if err := renderContent(&blog); err != nil {
+			return err
+		}
+		if err := assignBlogSlug(tx, &blog, updateBlog.Slug, blog.Language); err != nil {
+			return err
+		}
+		if err := blog.SEO.Apply(&updateBlog.SEOUpdate); err != nil {
+			return err
+		}
 
-	blog.Status = updateBlog.Status
+		wasPublished := blog.IsPublished()
+		if err := blog.Publication.Apply(&updateBlog.PublicationUpdate, time.Now()); err != nil {
+			return err
+		}
 
-	// Зберігаємо оновлений блог
-	err = db.Save(&blog).Error
+		// Зберігаємо оновлений блог разом з ревізією
+		if err := tx.Save(&blog).Error; err != nil {
+			return err
+		}
+		if err := recordBlogRevision(tx, &blog, userId, ""); err != nil {
+			return err
+		}
+		if !wasPublished && blog.IsPublished() {
+			return publishPublished(tx, &blog)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -163,30 +291,60 @@ func UpdateBlogById(db *gorm.DB, id uuid.UUID, updateBlog *models.BlogUpdate) (*
 	return GetBlogById(db, id)
 }
 
+// DeleteBlogById видаляє мовну версію блогу; медіа прибирають підписники події content.deleted
 func DeleteBlogById(db *gorm.DB, id uuid.UUID) error {
-	var blog models.Blog
-	var mediaList []mediaModel.Media
+	return db.Transaction(func(tx *gorm.DB) error {
+		var blog models.Blog
 
-	err := repository.DeleteByID(db, id, &blog)
-	if err != nil {
-		return err
-	}
+		err := repository.GetByID(tx, id, &blog)
+		if err != nil {
+			return err
+		}
+		groupId := translationGroupID(&blog)
 
-	err = repository.GetAllMediaByID(db, id, &mediaList)
-	if err != nil {
-		return err
-	}
-	for _, media := range mediaList {
-		err = service.DeleteImageInBucket(media.Url)
+		siblings, err := repository.CountTranslations[models.Blog](tx, groupId, id)
 		if err != nil {
 			return err
 		}
-	}
 
-	err = repository.DeleteContentByID(db, id, &mediaModel.Media{})
+		err = repository.DeleteByID(tx, id, &blog)
+		if err != nil {
+			return err
+		}
+		if err = revision.DeleteAll(tx, revision.EntityBlog, id); err != nil {
+			return err
+		}
+		if err = repository.DeleteSlugRedirects[models.Blog](tx, id); err != nil {
+			return err
+		}
+		if err = tagRepo.DeleteEntityTags(tx, tagModel.EntityBlogs, id); err != nil {
+			return err
+		}
+
+		return events.Publish(tx, events.ContentDeleted{
+			Entity:             events.EntityBlogs,
+			ID:                 id,
+			TranslationGroupID: groupId,
+			LastTranslation:    siblings == 0,
+		})
+	})
+}
+
+// renderContent санітизує текст блогу та оновлює HTML, витяг, час читання і зміст
+func renderContent(blog *models.Blog) error {
+	format, err := content.ParseFormat(string(blog.ContentFormat))
+	if err != nil {
+		return err
+	}
+	document, err := content.Render(blog.Content, format)
 	if err != nil {
 		return err
 	}
 
+	blog.ContentFormat = format
+	blog.ContentHTML = document.HTML
+	blog.Excerpt = document.Excerpt
+	blog.ReadingTime = document.ReadingTime
+	blog.TOC = document.TOC
 	return nil
 }
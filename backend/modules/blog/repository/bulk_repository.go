@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/repository"
+	"backend/modules/blog/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// BulkBlogs виконує масову операцію над блогами з перевіркою власника для кожного ID
+func BulkBlogs(db *gorm.DB, userId uuid.UUID, isSuperUser bool, request *entities.BulkRequest) (*entities.BulkResponse, error) {
+	resolve := func(tx *gorm.DB) ([]uuid.UUID, error) {
+		return bulkBlogIDs(tx, userId, isSuperUser, request.Filter)
+	}
+
+	return repository.RunBulk(db, request, resolve, func(tx *gorm.DB, id uuid.UUID) error {
+		var blog models.Blog
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&blog).Error
+		if err != nil {
+			return err
+		}
+		if blog.OwnerID != userId && !isSuperUser {
+			return repository.ErrBulkAccessDenied
+		}
+
+		switch request.Operation {
+		case entities.BulkSetState:
+			wasPublished := blog.IsPublished()
+			err = blog.Publication.Apply(&entities.PublicationUpdate{State: request.State}, time.Now())
+			if err != nil {
+				return err
+			}
+			if err = tx.Save(&blog).Error; err != nil {
+				return err
+			}
+			if !wasPublished && blog.IsPublished() {
+				if err = publishPublished(tx, &blog); err != nil {
+					return err
+				}
+			}
+			return recordBlogRevision(tx, &blog, userId, "")
+		case entities.BulkSetLanguage:
+			if blog.Language == request.Language {
+				return nil
+			}
+			err = repository.CheckTranslationAvailable[models.Blog](tx, translationGroupID(&blog), request.Language, blog.ID)
+			if err != nil {
+				return err
+			}
+			previousLanguage := blog.Language
+			blog.Language = request.Language
+			if err = assignBlogSlug(tx, &blog, nil, previousLanguage); err != nil {
+				return err
+			}
+			return tx.Model(&blog).Updates(map[string]interface{}{"language": blog.Language, "slug": blog.Slug}).Error
+		case entities.BulkDelete:
+			return DeleteBlogById(tx, id)
+		}
+		return errors.New("unsupported operation")
+	})
+}
+
+// bulkBlogIDs вибирає ID блогів за фільтром у межах доступних користувачу записів
+func bulkBlogIDs(db *gorm.DB, userId uuid.UUID, isSuperUser bool, filter *entities.BulkFilter) ([]uuid.UUID, error) {
+	query := db.Model(&models.Blog{})
+	if !isSuperUser {
+		query = query.Where("owner_id = ?", userId)
+	}
+	if filter.Language != "" {
+		query = query.Where("language = ?", filter.Language)
+	}
+	if filter.State != "" {
+		query = query.Where("state = ?", filter.State)
+	}
+
+	var ids []uuid.UUID
+	err := query.Order("position ASC").Limit(entities.BulkMaxItems+1).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return repository.LimitBulkIDs(ids)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/events"
+	"backend/internal/services/publication"
+	"backend/modules/blog/models"
+	"gorm.io/gorm"
+)
+
+// publishPublished публікує подію blog.published; у транзакції разом з нею відкочуються й записи outbox
+func publishPublished(db *gorm.DB, blog *models.Blog) error {
+	return events.Publish(db, models.NewBlogPublished(blog))
+}
+
+// PublicationHook повертає обробник переходів планувальника публікацій, що публікує подію
+// про блоги, опубліковані за розкладом, у транзакції планувальника
+func PublicationHook() publication.Hook {
+	return func(tx *gorm.DB, transition publication.Transition) error {
+		if transition.Table != "blogs" || transition.State != entities.StatePublished {
+			return nil
+		}
+		var blog models.Blog
+		if err := tx.First(&blog, "id = ?", transition.ID).Error; err != nil {
+			return err
+		}
+		return publishPublished(tx, &blog)
+	}
+}
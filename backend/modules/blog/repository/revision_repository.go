@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/revision"
+	"backend/modules/blog/models"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func recordBlogRevision(tx *gorm.DB, blog *models.Blog, authorId uuid.UUID, comment string) error {
+	snapshot := &models.BlogSnapshot{
+		Title:         blog.Title,
+		Content:       blog.Content,
+		ContentFormat: blog.ContentFormat,
+		SEO:           blog.SEO,
+		Publication:   blog.Publication,
+	}
+	_, err := revision.Record(tx, revision.EntityBlog, blog.ID, authorId, snapshot, comment)
+	return err
+}
+
+func GetBlogRevisions(db *gorm.DB, blogId uuid.UUID) ([]entities.RevisionGet, error) {
+	return revision.List(db, revision.EntityBlog, blogId)
+}
+
+func GetBlogRevision(db *gorm.DB, blogId uuid.UUID, number int) (*entities.Revision, error) {
+	return revision.Get(db, revision.EntityBlog, blogId, number)
+}
+
+func CompareBlogRevisions(db *gorm.DB, blogId uuid.UUID, from int, to int) (*entities.RevisionDiff, error) {
+	return revision.Compare(db, revision.EntityBlog, blogId, from, to)
+}
+
+// RestoreBlogRevision повертає заголовок, текст і SEO-метадані блогу до стану ревізії; стан публікації не змінюється
+func RestoreBlogRevision(db *gorm.DB, blogId uuid.UUID, number int, userId uuid.UUID) (*models.BlogGet, error) {
+	var snapshot models.BlogSnapshot
+	if _, err := revision.Restore(db, revision.EntityBlog, blogId, number, &snapshot); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var blog models.Blog
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", blogId).First(&blog).Error
+		if err != nil {
+			return err
+		}
+
+		blog.Title = snapshot.Title
+		blog.Content = snapshot.Content
+		if snapshot.ContentFormat != "" {
+			blog.ContentFormat = snapshot.ContentFormat
+		}
+		if err = renderContent(&blog); err != nil {
+			return err
+		}
+		if err = blog.SEO.Apply(&entities.SEOUpdate{
+			MetaTitle:       &snapshot.MetaTitle,
+			MetaDescription: &snapshot.MetaDescription,
+			OGImage:         &snapshot.OGImage,
+		}); err != nil {
+			return err
+		}
+		if err = tx.Save(&blog).Error; err != nil {
+			return err
+		}
+		return recordBlogRevision(tx, &blog, userId, fmt.Sprintf("restored from revision %d", number))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetBlogById(db, blogId)
+}
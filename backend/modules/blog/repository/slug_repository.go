@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"backend/internal/repository"
+	"backend/internal/services/utils"
+	"backend/modules/blog/models"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// assignBlogSlug задає slug блогу в його мові за тими ж правилами, що й для товарів:
+// явний slug має бути вільним, порожній генерується з заголовка, попередній стає перенаправленням
+func assignBlogSlug(tx *gorm.DB, blog *models.Blog, requested *string, previousLanguage string) error {
+	previous := ""
+	if blog.Slug != nil {
+		previous = *blog.Slug
+	}
+
+	var slug string
+	var err error
+	switch {
+	case requested != nil && strings.TrimSpace(*requested) != "":
+		slug, err = repository.CheckSlugAvailable[models.Blog](tx, utils.Slugify(*requested), blog.Language, blog.ID)
+	case requested == nil && previous != "":
+		slug, err = repository.UniqueSlug[models.Blog](tx, previous, blog.Language, blog.ID)
+	default:
+		slug, err = repository.UniqueSlug[models.Blog](tx, utils.Slugify(blog.Title), blog.Language, blog.ID)
+	}
+	if err != nil {
+		return err
+	}
+	blog.Slug = &slug
+
+	if previous != "" && (previous != slug || previousLanguage != blog.Language) {
+		return repository.SaveSlugRedirect[models.Blog](tx, blog.ID, previousLanguage, previous)
+	}
+	return nil
+}
+
+// GetBlogBySlug повертає блог за slug; redirected означає, що slug застарів і треба перейти на blog.Slug
+func GetBlogBySlug(db *gorm.DB, language string, slug string) (blog *models.BlogGet, redirected bool, err error) {
+	var found models.Blog
+	redirected, err = repository.FindBySlug(db, language, slug, &found)
+	if err != nil {
+		return nil, false, err
+	}
+	blog, err = GetBlogById(db, found.ID)
+	return blog, redirected, err
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/repository"
+	"backend/modules/blog/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+func translationGroupID(blog *models.Blog) uuid.UUID {
+	if blog.TranslationGroupID == uuid.Nil {
+		return blog.ID
+	}
+	return blog.TranslationGroupID
+}
+
+// sharedContentIDs повертає ключі медіа блогу: спільний ключ групи та власний ID перекладу
+func sharedContentIDs(blog *models.Blog) []uuid.UUID {
+	groupId := translationGroupID(blog)
+	if groupId == blog.ID {
+		return []uuid.UUID{blog.ID}
+	}
+	return []uuid.UUID{groupId, blog.ID}
+}
+
+func GetBlogTranslations(db *gorm.DB, blogId uuid.UUID) ([]entities.TranslationGet, error) {
+	var blog models.Blog
+	err := repository.GetByID(db, blogId, &blog)
+	if err != nil {
+		return nil, err
+	}
+	return repository.GetTranslations[models.Blog](db, translationGroupID(&blog))
+}
+
+func CreateBlogTranslation(db *gorm.DB, blogId uuid.UUID, post *entities.TranslationPost) (*models.BlogGet, error) {
+	var source models.Blog
+	err := repository.GetByID(db, blogId, &source)
+	if err != nil {
+		return nil, err
+	}
+	groupId := translationGroupID(&source)
+
+	if post.Language == source.Language {
+		return nil, repository.ErrTranslationExists
+	}
+	err = repository.CheckTranslationAvailable[models.Blog](db, groupId, post.Language, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	publication := entities.Publication{State: post.State}
+	if err = publication.Init(time.Now()); err != nil {
+		return nil, err
+	}
+
+	translation := &models.Blog{
+		Title:              post.Title,
+		Content:            post.Content,
+		ContentFormat:      source.ContentFormat,
+		Language:           post.Language,
+		Publication:        publication,
+		TranslationGroupID: groupId,
+		OwnerID:            source.OwnerID,
+	}
+
+	// Переклад, його позиція, slug і перша ревізія записуються разом
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Позиції в кожній мові незалежні: без явної позиції переклад стає останнім
+		if post.Position != nil {
+			translation.Position = *post.Position
+			err = repository.GetPosition(tx.Where("language = ?", post.Language), translation.Position, &models.Blog{})
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil {
+				if shiftErr := repository.ShiftPositions[models.Blog](tx, translation.Position, translation.Language); shiftErr != nil {
+					return shiftErr
+				}
+			}
+		} else {
+			var maxPosition *int
+			err = tx.Model(&models.Blog{}).Where("language = ?", post.Language).Select("MAX(position)").Scan(&maxPosition).Error
+			if err != nil {
+				return err
+			}
+			if maxPosition != nil {
+				translation.Position = *maxPosition + 1
+			}
+		}
+
+		if err = renderContent(translation); err != nil {
+			return err
+		}
+		if err = assignBlogSlug(tx, translation, nil, translation.Language); err != nil {
+			return err
+		}
+		if err = repository.CreateEssence(tx, translation); err != nil {
+			return err
+		}
+		return recordBlogRevision(tx, translation, translation.OwnerID, "created")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetBlogById(db, translation.ID)
+}
+
+func DeleteBlogTranslation(db *gorm.DB, blogId uuid.UUID, language string) error {
+	var blog models.Blog
+	err := repository.GetByID(db, blogId, &blog)
+	if err != nil {
+		return err
+	}
+
+	var translation models.Blog
+	err = db.Where("translation_group_id = ? AND language = ?", translationGroupID(&blog), language).First(&translation).Error
+	if err != nil {
+		return err
+	}
+
+	siblings, err := repository.CountTranslations[models.Blog](db, translationGroupID(&blog), translation.ID)
+	if err != nil {
+		return err
+	}
+	if siblings == 0 {
+		return errors.New("cannot remove the only language version of a blog post")
+	}
+
+	return DeleteBlogById(db, translation.ID)
+}
+
+func GetMissingBlogTranslations(db *gorm.DB, userId uuid.UUID, isSuperUser bool, languages []string) ([]entities.MissingTranslation, error) {
+	query := db.Model(&models.Blog{})
+	if !isSuperUser {
+		query = query.Where("owner_id = ?", userId)
+	}
+	return repository.FindMissingTranslations(query, languages)
+}
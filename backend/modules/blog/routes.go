@@ -9,9 +9,21 @@ func RegisterRoutes(r *gin.RouterGroup) {
 	blogGroup := r.Group("/blog")
 	{
 		blogGroup.POST("/", handlers.CreateBlogHandler)
+		blogGroup.POST("/bulk", handlers.BulkBlogsHandler)
 		blogGroup.GET("/", handlers.GetAllBlogsHandler)
+		blogGroup.GET("/slug/:language/:slug", handlers.GetBlogBySlugHandler)
 		blogGroup.GET("/:id", handlers.GetBlogByIdHandler)
 		blogGroup.PATCH("/:id", handlers.UpdateBlogByIdHandler)
 		blogGroup.DELETE("/:id", handlers.DeleteBlogByIdHandler)
+		blogGroup.GET("/:id/tags", handlers.GetBlogTagsHandler)
+		blogGroup.PUT("/:id/tags", handlers.SetBlogTagsHandler)
+		blogGroup.GET("/:id/revisions", handlers.GetBlogRevisionsHandler)
+		blogGroup.GET("/:id/revisions/diff", handlers.CompareBlogRevisionsHandler)
+		blogGroup.GET("/:id/revisions/:number", handlers.GetBlogRevisionHandler)
+		blogGroup.POST("/:id/revisions/:number/restore", handlers.RestoreBlogRevisionHandler)
+		blogGroup.GET("/:id/translations", handlers.GetBlogTranslationsHandler)
+		blogGroup.POST("/:id/translations", handlers.CreateBlogTranslationHandler)
+		blogGroup.DELETE("/:id/translations/:language", handlers.DeleteBlogTranslationHandler)
+		blogGroup.GET("/translations/missing", handlers.GetMissingBlogTranslationsHandler)
 	}
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// EventCreated — створено подію календаря
+type EventCreated struct {
+	Event *CalendarEvent `json:"event"`
+}
+
+func (EventCreated) EventName() string { return "calendar.event.created" }
+
+// ReminderDue — настав час нагадати про подію її власнику
+type ReminderDue struct {
+	EventID     uuid.UUID `json:"event_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	StartDate   time.Time `json:"start_date"`
+}
+
+func (ReminderDue) EventName() string { return "calendar.reminder_due" }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"backend/internal/repository"
+	"backend/internal/services/events"
 	"backend/modules/calendar/models"
 	"errors"
 	"github.com/google/uuid"
@@ -32,11 +33,7 @@ func CreateEvent(db *gorm.DB, c *models.Calendar) (*models.CalendarEvent, error)
 
 	log.Printf("📌 The event '%s' reminds us of %s ", c.Title, reminderTime)
 
-	if err := db.Create(c).Error; err != nil {
-		return nil, err
-	}
-
-	return &models.CalendarEvent{
+	event := &models.CalendarEvent{
 		ID:             c.ID,
 		Title:          c.Title,
 		Description:    c.Description,
@@ -52,7 +49,19 @@ func CreateEvent(db *gorm.DB, c *models.Calendar) (*models.CalendarEvent, error)
 		SendMail:       c.SendEmail,
 		ReminderSent:   c.ReminderSent,
 		UserID:         c.UserID,
-	}, nil
+	}
+
+	// Подія зберігається разом із записом, тож підписники не бачать неіснуючого запису
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(c).Error; err != nil {
+			return err
+		}
+		return events.Publish(tx, models.EventCreated{Event: event})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
 func GetAllEvents(db *gorm.DB, userId uuid.UUID) ([]models.CalendarEvent, error) {
@@ -1,51 +1,33 @@
 package reminder
 
 import (
-	"backend/internal/services/utils"
+	"backend/internal/services/events"
 	"backend/modules/calendar/models"
 	"backend/modules/calendar/service"
-	"backend/modules/user/repository"
-	"fmt"
 	"gorm.io/gorm"
 	"log"
-	"time"
 )
 
+// SendReminder публікує calendar.reminder_due і позначає нагадування відправленим в одній транзакції:
+// сповіщення надсилає підписник після коміту, тож нагадування не загубиться і не піде двічі
 func SendReminder(db *gorm.DB, event models.Calendar) {
-	user, err := repository.GetUserById(db, event.UserID)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := events.Publish(tx, models.ReminderDue{
+			EventID:     event.ID,
+			UserID:      event.UserID,
+			Title:       event.Title,
+			Description: event.Description,
+			StartDate:   event.StartDate,
+		})
+		if err != nil {
+			return err
+		}
+		return service.MarkReminderSent(tx, event.ID)
+	})
 	if err != nil {
-		log.Printf("⚠️ Event '%s' has no user email, skipped.\n", event.Title)
+		log.Printf("❌ Error scheduling a reminder for an event '%s': %v\n", event.Title, err)
 		return
 	}
 
-	log.Printf("👤 Found user: %s (%s)", user.FullName, user.Email)
-
-	warsawLoc, err := time.LoadLocation("Europe/Warsaw")
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	subject := fmt.Sprintf("🔔 Reminder.: %s", event.Title)
-	message := fmt.Sprintf(`
-		<h3>Hello, %s!</h3>
-		<p>We remind you that the event <strong>%s</strong> will begin <strong>%s</strong>.</p>
-		<p>Details: %s</p>
-		<hr>
-		<p><em>This is an automated message. Do not reply to it.</em></p>`,
-		user.FullName, event.Title, event.StartDate.In(warsawLoc).Format("02.01.2006 15:04"), event.Description,
-	)
-
-	err = utils.SendEmail(user.Email, subject, message, true)
-	if err != nil {
-		log.Printf("❌ Error sending email for an event '%s' (%s): %v\n", event.Title, user.Email, err)
-		return
-	}
-
-	log.Printf("✅ A reminder has been sent: %s (%s)\n", event.Title, user.Email)
-
-	// Позначаємо подію як "нагадування відправлено"
-	err = service.MarkReminderSent(db, event.ID)
-	if err != nil {
-		log.Printf("❌ Event update error '%s': %v\n", event.Title, err)
-	}
+	log.Printf("✅ A reminder has been queued: %s\n", event.Title)
 }
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/category/models"
+	"backend/modules/category/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+func CreateCategoryHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	var post models.CategoryPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := repository.CreateCategory(db, &post)
+	if errors.Is(err, repository.ErrCategorySlugTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, category)
+}
+
+func GetAllCategoriesHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	categories, err := repository.GetAllCategories(db, ctx.DefaultQuery("language", "pl"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, categories)
+}
+
+func GetCategoryTreeHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	tree, err := repository.GetCategoryTree(db, ctx.DefaultQuery("language", "pl"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tree)
+}
+
+func GetCategoryByIdHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	category, err := repository.GetCategoryById(db, id, ctx.DefaultQuery("language", "pl"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+func UpdateCategoryHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var update models.CategoryUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := repository.UpdateCategory(db, id, &update)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+	if errors.Is(err, repository.ErrCategorySlugTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+func DeleteCategoryHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	err = repository.DeleteCategory(db, id)
+	if errors.Is(err, repository.ErrCategoryHasChildren) || errors.Is(err, repository.ErrCategoryAttributes) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Category deleted"})
+}
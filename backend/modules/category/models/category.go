@@ -0,0 +1,39 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Category struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	ParentID  *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id"`
+	Slug      string         `gorm:"not null;uniqueIndex" json:"slug"`
+	Position  int            `gorm:"not null;default:0" json:"position"`
+	Names     []CategoryName `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE" json:"names"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CategoryName — назва категорії для конкретної мови
+type CategoryName struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	CategoryID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_category_language" json:"category_id"`
+	Language   string    `gorm:"not null;uniqueIndex:idx_category_language" json:"language"`
+	Name       string    `gorm:"not null" json:"name"`
+}
+
+func (category *Category) BeforeCreate(*gorm.DB) error {
+	if category.ID == uuid.Nil {
+		category.ID = uuid.New()
+	}
+	return nil
+}
+
+func (name *CategoryName) BeforeCreate(*gorm.DB) error {
+	if name.ID == uuid.Nil {
+		name.ID = uuid.New()
+	}
+	return nil
+}
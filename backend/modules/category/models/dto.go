@@ -0,0 +1,33 @@
+package models
+
+import "github.com/google/uuid"
+
+type CategoryPost struct {
+	ParentID *uuid.UUID        `json:"parent_id"`
+	Slug     string            `json:"slug"`
+	Position int               `json:"position"`
+	Names    map[string]string `json:"names"`
+}
+
+type CategoryGet struct {
+	ID       uuid.UUID         `json:"id"`
+	ParentID *uuid.UUID        `json:"parent_id"`
+	Slug     string            `json:"slug"`
+	Position int               `json:"position"`
+	Name     string            `json:"name"`
+	Names    map[string]string `json:"names"`
+	Children []*CategoryGet    `json:"children,omitempty"`
+}
+
+type CategoryUpdate struct {
+	ParentID *uuid.UUID        `json:"parent_id"`
+	IsRoot   bool              `json:"is_root"`
+	Slug     *string           `json:"slug"`
+	Position *int              `json:"position"`
+	Names    map[string]string `json:"names"`
+}
+
+type CategoryGetAll struct {
+	Data  []*CategoryGet
+	Count int
+}
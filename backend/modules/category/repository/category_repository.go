@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"backend/internal/repository"
+	"backend/internal/services/utils"
+	"backend/modules/category/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrCategoryCycle       = errors.New("a category cannot be moved under itself or its descendants")
+	ErrCategoryHasChildren = errors.New("the category has subcategories")
+	ErrCategoryAttributes  = errors.New("the category has its own attributes; move or delete them first")
+	ErrCategorySlugTaken   = errors.New("the category slug is already taken")
+)
+
+func CreateCategory(db *gorm.DB, post *models.CategoryPost) (*models.CategoryGet, error) {
+	if len(post.Names) == 0 {
+		return nil, errors.New("the category name cannot be empty")
+	}
+
+	slug := utils.Slugify(post.Slug)
+	if slug == "" {
+		slug = utils.Slugify(firstName(post.Names))
+	}
+	if slug == "" {
+		return nil, errors.New("the category slug cannot be empty")
+	}
+
+	if post.ParentID != nil {
+		if err := repository.GetByID(db, *post.ParentID, &models.Category{}); err != nil {
+			return nil, errors.New("parent category not found")
+		}
+	}
+
+	category := &models.Category{
+		ParentID: post.ParentID,
+		Slug:     slug,
+		Position: post.Position,
+	}
+	for language, name := range post.Names {
+		category.Names = append(category.Names, models.CategoryName{Language: language, Name: name})
+	}
+
+	if err := checkSlugAvailable(db, slug, uuid.Nil); err != nil {
+		return nil, err
+	}
+	if err := repository.CreateEssence(db, category); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, ErrCategorySlugTaken
+		}
+		return nil, err
+	}
+	return toCategoryGet(category, ""), nil
+}
+
+func GetCategoryById(db *gorm.DB, id uuid.UUID, language string) (*models.CategoryGet, error) {
+	var category models.Category
+	err := db.Preload("Names").Where("id = ?", id).First(&category).Error
+	if err != nil {
+		return nil, err
+	}
+	return toCategoryGet(&category, language), nil
+}
+
+func GetAllCategories(db *gorm.DB, language string) (*models.CategoryGetAll, error) {
+	var categories []*models.Category
+	err := db.Preload("Names").Order("position ASC, slug ASC").Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.CategoryGetAll{}
+	for _, category := range categories {
+		response.Data = append(response.Data, toCategoryGet(category, language))
+	}
+	response.Count = len(categories)
+	return response, nil
+}
+
+// GetCategoryTree повертає категорії у вигляді дерева
+func GetCategoryTree(db *gorm.DB, language string) ([]*models.CategoryGet, error) {
+	all, err := GetAllCategories(db, language)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[uuid.UUID]*models.CategoryGet, len(all.Data))
+	for _, category := range all.Data {
+		nodes[category.ID] = category
+	}
+
+	roots := make([]*models.CategoryGet, 0)
+	for _, category := range all.Data {
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+	return roots, nil
+}
+
+// GetSubtreeIDs повертає ID категорії та всіх її нащадків
+func GetSubtreeIDs(db *gorm.DB, id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := db.Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree`, id).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func UpdateCategory(db *gorm.DB, id uuid.UUID, update *models.CategoryUpdate) (*models.CategoryGet, error) {
+	var category models.Category
+	err := repository.GetByID(db, id, &category)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.IsRoot {
+		category.ParentID = nil
+	} else if update.ParentID != nil && (category.ParentID == nil || *category.ParentID != *update.ParentID) {
+		// Нова батьківська категорія не може бути нащадком поточної
+		subtree, err := GetSubtreeIDs(db, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, childId := range subtree {
+			if childId == *update.ParentID {
+				return nil, ErrCategoryCycle
+			}
+		}
+		if err = repository.GetByID(db, *update.ParentID, &models.Category{}); err != nil {
+			return nil, errors.New("parent category not found")
+		}
+		category.ParentID = update.ParentID
+	}
+
+	if update.Slug != nil {
+		slug := utils.Slugify(*update.Slug)
+		if slug == "" {
+			return nil, errors.New("the category slug cannot be empty")
+		}
+		if err = checkSlugAvailable(db, slug, id); err != nil {
+			return nil, err
+		}
+		category.Slug = slug
+	}
+	if update.Position != nil {
+		category.Position = *update.Position
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&category).Error; err != nil {
+			return err
+		}
+		for language, name := range update.Names {
+			if err := SetCategoryName(tx, id, language, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if repository.IsUniqueViolation(err) {
+		return nil, ErrCategorySlugTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return GetCategoryById(db, id, "")
+}
+
+// SetCategoryName створює, оновлює або (для пустого імені) видаляє назву для мови
+func SetCategoryName(db *gorm.DB, categoryId uuid.UUID, language string, name string) error {
+	if name == "" {
+		return db.Where("category_id = ? AND language = ?", categoryId, language).
+			Delete(&models.CategoryName{}).Error
+	}
+
+	var existing models.CategoryName
+	err := db.Where("category_id = ? AND language = ?", categoryId, language).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return db.Create(&models.CategoryName{CategoryID: categoryId, Language: language, Name: name}).Error
+	}
+	if err != nil {
+		return err
+	}
+	existing.Name = name
+	return db.Save(&existing).Error
+}
+
+func DeleteCategory(db *gorm.DB, id uuid.UUID) error {
+	var children int64
+	err := db.Model(&models.Category{}).Where("parent_id = ?", id).Count(&children).Error
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrCategoryHasChildren
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Атрибути категорії разом зі значеннями не мають сенсу без неї, тож видалення відхиляється
+		var attributes int64
+		if err := tx.Table("attributes").Where("category_id = ?", id).Count(&attributes).Error; err != nil {
+			return err
+		}
+		if attributes > 0 {
+			return ErrCategoryAttributes
+		}
+
+		// Товари втрачають категорію, але не видаляються
+		if err := tx.Table("items").Where("category_id = ?", id).Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("category_id = ?", id).Delete(&models.CategoryName{}).Error; err != nil {
+			return err
+		}
+		return repository.DeleteByID(tx, id, &models.Category{})
+	})
+}
+
+func toCategoryGet(category *models.Category, language string) *models.CategoryGet {
+	names := make(map[string]string, len(category.Names))
+	for _, n := range category.Names {
+		names[n.Language] = n.Name
+	}
+
+	name, ok := names[language]
+	if !ok {
+		name = firstName(names)
+	}
+
+	return &models.CategoryGet{
+		ID:       category.ID,
+		ParentID: category.ParentID,
+		Slug:     category.Slug,
+		Position: category.Position,
+		Name:     name,
+		Names:    names,
+	}
+}
+
+// firstName повертає назву зі стабільним порядком мов, якщо потрібної мови немає
+func firstName(names map[string]string) string {
+	languages := make([]string, 0, len(names))
+	for language := range names {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	for _, language := range languages {
+		if names[language] != "" {
+			return names[language]
+		}
+	}
+	return ""
+}
+
+// GetAncestorIDs повертає ID категорії та всіх її предків
+func GetAncestorIDs(db *gorm.DB, id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM categories WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+		)
+		SELECT id FROM ancestors`, id).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// checkSlugAvailable перевіряє, що slug не зайнятий іншою категорією
+func checkSlugAvailable(db *gorm.DB, slug string, exceptId uuid.UUID) error {
+	var count int64
+	err := db.Model(&models.Category{}).Where("slug = ? AND id <> ?", slug, exceptId).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrCategorySlugTaken
+	}
+	return nil
+}
+
+// LegacyCategorySlug повертає slug для назви категорії зі старої текстової колонки.
+// Якщо назва не дає slug (наприклад, ієрогліфи), slug будується з хешу назви: різні назви
+// не зливаються в одну категорію, а повторний запуск дає той самий результат
+func LegacyCategorySlug(name string) (slug string, fallback bool) {
+	if slug = utils.Slugify(name); slug != "" {
+		return slug, false
+	}
+	sum := sha256.Sum256([]byte(strings.TrimSpace(name)))
+	return "category-" + hex.EncodeToString(sum[:4]), true
+}
@@ -0,0 +1,18 @@
+package category
+
+import (
+	"backend/modules/category/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	categoryGroup := r.Group("/categories")
+	{
+		categoryGroup.POST("/", handlers.CreateCategoryHandler)
+		categoryGroup.GET("/", handlers.GetAllCategoriesHandler)
+		categoryGroup.GET("/tree", handlers.GetCategoryTreeHandler)
+		categoryGroup.GET("/:id", handlers.GetCategoryByIdHandler)
+		categoryGroup.PATCH("/:id", handlers.UpdateCategoryHandler)
+		categoryGroup.DELETE("/:id", handlers.DeleteCategoryHandler)
+	}
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/comment/models"
+	"backend/modules/comment/repository"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Не більше commentRateLimit коментарів за commentRateWindow з однієї адреси або від одного користувача
+const (
+	commentRateLimit  = 5
+	commentRateWindow = 10 * time.Minute
+)
+
+// GetBlogCommentsHandler — схвалені коментарі опублікованого блогу деревом
+func GetBlogCommentsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	blogId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	blog, err := repository.GetPublishedBlog(db, blogId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
+	comments, err := repository.GetBlogComments(db, blog.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": comments, "comments_enabled": blog.CommentsEnabled})
+}
+
+// CreateBlogCommentHandler приймає коментар від користувача з токеном або від анонімного відвідувача
+func CreateBlogCommentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	blogId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	var post models.CommentPost
+	if err = ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var author *userModels.User
+	if _, authenticated := ctx.Get("id"); authenticated {
+		user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+		if !ok {
+			return
+		}
+		author = user
+	}
+
+	blog, err := repository.GetPublishedBlog(db, blogId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
+	// Адміністратори не обмежуються
+	var limit *repository.RateLimit
+	if author == nil || (!author.IsAdmin && !author.IsSuperUser) {
+		limit = &repository.RateLimit{Max: commentRateLimit, Window: commentRateWindow}
+	}
+
+	comment, err := repository.CreateComment(db, blog, &post, author, ctx.ClientIP(), limit)
+	if errors.Is(err, repository.ErrTooManyComments) {
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many comments, try again later"})
+		return
+	}
+	if errors.Is(err, repository.ErrCommentsDisabled) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// GetCommentsHandler — черга модерації з фільтрами ?status= і ?blog_id=
+func GetCommentsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	var blogId *uuid.UUID
+	if value := ctx.Query("blog_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+			return
+		}
+		blogId = &id
+	}
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+
+	comments, err := repository.GetComments(db, models.CommentStatus(ctx.Query("status")), blogId, skip, limit)
+	if errors.Is(err, repository.ErrInvalidStatus) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comments)
+}
+
+func ModerateCommentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	var moderate models.CommentModerate
+	if err = ctx.ShouldBindJSON(&moderate); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := repository.ModerateComment(db, id, moderate.Status)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+func DeleteCommentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	err = repository.DeleteComment(db, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Comment deleted"})
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	blogModels "backend/modules/blog/models"
+	userModels "backend/modules/user/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type CommentStatus string
+
+const (
+	StatusPending  CommentStatus = "pending"
+	StatusApproved CommentStatus = "approved"
+	StatusSpam     CommentStatus = "spam"
+)
+
+func (s CommentStatus) Valid() bool {
+	return s == StatusPending || s == StatusApproved || s == StatusSpam
+}
+
+// Comment — коментар до блогу; ParentID вказує на коментар, на який відповідають
+type Comment struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	BlogID      uuid.UUID        `gorm:"type:uuid;not null;index" json:"blog_id"`
+	ParentID    *uuid.UUID       `gorm:"type:uuid;index" json:"parent_id"`
+	UserID      *uuid.UUID       `gorm:"type:uuid;index" json:"user_id"`
+	AuthorName  string           `gorm:"type:varchar(100);not null" json:"author_name"`
+	AuthorEmail string           `gorm:"type:varchar(255);not null" json:"-"`
+	Content     string           `gorm:"type:text;not null" json:"content"`
+	Status      CommentStatus    `gorm:"type:varchar(16);not null;default:'pending';index" json:"status"`
+	IP          string           `gorm:"type:varchar(64);index" json:"-"`
+	Blog        blogModels.Blog  `gorm:"foreignKey:BlogID;constraint:OnDelete:CASCADE" json:"-"`
+	Parent      *Comment         `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE" json:"-"`
+	User        *userModels.User `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL" json:"-"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (comment *Comment) BeforeCreate(*gorm.DB) error {
+	if comment.ID == uuid.Nil {
+		comment.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CommentPost — новий коментар; ім'я та email обов'язкові лише для анонімних відвідувачів
+type CommentPost struct {
+	ParentID    *uuid.UUID `json:"parent_id"`
+	AuthorName  string     `json:"author_name"`
+	AuthorEmail string     `json:"author_email"`
+	Content     string     `json:"content" binding:"required"`
+}
+
+type CommentGet struct {
+	ID          uuid.UUID     `json:"id"`
+	BlogID      uuid.UUID     `json:"blog_id"`
+	ParentID    *uuid.UUID    `json:"parent_id"`
+	UserID      *uuid.UUID    `json:"user_id"`
+	AuthorName  string        `json:"author_name"`
+	AuthorEmail string        `json:"author_email,omitempty"`
+	Content     string        `json:"content"`
+	Status      CommentStatus `json:"status"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Replies     []*CommentGet `json:"replies,omitempty"`
+}
+
+type CommentModerate struct {
+	Status CommentStatus `json:"status" binding:"required"`
+}
+
+type CommentGetAll struct {
+	Data  []*CommentGet
+	Count int64
+}
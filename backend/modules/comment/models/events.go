@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CommentPending — новий коментар чекає модерації
+type CommentPending struct {
+	CommentID   uuid.UUID `json:"comment_id"`
+	BlogID      uuid.UUID `json:"blog_id"`
+	BlogTitle   string    `json:"blog_title"`
+	AuthorName  string    `json:"author_name"`
+	AuthorEmail string    `json:"author_email"`
+	Content     string    `json:"content"`
+}
+
+func (CommentPending) EventName() string { return "comment.pending" }
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/events"
+	blogModels "backend/modules/blog/models"
+	"backend/modules/comment/models"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxNameLength    = 100
+	maxContentLength = 5000
+	maxPageSize      = 100
+)
+
+var (
+	ErrCommentsDisabled = errors.New("comments are disabled for this post")
+	ErrInvalidParent    = errors.New("the parent comment does not exist in this post")
+	ErrInvalidAuthor    = errors.New("author_name and a valid author_email are required")
+	ErrInvalidContent   = errors.New("the comment must contain from 1 to 5000 characters")
+	ErrInvalidStatus    = errors.New("unknown comment status")
+	ErrTooManyComments  = errors.New("too many comments, try again later")
+)
+
+// RateLimit — не більше Max коментарів за Window з однієї адреси або від одного користувача
+type RateLimit struct {
+	Max    int64
+	Window time.Duration
+}
+
+// GetPublishedBlog повертає опублікований блог, до якого відвідувачі бачать коментарі
+func GetPublishedBlog(db *gorm.DB, blogId uuid.UUID) (*blogModels.Blog, error) {
+	var blog blogModels.Blog
+	err := db.Where("id = ? AND state = ?", blogId, entities.StatePublished).First(&blog).Error
+	if err != nil {
+		return nil, err
+	}
+	return &blog, nil
+}
+
+// CountRecentComments рахує коментарі з IP-адреси або від користувача після since
+func CountRecentComments(db *gorm.DB, ip string, userId *uuid.UUID, since time.Time) (int64, error) {
+	query := db.Model(&models.Comment{}).Where("created_at > ?", since)
+	if userId != nil {
+		query = query.Where("ip = ? OR user_id = ?", ip, *userId)
+	} else {
+		query = query.Where("ip = ?", ip)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CreateComment додає коментар; коментарі адміністраторів і автора блогу публікуються одразу,
+// решта потрапляє в чергу модерації. Без limit кількість коментарів не обмежується
+func CreateComment(db *gorm.DB, blog *blogModels.Blog, post *models.CommentPost, author *userModels.User, ip string, limit *RateLimit) (*models.Comment, error) {
+	if !blog.CommentsEnabled {
+		return nil, ErrCommentsDisabled
+	}
+
+	content := strings.TrimSpace(post.Content)
+	if content == "" || utf8.RuneCountInString(content) > maxContentLength {
+		return nil, ErrInvalidContent
+	}
+
+	comment := &models.Comment{
+		BlogID:   blog.ID,
+		ParentID: post.ParentID,
+		Content:  content,
+		Status:   models.StatusPending,
+		IP:       ip,
+	}
+
+	if author != nil {
+		comment.UserID = &author.ID
+		comment.AuthorName = author.FullName
+		comment.AuthorEmail = author.Email
+		if comment.AuthorName == "" {
+			comment.AuthorName = author.Email
+		}
+		if author.IsAdmin || author.IsSuperUser || author.ID == blog.OwnerID {
+			comment.Status = models.StatusApproved
+		}
+	} else {
+		name := strings.TrimSpace(post.AuthorName)
+		address, err := mail.ParseAddress(strings.TrimSpace(post.AuthorEmail))
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength || err != nil {
+			return nil, ErrInvalidAuthor
+		}
+		comment.AuthorName = name
+		comment.AuthorEmail = address.Address
+	}
+
+	// Відповідати можна лише на опублікований коментар того самого блогу
+	if post.ParentID != nil {
+		var parent models.Comment
+		err := db.Where("id = ? AND blog_id = ? AND status = ?", *post.ParentID, blog.ID, models.StatusApproved).First(&parent).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidParent
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if limit != nil {
+			if err := checkRateLimit(tx, ip, comment.UserID, limit); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		if comment.Status != models.StatusPending {
+			return nil
+		}
+		// Модератори дізнаються про коментар лише разом із його збереженням
+		return events.Publish(tx, models.CommentPending{
+			CommentID:   comment.ID,
+			BlogID:      comment.BlogID,
+			BlogTitle:   blog.Title,
+			AuthorName:  comment.AuthorName,
+			AuthorEmail: comment.AuthorEmail,
+			Content:     comment.Content,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// checkRateLimit блокує адресу й користувача до кінця транзакції, тож паралельні запити
+// рахують коментарі по черзі і не проходять ліміт разом
+func checkRateLimit(tx *gorm.DB, ip string, userId *uuid.UUID, limit *RateLimit) error {
+	keys := []string{"comment-ip:" + ip}
+	if userId != nil {
+		keys = append(keys, "comment-user:"+userId.String())
+	}
+	// Однаковий порядок блокувань виключає взаємне блокування
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+			return err
+		}
+	}
+
+	count, err := CountRecentComments(tx, ip, userId, time.Now().Add(-limit.Window))
+	if err != nil {
+		return err
+	}
+	if count >= limit.Max {
+		return ErrTooManyComments
+	}
+	return nil
+}
+
+// GetBlogComments повертає схвалені коментарі блогу у вигляді дерева
+func GetBlogComments(db *gorm.DB, blogId uuid.UUID) ([]*models.CommentGet, error) {
+	var comments []models.Comment
+	err := db.Where("blog_id = ? AND status = ?", blogId, models.StatusApproved).
+		Order("created_at ASC").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.CommentGet, 0, len(comments))
+	for i := range comments {
+		result = append(result, toCommentGet(&comments[i], false))
+	}
+	return BuildThread(result), nil
+}
+
+// BuildThread розкладає коментарі у дерево за ParentID; гілки без видимого батька відкидаються
+func BuildThread(comments []*models.CommentGet) []*models.CommentGet {
+	byId := make(map[uuid.UUID]*models.CommentGet, len(comments))
+	for _, comment := range comments {
+		comment.Replies = nil
+		byId[comment.ID] = comment
+	}
+
+	roots := make([]*models.CommentGet, 0)
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			roots = append(roots, comment)
+			continue
+		}
+		if parent, ok := byId[*comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, comment)
+		}
+	}
+	return roots
+}
+
+// GetComments повертає чергу модерації: найновіші першими, з email автора
+func GetComments(db *gorm.DB, status models.CommentStatus, blogId *uuid.UUID, skip, limit int) (*models.CommentGetAll, error) {
+	if status != "" && !status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+	if skip < 0 {
+		skip = 0
+	}
+	if limit <= 0 || limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	query := db.Model(&models.Comment{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if blogId != nil {
+		query = query.Where("blog_id = ?", *blogId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	var comments []models.Comment
+	err := query.Order("created_at DESC").Offset(skip).Limit(limit).Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.CommentGetAll{Data: make([]*models.CommentGet, 0, len(comments)), Count: count}
+	for i := range comments {
+		response.Data = append(response.Data, toCommentGet(&comments[i], true))
+	}
+	return response, nil
+}
+
+func ModerateComment(db *gorm.DB, id uuid.UUID, status models.CommentStatus) (*models.CommentGet, error) {
+	if !status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+
+	var comment models.Comment
+	if err := db.First(&comment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	comment.Status = status
+	if err := db.Model(&comment).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+	return toCommentGet(&comment, true), nil
+}
+
+// DeleteComment видаляє коментар разом із відповідями на нього
+func DeleteComment(db *gorm.DB, id uuid.UUID) error {
+	result := db.Delete(&models.Comment{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func toCommentGet(comment *models.Comment, withEmail bool) *models.CommentGet {
+	result := &models.CommentGet{
+		ID:         comment.ID,
+		BlogID:     comment.BlogID,
+		ParentID:   comment.ParentID,
+		UserID:     comment.UserID,
+		AuthorName: comment.AuthorName,
+		Content:    comment.Content,
+		Status:     comment.Status,
+		CreatedAt:  comment.CreatedAt,
+	}
+	if withEmail {
+		result.AuthorEmail = comment.AuthorEmail
+	}
+	return result
+}
@@ -0,0 +1,21 @@
+package comment
+
+import (
+	"backend/modules/comment/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterPublicRoutes — коментарі, доступні відвідувачам без токена
+func RegisterPublicRoutes(r *gin.RouterGroup) {
+	r.GET("/blog/:id/comments", handlers.GetBlogCommentsHandler)
+	r.POST("/blog/:id/comments", handlers.CreateBlogCommentHandler)
+}
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	commentGroup := r.Group("/comments")
+	{
+		commentGroup.GET("/", handlers.GetCommentsHandler)
+		commentGroup.PATCH("/:id", handlers.ModerateCommentHandler)
+		commentGroup.DELETE("/:id", handlers.DeleteCommentHandler)
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/contact/models"
+	"backend/modules/contact/repository"
+	tagModels "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+func CreateContactHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.RequireAdmin(ctx, db)
+	if !ok {
+		return
+	}
+
+	var post models.ContactPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contact, err := repository.CreateContact(db, &post, user.ID)
+	if err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, contact)
+}
+
+func GetContactsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	params := &models.ContactParameters{
+		Search:       ctx.Query("q"),
+		Kind:         models.Kind(ctx.Query("kind")),
+		Tags:         tagRepo.ParseTagSlugs(ctx.Query("tags")),
+		TagsMatchAny: ctx.Query("tags_match") == "any",
+		Skip:         max(skip, 0),
+		Limit:        limit,
+	}
+	if params.Kind != "" && !params.Kind.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid kind"})
+		return
+	}
+	var ok bool
+	if params.CompanyID, ok = parseUUIDParam(ctx, "company_id"); !ok {
+		return
+	}
+	if params.OwnerID, ok = parseUUIDParam(ctx, "owner_id"); !ok {
+		return
+	}
+
+	contacts, err := repository.GetContacts(db, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contacts)
+}
+
+func GetContactHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	contact, err := repository.GetContact(db, contactId)
+	if err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contact)
+}
+
+func UpdateContactHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	var update models.ContactUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contact, err := repository.UpdateContact(db, contactId, &update)
+	if err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contact)
+}
+
+func DeleteContactHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteContact(db, contactId); err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Contact deleted"})
+}
+
+func SetContactTagsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	var put tagModels.TagsPut
+	if err := ctx.ShouldBindJSON(&put); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := repository.SetContactTags(db, contactId, put.Tags)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// respondContactError відповідає 404 для відсутнього контакту, 400 для помилок перевірки, інакше 500
+func respondContactError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+	case errors.Is(err, repository.ErrInvalidKind),
+		errors.Is(err, repository.ErrNameRequired),
+		errors.Is(err, repository.ErrInvalidCompany),
+		errors.Is(err, repository.ErrCompanyNotAllow),
+		errors.Is(err, repository.ErrUserNotFound),
+		errors.Is(err, repository.ErrInvalidCountry),
+		errors.Is(err, repository.ErrEmptyNote):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// contactIdParam перевіряє права й розбирає :id; при помилці відповідь уже надіслана
+func contactIdParam(ctx *gin.Context) (uuid.UUID, bool) {
+	if _, ok := utils2.RequireAdmin(ctx, postgres.DB); !ok {
+		return uuid.Nil, false
+	}
+	contactId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact ID"})
+		return uuid.Nil, false
+	}
+	return contactId, true
+}
+
+func parseUUIDParam(ctx *gin.Context, name string) (*uuid.UUID, bool) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return nil, false
+	}
+	return &id, true
+}
+
+// CRM доступний лише персоналу магазину
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/spreadsheet"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/contact/repository"
+	"backend/modules/contact/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// Максимальний розмір файлу імпорту
+const importMaxFileSize = 10 << 20
+
+// ImportContactsHandler імпортує контакти з CSV чи XLSX синхронно; з dry_run=true лише перевіряє файл
+func ImportContactsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.RequireAdmin(ctx, db)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	if fileHeader.Size > importMaxFileSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+		return
+	}
+
+	formatName := ctx.PostForm("format")
+	if formatName == "" {
+		formatName = fileHeader.Filename
+	}
+	format, err := spreadsheet.ParseFormat(formatName)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, importMaxFileSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	table, err := spreadsheet.Read(data, format)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(table) < 2 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "The file has no data rows"})
+		return
+	}
+	if len(table)-1 > repository.ImportMaxRows {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("The file has more than %d rows", repository.ImportMaxRows)})
+		return
+	}
+
+	rows, rowErrors, err := service.ParseImportRows(table)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := repository.ImportContacts(db, rows, user.ID, ctx.PostForm("dry_run") == "true")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	for _, rowError := range rowErrors {
+		result.Failed++
+		result.Errors = append(result.Errors, rowError)
+	}
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Row < result.Errors[j].Row
+	})
+
+	ctx.JSON(http.StatusOK, result)
+}
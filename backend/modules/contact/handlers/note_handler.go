@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	calendarRepo "backend/modules/calendar/repository"
+	"backend/modules/contact/models"
+	"backend/modules/contact/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+func GetContactNotesHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	notes, err := repository.GetNotes(db, contactId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"notes": notes})
+}
+
+func CreateContactNoteHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+	user, _ := utils2.RequireAdmin(ctx, postgres.DB)
+
+	var post models.NotePost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := repository.GetContact(db, contactId); err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	note, err := repository.CreateNote(db, contactId, user.ID, post.Body)
+	if err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, note)
+}
+
+// UpdateContactNoteHandler — нотатку змінює автор або суперкористувач
+func UpdateContactNoteHandler(ctx *gin.Context) {
+	db := postgres.DB
+	note, ok := getOwnNote(ctx)
+	if !ok {
+		return
+	}
+
+	var post models.NotePost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := repository.UpdateNote(db, note, post.Body); err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, note)
+}
+
+func DeleteContactNoteHandler(ctx *gin.Context) {
+	db := postgres.DB
+	note, ok := getOwnNote(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteNote(db, note); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Note deleted"})
+}
+
+// LinkContactEventHandler прив'язує до контакту власну подію календаря
+func LinkContactEventHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, eventId, ok := linkParams(ctx, "eventId")
+	if !ok {
+		return
+	}
+	user, _ := utils2.RequireAdmin(ctx, postgres.DB)
+
+	event, err := calendarRepo.GetEventById(db, eventId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+	if event.UserID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to link this event"})
+		return
+	}
+	if _, err = repository.GetContact(db, contactId); err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	if err = repository.LinkEvent(db, contactId, eventId); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Event linked"})
+}
+
+func UnlinkContactEventHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, eventId, ok := linkParams(ctx, "eventId")
+	if !ok {
+		return
+	}
+
+	err := repository.UnlinkEvent(db, contactId, eventId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Event is not linked to this contact"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Event unlinked"})
+}
+
+// LinkContactOrderHandler прив'язує замовлення, зроблене з іншого облікового запису чи email
+func LinkContactOrderHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, orderId, ok := linkParams(ctx, "orderId")
+	if !ok {
+		return
+	}
+
+	if _, err := repository.GetContact(db, contactId); err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	err := repository.LinkOrder(db, contactId, orderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Order linked"})
+}
+
+func UnlinkContactOrderHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, orderId, ok := linkParams(ctx, "orderId")
+	if !ok {
+		return
+	}
+
+	err := repository.UnlinkOrder(db, contactId, orderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order is not linked to this contact"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Order unlinked"})
+}
+
+func getOwnNote(ctx *gin.Context) (*models.ContactNote, bool) {
+	contactId, noteId, ok := linkParams(ctx, "noteId")
+	if !ok {
+		return nil, false
+	}
+	user, _ := utils2.RequireAdmin(ctx, postgres.DB)
+
+	note, err := repository.GetNote(postgres.DB, contactId, noteId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Note not found"})
+		return nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if (note.AuthorID == nil || *note.AuthorID != user.ID) && !user.IsSuperUser {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Only the author can change this note"})
+		return nil, false
+	}
+	return note, true
+}
+
+// linkParams розбирає :id контакту та другий ідентифікатор маршруту
+func linkParams(ctx *gin.Context, name string) (uuid.UUID, uuid.UUID, bool) {
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return contactId, id, true
+}
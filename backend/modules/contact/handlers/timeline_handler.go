@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/modules/contact/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func GetContactOrdersHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	orders, err := repository.GetContactOrders(db, contactId)
+	if err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+// GetContactTimelineHandler — історія контакту; для компанії включає активність її працівників
+func GetContactTimelineHandler(ctx *gin.Context) {
+	db := postgres.DB
+	contactId, ok := contactIdParam(ctx)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	var before *time.Time
+	if value := ctx.Query("before"); value != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid before, expected RFC 3339"})
+			return
+		}
+		before = &parsed
+	}
+
+	timeline, err := repository.GetTimeline(db, contactId, before, limit)
+	if err != nil {
+		respondContactError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeline)
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	calendarModels "backend/modules/calendar/models"
+	orderModels "backend/modules/order/models"
+	userModels "backend/modules/user/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindCompany Kind = "company"
+	KindPerson  Kind = "person"
+)
+
+func (k Kind) Valid() bool {
+	return k == KindCompany || k == KindPerson
+}
+
+// Contact — клієнт CRM: компанія або особа. Особа може працювати в компанії (CompanyID),
+// а UserID пов'язує контакт з обліковим записом покупця, щоб підтягнути його замовлення
+type Contact struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	Kind      Kind             `gorm:"type:varchar(16);not null;index" json:"kind"`
+	Name      string           `gorm:"type:varchar(255);not null;index" json:"name"`
+	FirstName string           `gorm:"type:varchar(100)" json:"first_name"`
+	LastName  string           `gorm:"type:varchar(100)" json:"last_name"`
+	Position  string           `gorm:"type:varchar(100)" json:"position"`
+	Email     string           `gorm:"type:varchar(255);index" json:"email"`
+	Phone     string           `gorm:"type:varchar(32)" json:"phone"`
+	Website   string           `gorm:"type:varchar(255)" json:"website"`
+	TaxID     string           `gorm:"type:varchar(32);index" json:"tax_id"`
+	CompanyID *uuid.UUID       `gorm:"type:uuid;index" json:"company_id"`
+	UserID    *uuid.UUID       `gorm:"type:uuid;index" json:"user_id"`
+	OwnerID   uuid.UUID        `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Addresses []ContactAddress `gorm:"foreignKey:ContactID;constraint:OnDelete:CASCADE" json:"addresses"`
+	Company   *Contact         `gorm:"foreignKey:CompanyID;constraint:OnDelete:SET NULL" json:"-"`
+	User      *userModels.User `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL" json:"-"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type ContactAddress struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ContactID  uuid.UUID `gorm:"type:uuid;not null;index" json:"contact_id"`
+	Label      string    `gorm:"type:varchar(32)" json:"label"`
+	Street     string    `gorm:"type:varchar(255)" json:"street"`
+	City       string    `gorm:"type:varchar(100)" json:"city"`
+	PostalCode string    `gorm:"type:varchar(16)" json:"postal_code"`
+	Country    string    `gorm:"type:varchar(2)" json:"country"`
+	IsPrimary  bool      `gorm:"not null;default:false" json:"is_primary"`
+}
+
+type ContactNote struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	ContactID uuid.UUID        `gorm:"type:uuid;not null;index" json:"contact_id"`
+	AuthorID  *uuid.UUID       `gorm:"type:uuid" json:"author_id"`
+	Body      string           `gorm:"type:text;not null" json:"body"`
+	Contact   Contact          `gorm:"foreignKey:ContactID;constraint:OnDelete:CASCADE" json:"-"`
+	Author    *userModels.User `gorm:"foreignKey:AuthorID;constraint:OnDelete:SET NULL" json:"-"`
+	CreatedAt time.Time        `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// ContactEvent — подія календаря, пов'язана з контактом (зустріч, дзвінок)
+type ContactEvent struct {
+	ContactID uuid.UUID               `gorm:"type:uuid;primaryKey" json:"contact_id"`
+	EventID   uuid.UUID               `gorm:"type:uuid;primaryKey;index" json:"event_id"`
+	Contact   Contact                 `gorm:"foreignKey:ContactID;constraint:OnDelete:CASCADE" json:"-"`
+	Event     calendarModels.Calendar `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// ContactOrder — замовлення, прив'язане до контакту вручну; замовлення з тим самим
+// обліковим записом чи email пов'язуються автоматично й запису тут не потребують
+type ContactOrder struct {
+	ContactID uuid.UUID         `gorm:"type:uuid;primaryKey" json:"contact_id"`
+	OrderID   uuid.UUID         `gorm:"type:uuid;primaryKey;index" json:"order_id"`
+	Contact   Contact           `gorm:"foreignKey:ContactID;constraint:OnDelete:CASCADE" json:"-"`
+	Order     orderModels.Order `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func (contact *Contact) BeforeCreate(*gorm.DB) error {
+	if contact.ID == uuid.Nil {
+		contact.ID = uuid.New()
+	}
+	return nil
+}
+
+func (address *ContactAddress) BeforeCreate(*gorm.DB) error {
+	if address.ID == uuid.Nil {
+		address.ID = uuid.New()
+	}
+	return nil
+}
+
+func (note *ContactNote) BeforeCreate(*gorm.DB) error {
+	if note.ID == uuid.Nil {
+		note.ID = uuid.New()
+	}
+	return nil
+}
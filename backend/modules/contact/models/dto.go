@@ -0,0 +1,111 @@
+package models
+
+import (
+	tagModels "backend/modules/tag/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AddressPost struct {
+	Label      string `json:"label"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	IsPrimary  bool   `json:"is_primary"`
+}
+
+type ContactPost struct {
+	Kind      Kind          `json:"kind" binding:"required"`
+	Name      string        `json:"name"`
+	FirstName string        `json:"first_name"`
+	LastName  string        `json:"last_name"`
+	Position  string        `json:"position"`
+	Email     string        `json:"email"`
+	Phone     string        `json:"phone"`
+	Website   string        `json:"website"`
+	TaxID     string        `json:"tax_id"`
+	CompanyID *uuid.UUID    `json:"company_id"`
+	UserID    *uuid.UUID    `json:"user_id"`
+	OwnerID   *uuid.UUID    `json:"owner_id"`
+	Addresses []AddressPost `json:"addresses"`
+	Tags      []string      `json:"tags"`
+}
+
+// ContactUpdate — часткове оновлення; нульовий UUID у company_id чи user_id знімає зв'язок,
+// а передані addresses чи tags повністю замінюють наявні
+type ContactUpdate struct {
+	Name      *string        `json:"name"`
+	FirstName *string        `json:"first_name"`
+	LastName  *string        `json:"last_name"`
+	Position  *string        `json:"position"`
+	Email     *string        `json:"email"`
+	Phone     *string        `json:"phone"`
+	Website   *string        `json:"website"`
+	TaxID     *string        `json:"tax_id"`
+	CompanyID *uuid.UUID     `json:"company_id"`
+	UserID    *uuid.UUID     `json:"user_id"`
+	OwnerID   *uuid.UUID     `json:"owner_id"`
+	Addresses *[]AddressPost `json:"addresses"`
+	Tags      *[]string      `json:"tags"`
+}
+
+type ContactGet struct {
+	Contact
+	CompanyName *string            `json:"company_name"`
+	Tags        []tagModels.TagGet `json:"tags"`
+}
+
+type ContactGetAll struct {
+	Data  []*ContactGet
+	Count int
+	Total int64
+	Tags  []tagModels.TagGet
+}
+
+// ContactParameters — фільтри списку контактів
+type ContactParameters struct {
+	Search       string
+	Kind         Kind
+	CompanyID    *uuid.UUID
+	OwnerID      *uuid.UUID
+	Tags         []string
+	TagsMatchAny bool
+	Skip         int
+	Limit        int
+}
+
+type NotePost struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// TimelineEntry — подія з історії контакту з будь-якого модуля; RefID вказує на джерело
+// (нотатку, подію календаря, замовлення, документ, платіж чи коментар)
+type TimelineEntry struct {
+	Type      string     `json:"type"`
+	At        time.Time  `json:"at"`
+	Title     string     `json:"title"`
+	Details   string     `json:"details,omitempty"`
+	RefID     uuid.UUID  `json:"ref_id"`
+	ContactID uuid.UUID  `json:"contact_id"`
+	OrderID   *uuid.UUID `json:"order_id,omitempty"`
+	Amount    *int64     `json:"amount,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+}
+
+// Типи записів історії
+const (
+	TimelineNote        = "note"
+	TimelineEvent       = "event"
+	TimelineOrder       = "order"
+	TimelineOrderStatus = "order_status"
+	TimelineDocument    = "document"
+	TimelinePayment     = "payment"
+	TimelineComment     = "comment"
+)
+
+type Timeline struct {
+	Data []TimelineEntry `json:"data"`
+	// Next — значення before для наступної сторінки; відсутнє, якщо записів більше немає
+	Next *time.Time `json:"next"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// ImportRow — рядок файлу імпорту після розбору; порожні значення не змінюють наявний контакт
+type ImportRow struct {
+	Line      int
+	Kind      Kind
+	Name      string
+	FirstName string
+	LastName  string
+	Position  string
+	Email     string
+	Phone     string
+	Website   string
+	TaxID     string
+	Company   string
+	Address   AddressPost
+	Tags      []string
+	Note      string
+}
+
+// ImportRowError — помилка конкретного рядка файлу (нумерація як у таблиці, з заголовком)
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	DryRun  bool             `json:"dry_run"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// Більше помилок у відповіді не повертається, лише рахуються
+const importMaxErrors = 100
+
+func (result *ImportResult) AddError(row int, err error) {
+	result.Failed++
+	if len(result.Errors) < importMaxErrors {
+		result.Errors = append(result.Errors, ImportRowError{Row: row, Error: err.Error()})
+	}
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"backend/modules/contact/models"
+	tagModel "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+var (
+	ErrInvalidKind     = errors.New("kind must be company or person")
+	ErrNameRequired    = errors.New("name is required")
+	ErrInvalidCompany  = errors.New("company_id must refer to an existing company contact")
+	ErrCompanyNotAllow = errors.New("a company cannot belong to another company")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidCountry  = errors.New("country must be a two-letter code")
+	ErrEmptyNote       = errors.New("note body cannot be empty")
+)
+
+func CreateContact(db *gorm.DB, post *models.ContactPost, ownerId uuid.UUID) (*models.ContactGet, error) {
+	contact := models.Contact{
+		Kind:      post.Kind,
+		Name:      strings.TrimSpace(post.Name),
+		FirstName: strings.TrimSpace(post.FirstName),
+		LastName:  strings.TrimSpace(post.LastName),
+		Position:  strings.TrimSpace(post.Position),
+		Email:     normalizeEmail(post.Email),
+		Phone:     strings.TrimSpace(post.Phone),
+		Website:   strings.TrimSpace(post.Website),
+		TaxID:     strings.TrimSpace(post.TaxID),
+		CompanyID: post.CompanyID,
+		UserID:    post.UserID,
+		OwnerID:   ownerId,
+	}
+	if post.OwnerID != nil {
+		contact.OwnerID = *post.OwnerID
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := validateContact(tx, &contact); err != nil {
+			return err
+		}
+		addresses, err := buildAddresses(post.Addresses)
+		if err != nil {
+			return err
+		}
+		contact.Addresses = addresses
+		if err = tx.Create(&contact).Error; err != nil {
+			return err
+		}
+		if len(post.Tags) > 0 {
+			_, err = tagRepo.SetEntityTags(tx, tagModel.EntityContacts, contact.ID, post.Tags)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetContact(db, contact.ID)
+}
+
+func GetContact(db *gorm.DB, id uuid.UUID) (*models.ContactGet, error) {
+	var contact models.Contact
+	err := db.Preload("Addresses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("is_primary DESC, label")
+	}).First(&contact, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := toContactGets(db, []models.Contact{contact})
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+func GetContacts(db *gorm.DB, params *models.ContactParameters) (*models.ContactGetAll, error) {
+	query := db.Model(&models.Contact{})
+	if params.Search != "" {
+		pattern := "%" + escapeLike(params.Search) + "%"
+		query = query.Where(`contacts.name ILIKE @p OR contacts.email ILIKE @p OR contacts.phone ILIKE @p
+			OR contacts.tax_id ILIKE @p OR contacts.company_id IN (SELECT id FROM contacts WHERE name ILIKE @p)`,
+			map[string]interface{}{"p": pattern})
+	}
+	if params.Kind != "" {
+		query = query.Where("contacts.kind = ?", params.Kind)
+	}
+	if params.CompanyID != nil {
+		query = query.Where("contacts.company_id = ?", *params.CompanyID)
+	}
+	if params.OwnerID != nil {
+		query = query.Where("contacts.owner_id = ?", *params.OwnerID)
+	}
+
+	tags, err := tagRepo.CountTags(db, tagModel.EntityContacts, query.Session(&gorm.Session{}).Select("contacts.id"))
+	if err != nil {
+		return nil, err
+	}
+	query = tagRepo.FilterByTags(query, tagModel.EntityContacts, "contacts.id", params.Tags, params.TagsMatchAny)
+
+	var total int64
+	if err = query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var contacts []models.Contact
+	err = query.Preload("Addresses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("is_primary DESC, label")
+	}).Order("contacts.name").Offset(params.Skip).Limit(params.Limit).Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := toContactGets(db, contacts)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ContactGetAll{Data: data, Count: len(data), Total: total, Tags: tags}, nil
+}
+
+func UpdateContact(db *gorm.DB, id uuid.UUID, update *models.ContactUpdate) (*models.ContactGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var contact models.Contact
+		if err := tx.First(&contact, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		assign := func(target *string, value *string) {
+			if value != nil {
+				*target = strings.TrimSpace(*value)
+			}
+		}
+		assign(&contact.Name, update.Name)
+		assign(&contact.FirstName, update.FirstName)
+		assign(&contact.LastName, update.LastName)
+		assign(&contact.Position, update.Position)
+		assign(&contact.Phone, update.Phone)
+		assign(&contact.Website, update.Website)
+		assign(&contact.TaxID, update.TaxID)
+		if update.Email != nil {
+			contact.Email = normalizeEmail(*update.Email)
+		}
+		if update.CompanyID != nil {
+			contact.CompanyID = nilIfZero(*update.CompanyID)
+		}
+		if update.UserID != nil {
+			contact.UserID = nilIfZero(*update.UserID)
+		}
+		if update.OwnerID != nil {
+			contact.OwnerID = *update.OwnerID
+		}
+
+		if err := validateContact(tx, &contact); err != nil {
+			return err
+		}
+		// Select("*") зберігає й обнулені зв'язки company_id та user_id
+		if err := tx.Model(&contact).Select("*").Omit("ID", "CreatedAt", "Addresses").Updates(&contact).Error; err != nil {
+			return err
+		}
+
+		if update.Addresses != nil {
+			addresses, err := buildAddresses(*update.Addresses)
+			if err != nil {
+				return err
+			}
+			if err = tx.Where("contact_id = ?", id).Delete(&models.ContactAddress{}).Error; err != nil {
+				return err
+			}
+			for i := range addresses {
+				addresses[i].ContactID = id
+			}
+			if len(addresses) > 0 {
+				if err = tx.Create(&addresses).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if update.Tags != nil {
+			if _, err := tagRepo.SetEntityTags(tx, tagModel.EntityContacts, id, *update.Tags); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetContact(db, id)
+}
+
+// DeleteContact видаляє контакт з адресами, нотатками та зв'язками; працівники видаленої компанії лишаються без компанії
+func DeleteContact(db *gorm.DB, id uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Contact{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tagRepo.DeleteEntityTags(tx, tagModel.EntityContacts, id)
+	})
+}
+
+func SetContactTags(db *gorm.DB, id uuid.UUID, names []string) ([]tagModel.TagGet, error) {
+	if err := db.Select("id").First(&models.Contact{}, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return tagRepo.SetEntityTags(db, tagModel.EntityContacts, id, names)
+}
+
+// validateContact нормалізує ім'я та перевіряє зв'язки з компанією й обліковим записом
+func validateContact(tx *gorm.DB, contact *models.Contact) error {
+	if !contact.Kind.Valid() {
+		return ErrInvalidKind
+	}
+	if contact.Kind == models.KindPerson {
+		if contact.Name == "" {
+			contact.Name = strings.TrimSpace(contact.FirstName + " " + contact.LastName)
+		}
+	} else if contact.CompanyID != nil {
+		return ErrCompanyNotAllow
+	}
+	if contact.Name == "" {
+		return ErrNameRequired
+	}
+
+	if contact.CompanyID != nil {
+		var count int64
+		err := tx.Model(&models.Contact{}).
+			Where("id = ? AND kind = ?", *contact.CompanyID, models.KindCompany).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrInvalidCompany
+		}
+	}
+	if contact.UserID != nil {
+		var count int64
+		if err := tx.Model(&userModels.User{}).Where("id = ?", *contact.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrUserNotFound
+		}
+	}
+	return nil
+}
+
+// buildAddresses перевіряє адреси; основною може бути лише одна, а якщо жодну не позначено — нею стає перша
+func buildAddresses(posts []models.AddressPost) ([]models.ContactAddress, error) {
+	addresses := make([]models.ContactAddress, 0, len(posts))
+	hasPrimary := false
+	for _, post := range posts {
+		country := strings.ToUpper(strings.TrimSpace(post.Country))
+		if len(country) != 0 && len(country) != 2 {
+			return nil, ErrInvalidCountry
+		}
+		addresses = append(addresses, models.ContactAddress{
+			Label:      strings.TrimSpace(post.Label),
+			Street:     strings.TrimSpace(post.Street),
+			City:       strings.TrimSpace(post.City),
+			PostalCode: strings.TrimSpace(post.PostalCode),
+			Country:    country,
+			IsPrimary:  post.IsPrimary && !hasPrimary,
+		})
+		hasPrimary = hasPrimary || post.IsPrimary
+	}
+	if !hasPrimary && len(addresses) > 0 {
+		addresses[0].IsPrimary = true
+	}
+	return addresses, nil
+}
+
+func toContactGets(db *gorm.DB, contacts []models.Contact) ([]*models.ContactGet, error) {
+	ids := make([]uuid.UUID, 0, len(contacts))
+	var companyIds []uuid.UUID
+	for _, contact := range contacts {
+		ids = append(ids, contact.ID)
+		if contact.CompanyID != nil {
+			companyIds = append(companyIds, *contact.CompanyID)
+		}
+	}
+
+	tagsMap, err := tagRepo.GetEntityTags(db, tagModel.EntityContacts, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	companyNames := make(map[uuid.UUID]string)
+	if len(companyIds) > 0 {
+		var companies []models.Contact
+		if err = db.Select("id", "name").Where("id IN ?", companyIds).Find(&companies).Error; err != nil {
+			return nil, err
+		}
+		for _, company := range companies {
+			companyNames[company.ID] = company.Name
+		}
+	}
+
+	result := make([]*models.ContactGet, 0, len(contacts))
+	for _, contact := range contacts {
+		get := &models.ContactGet{Contact: contact, Tags: tagsMap[contact.ID]}
+		if get.Addresses == nil {
+			get.Addresses = []models.ContactAddress{}
+		}
+		if get.Tags == nil {
+			get.Tags = []tagModel.TagGet{}
+		}
+		if contact.CompanyID != nil {
+			if name, ok := companyNames[*contact.CompanyID]; ok {
+				get.CompanyName = &name
+			}
+		}
+		result = append(result, get)
+	}
+	return result, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func nilIfZero(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"backend/modules/contact/models"
+	tagModel "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ImportMaxRows — найбільша кількість рядків в одному файлі імпорту
+const ImportMaxRows = 5000
+
+// errDryRun відкочує транзакцію пробного імпорту
+var errDryRun = errors.New("dry run")
+
+// ImportContacts створює або оновлює контакти з розібраних рядків. Наявний контакт шукається за email,
+// компанія без email — за NIP або назвою. Компанії з колонки company створюються за потреби.
+// Кожен рядок — окрема точка збереження, тож помилка рядка не скасовує решту
+func ImportContacts(db *gorm.DB, rows []models.ImportRow, ownerId uuid.UUID, dryRun bool) (*models.ImportResult, error) {
+	result := &models.ImportResult{DryRun: dryRun, Errors: []models.ImportRowError{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		companies := make(map[string]uuid.UUID)
+		for _, row := range rows {
+			var created bool
+			// Компанії, знайдені або створені рядком, потрапляють у кеш лише після фіксації точки збереження:
+			// після відкату створена компанія зникає, і наступні рядки мають створити її знову
+			resolved := make(map[string]uuid.UUID)
+			err := tx.Transaction(func(rowTx *gorm.DB) error {
+				var err error
+				created, err = importRow(rowTx, row, ownerId, companies, resolved)
+				return err
+			})
+			if err != nil {
+				result.AddError(row.Line, err)
+				continue
+			}
+			for key, id := range resolved {
+				companies[key] = id
+			}
+			if created {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
+func importRow(tx *gorm.DB, row models.ImportRow, ownerId uuid.UUID, companies, resolved map[string]uuid.UUID) (bool, error) {
+	contact, err := findExisting(tx, row)
+	if err != nil {
+		return false, err
+	}
+	created := contact == nil
+	if created {
+		contact = &models.Contact{Kind: row.Kind, OwnerID: ownerId}
+	}
+
+	set := func(target *string, value string) {
+		if value != "" {
+			*target = value
+		}
+	}
+	set(&contact.Name, row.Name)
+	set(&contact.FirstName, row.FirstName)
+	set(&contact.LastName, row.LastName)
+	set(&contact.Position, row.Position)
+	set(&contact.Email, row.Email)
+	set(&contact.Phone, row.Phone)
+	set(&contact.Website, row.Website)
+	set(&contact.TaxID, row.TaxID)
+
+	if row.Company != "" {
+		companyId, err := resolveCompany(tx, row.Company, ownerId, companies, resolved)
+		if err != nil {
+			return false, err
+		}
+		contact.CompanyID = &companyId
+	}
+
+	if err = validateContact(tx, contact); err != nil {
+		return false, err
+	}
+	if err = tx.Save(contact).Error; err != nil {
+		return false, err
+	}
+
+	if err = importAddress(tx, contact.ID, row.Address); err != nil {
+		return false, err
+	}
+	if len(row.Tags) > 0 {
+		if _, err = tagRepo.SetEntityTags(tx, tagModel.EntityContacts, contact.ID, row.Tags); err != nil {
+			return false, err
+		}
+	}
+	if row.Note != "" {
+		note := models.ContactNote{ContactID: contact.ID, AuthorID: &ownerId, Body: row.Note}
+		if err = tx.Create(&note).Error; err != nil {
+			return false, err
+		}
+	}
+	return created, nil
+}
+
+func findExisting(tx *gorm.DB, row models.ImportRow) (*models.Contact, error) {
+	query := tx.Where("kind = ?", row.Kind)
+	switch {
+	case row.Email != "":
+		query = query.Where("email = ?", row.Email)
+	case row.Kind == models.KindCompany && row.TaxID != "":
+		query = query.Where("tax_id = ?", row.TaxID)
+	case row.Kind == models.KindCompany:
+		query = query.Where("lower(name) = lower(?)", row.Name)
+	default:
+		return nil, nil
+	}
+
+	var contacts []models.Contact
+	if err := query.Order("created_at").Limit(2).Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	switch len(contacts) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &contacts[0], nil
+	default:
+		return nil, errors.New("several existing contacts match this row")
+	}
+}
+
+// resolveCompany знаходить компанію за назвою або створює нову. Спершу перевіряється кеш зафіксованих
+// рядків, а результат записується в resolved, який викликач додає до кешу після фіксації рядка
+func resolveCompany(tx *gorm.DB, name string, ownerId uuid.UUID, companies, resolved map[string]uuid.UUID) (uuid.UUID, error) {
+	key := strings.ToLower(name)
+	if id, ok := companies[key]; ok {
+		return id, nil
+	}
+
+	var company models.Contact
+	err := tx.Select("id").
+		Where("kind = ? AND lower(name) = ?", models.KindCompany, key).
+		Order("created_at").
+		First(&company).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		company = models.Contact{Kind: models.KindCompany, Name: name, OwnerID: ownerId}
+		err = tx.Create(&company).Error
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	resolved[key] = company.ID
+	return company.ID, nil
+}
+
+// importAddress оновлює основну адресу контакту або додає її, якщо адреси ще немає
+func importAddress(tx *gorm.DB, contactId uuid.UUID, post models.AddressPost) error {
+	if post.Street == "" && post.City == "" && post.PostalCode == "" && post.Country == "" {
+		return nil
+	}
+
+	var address models.ContactAddress
+	err := tx.Where("contact_id = ? AND is_primary", contactId).First(&address).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		address = models.ContactAddress{ContactID: contactId, IsPrimary: true}
+	} else if err != nil {
+		return err
+	}
+
+	set := func(target *string, value string) {
+		if value != "" {
+			*target = value
+		}
+	}
+	set(&address.Street, post.Street)
+	set(&address.City, post.City)
+	set(&address.PostalCode, post.PostalCode)
+	set(&address.Country, post.Country)
+	return tx.Save(&address).Error
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	calendarModels "backend/modules/calendar/models"
+	"backend/modules/contact/models"
+	orderModels "backend/modules/order/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+func GetNotes(db *gorm.DB, contactId uuid.UUID) ([]models.ContactNote, error) {
+	notes := []models.ContactNote{}
+	err := db.Where("contact_id = ?", contactId).Order("created_at DESC").Find(&notes).Error
+	return notes, err
+}
+
+func GetNote(db *gorm.DB, contactId uuid.UUID, noteId uuid.UUID) (*models.ContactNote, error) {
+	var note models.ContactNote
+	if err := db.First(&note, "id = ? AND contact_id = ?", noteId, contactId).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func CreateNote(db *gorm.DB, contactId uuid.UUID, authorId uuid.UUID, body string) (*models.ContactNote, error) {
+	note := models.ContactNote{ContactID: contactId, AuthorID: &authorId, Body: strings.TrimSpace(body)}
+	if note.Body == "" {
+		return nil, ErrEmptyNote
+	}
+	if err := db.Create(&note).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func UpdateNote(db *gorm.DB, note *models.ContactNote, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return ErrEmptyNote
+	}
+	note.Body = body
+	return db.Model(note).Update("body", body).Error
+}
+
+func DeleteNote(db *gorm.DB, note *models.ContactNote) error {
+	return db.Delete(note).Error
+}
+
+// LinkEvent прив'язує подію календаря до контакту; повторна прив'язка нічого не змінює
+func LinkEvent(db *gorm.DB, contactId uuid.UUID, eventId uuid.UUID) error {
+	if err := db.Select("id").First(&calendarModels.Calendar{}, "id = ?", eventId).Error; err != nil {
+		return err
+	}
+	link := models.ContactEvent{ContactID: contactId, EventID: eventId}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&link).Error
+}
+
+func UnlinkEvent(db *gorm.DB, contactId uuid.UUID, eventId uuid.UUID) error {
+	return unlink(db, &models.ContactEvent{}, "contact_id = ? AND event_id = ?", contactId, eventId)
+}
+
+func LinkOrder(db *gorm.DB, contactId uuid.UUID, orderId uuid.UUID) error {
+	if err := db.Select("id").First(&orderModels.Order{}, "id = ?", orderId).Error; err != nil {
+		return err
+	}
+	link := models.ContactOrder{ContactID: contactId, OrderID: orderId}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&link).Error
+}
+
+func UnlinkOrder(db *gorm.DB, contactId uuid.UUID, orderId uuid.UUID) error {
+	return unlink(db, &models.ContactOrder{}, "contact_id = ? AND order_id = ?", contactId, orderId)
+}
+
+func unlink(db *gorm.DB, link interface{}, condition string, args ...interface{}) error {
+	result := db.Where(condition, args...).Delete(link)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+package repository
+
+import (
+	calendarModels "backend/modules/calendar/models"
+	commentModels "backend/modules/comment/models"
+	"backend/modules/contact/models"
+	"backend/modules/contact/service"
+	documentModels "backend/modules/document/models"
+	orderModels "backend/modules/order/models"
+	paymentModels "backend/modules/payment/models"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// contactScope — контакт, а для компанії ще й її працівники, з ключами для пошуку їхньої активності
+type contactScope struct {
+	ids     []uuid.UUID
+	userIds []uuid.UUID
+	emails  []string
+	byUser  map[uuid.UUID]uuid.UUID
+	byEmail map[string]uuid.UUID
+	// orders — контакт для кожного пов'язаного замовлення
+	orders map[uuid.UUID]uuid.UUID
+}
+
+func loadScope(db *gorm.DB, contactId uuid.UUID) (*contactScope, error) {
+	var contacts []models.Contact
+	err := db.Select("id", "kind", "email", "user_id", "company_id").
+		Where("id = ? OR company_id = ?", contactId, contactId).
+		Order("company_id NULLS FIRST").
+		Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(contacts) == 0 || contacts[0].ID != contactId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	scope := &contactScope{
+		byUser:  make(map[uuid.UUID]uuid.UUID),
+		byEmail: make(map[string]uuid.UUID),
+		orders:  make(map[uuid.UUID]uuid.UUID),
+	}
+	for _, contact := range contacts {
+		scope.ids = append(scope.ids, contact.ID)
+		if contact.UserID != nil {
+			if _, exists := scope.byUser[*contact.UserID]; !exists {
+				scope.byUser[*contact.UserID] = contact.ID
+				scope.userIds = append(scope.userIds, *contact.UserID)
+			}
+		}
+		if contact.Email != "" {
+			if _, exists := scope.byEmail[contact.Email]; !exists {
+				scope.byEmail[contact.Email] = contact.ID
+				scope.emails = append(scope.emails, contact.Email)
+			}
+		}
+	}
+
+	// Явна прив'язка має перевагу над збігом облікового запису чи email
+	var orders []struct {
+		ID     uuid.UUID
+		UserID uuid.UUID
+		Email  string
+		Linked *uuid.UUID
+	}
+	err = scope.ordersQuery(db).
+		Select(`orders.id, orders.user_id, lower(orders.email) AS email,
+			(SELECT co.contact_id FROM contact_orders co WHERE co.order_id = orders.id AND co.contact_id IN ? LIMIT 1) AS linked`, scope.ids).
+		Scan(&orders).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		switch {
+		case order.Linked != nil:
+			scope.orders[order.ID] = *order.Linked
+		case scope.byUser[order.UserID] != uuid.Nil:
+			scope.orders[order.ID] = scope.byUser[order.UserID]
+		default:
+			scope.orders[order.ID] = scope.byEmail[order.Email]
+		}
+	}
+	return scope, nil
+}
+
+// ordersQuery вибирає замовлення контактів: прив'язані вручну, зроблені з їхнього облікового запису або на їхній email
+func (s *contactScope) ordersQuery(db *gorm.DB) *gorm.DB {
+	condition := db.Where("orders.id IN (?)", db.Model(&models.ContactOrder{}).Select("order_id").Where("contact_id IN ?", s.ids))
+	if len(s.userIds) > 0 {
+		condition = condition.Or("orders.user_id IN ?", s.userIds)
+	}
+	if len(s.emails) > 0 {
+		condition = condition.Or("lower(orders.email) IN ?", s.emails)
+	}
+	return db.Model(&orderModels.Order{}).Where(condition)
+}
+
+func (s *contactScope) orderIds() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(s.orders))
+	for id := range s.orders {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// GetContactOrders повертає замовлення контакту (для компанії — і її працівників), найновіші спершу
+func GetContactOrders(db *gorm.DB, contactId uuid.UUID) ([]orderModels.Order, error) {
+	scope, err := loadScope(db, contactId)
+	if err != nil {
+		return nil, err
+	}
+	orders := []orderModels.Order{}
+	err = scope.ordersQuery(db).Preload("Lines").Order("orders.created_at DESC").Find(&orders).Error
+	return orders, err
+}
+
+// GetTimeline збирає історію контакту з нотаток, календаря, замовлень, документів, платежів і коментарів.
+// Сторінки гортаються курсором before: наступна сторінка містить записи, старіші за нього
+func GetTimeline(db *gorm.DB, contactId uuid.UUID, before *time.Time, limit int) (*models.Timeline, error) {
+	scope, err := loadScope(db, contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	// З кожного джерела беремо на один запис більше, щоб знати, чи є наступна сторінка
+	page := func(query *gorm.DB, column string) *gorm.DB {
+		if before != nil {
+			query = query.Where(column+" < ?", *before)
+		}
+		return query.Order(column + " DESC").Limit(limit + 1)
+	}
+
+	var sources [][]models.TimelineEntry
+	for _, load := range []func(*gorm.DB, *contactScope, pageFunc) ([]models.TimelineEntry, error){
+		noteEntries, eventEntries, orderEntries, orderStatusEntries, documentEntries, paymentEntries, commentEntries,
+	} {
+		entries, err := load(db, scope, page)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, entries)
+	}
+
+	timeline := service.MergeTimeline(limit, sources...)
+	return &timeline, nil
+}
+
+type pageFunc = func(*gorm.DB, string) *gorm.DB
+
+func noteEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	var notes []models.ContactNote
+	if err := page(db.Where("contact_id IN ?", scope.ids), "created_at").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(notes))
+	for _, note := range notes {
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelineNote,
+			At:        note.CreatedAt,
+			Title:     firstLine(note.Body),
+			Details:   note.Body,
+			RefID:     note.ID,
+			ContactID: note.ContactID,
+		})
+	}
+	return entries, nil
+}
+
+func eventEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	var events []struct {
+		calendarModels.Calendar
+		ContactID uuid.UUID
+	}
+	query := db.Table("calendars").
+		Select("calendars.id, calendars.title, calendars.description, calendars.start_date, ce.contact_id").
+		Joins("JOIN contact_events ce ON ce.event_id = calendars.id").
+		Where("ce.contact_id IN ?", scope.ids)
+	if err := page(query, "calendars.start_date").Scan(&events).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(events))
+	for _, event := range events {
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelineEvent,
+			At:        event.StartDate,
+			Title:     event.Title,
+			Details:   event.Description,
+			RefID:     event.ID,
+			ContactID: event.ContactID,
+		})
+	}
+	return entries, nil
+}
+
+func orderEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	if len(scope.orders) == 0 {
+		return nil, nil
+	}
+	var orders []orderModels.Order
+	if err := page(db.Where("id IN ?", scope.orderIds()), "created_at").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(orders))
+	for _, order := range orders {
+		orderId, total := order.ID, order.Total
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelineOrder,
+			At:        order.CreatedAt,
+			Title:     fmt.Sprintf("Order #%d", order.Number),
+			Details:   string(order.Status),
+			RefID:     order.ID,
+			ContactID: scope.orders[order.ID],
+			OrderID:   &orderId,
+			Amount:    &total,
+			Currency:  order.Currency,
+		})
+	}
+	return entries, nil
+}
+
+// orderStatusEntries — зміни статусів; створення замовлення вже показане окремим записом
+func orderStatusEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	if len(scope.orders) == 0 {
+		return nil, nil
+	}
+	var events []struct {
+		orderModels.OrderEvent
+		Number int64
+	}
+	query := db.Table("order_events").
+		Select("order_events.*, orders.number").
+		Joins("JOIN orders ON orders.id = order_events.order_id").
+		Where("order_events.order_id IN ? AND order_events.\"from\" <> ''", scope.orderIds())
+	if err := page(query, "order_events.created_at").Scan(&events).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(events))
+	for _, event := range events {
+		orderId := event.OrderID
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelineOrderStatus,
+			At:        event.CreatedAt,
+			Title:     fmt.Sprintf("Order #%d: %s → %s", event.Number, event.From, event.To),
+			Details:   event.Comment,
+			RefID:     event.ID,
+			ContactID: scope.orders[orderId],
+			OrderID:   &orderId,
+		})
+	}
+	return entries, nil
+}
+
+func documentEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	if len(scope.orders) == 0 {
+		return nil, nil
+	}
+	var documents []documentModels.Document
+	query := db.Omit("content").Where("order_id IN ?", scope.orderIds())
+	if err := page(query, "issued_at").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(documents))
+	for _, document := range documents {
+		orderId, gross := document.OrderID, document.Gross
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelineDocument,
+			At:        document.IssuedAt,
+			Title:     fmt.Sprintf("%s %s", document.Type, document.Number),
+			RefID:     document.ID,
+			ContactID: scope.orders[orderId],
+			OrderID:   &orderId,
+			Amount:    &gross,
+			Currency:  document.Currency,
+		})
+	}
+	return entries, nil
+}
+
+func paymentEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	if len(scope.orders) == 0 {
+		return nil, nil
+	}
+	var payments []paymentModels.Payment
+	if err := page(db.Where("order_id IN ?", scope.orderIds()), "created_at").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(payments))
+	for _, payment := range payments {
+		orderId, amount := payment.OrderID, payment.Amount
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelinePayment,
+			At:        payment.CreatedAt,
+			Title:     fmt.Sprintf("Payment via %s", payment.Provider),
+			Details:   string(payment.Status),
+			RefID:     payment.ID,
+			ContactID: scope.orders[orderId],
+			OrderID:   &orderId,
+			Amount:    &amount,
+			Currency:  payment.Currency,
+		})
+	}
+	return entries, nil
+}
+
+func commentEntries(db *gorm.DB, scope *contactScope, page pageFunc) ([]models.TimelineEntry, error) {
+	if len(scope.userIds) == 0 && len(scope.emails) == 0 {
+		return nil, nil
+	}
+	var comments []struct {
+		commentModels.Comment
+		BlogTitle string
+	}
+	condition := db.Where("comments.user_id IN ?", scope.userIds)
+	if len(scope.emails) > 0 {
+		condition = condition.Or("lower(comments.author_email) IN ?", scope.emails)
+	}
+	query := db.Table("comments").
+		Select("comments.id, comments.user_id, comments.author_email, comments.content, comments.status, comments.created_at, blogs.title AS blog_title").
+		Joins("JOIN blogs ON blogs.id = comments.blog_id").
+		Where(condition).
+		Where("comments.status <> ?", commentModels.StatusSpam)
+	if err := page(query, "comments.created_at").Scan(&comments).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]models.TimelineEntry, 0, len(comments))
+	for _, comment := range comments {
+		contactId := scope.byEmail[strings.ToLower(comment.AuthorEmail)]
+		if comment.UserID != nil {
+			if id, ok := scope.byUser[*comment.UserID]; ok {
+				contactId = id
+			}
+		}
+		entries = append(entries, models.TimelineEntry{
+			Type:      models.TimelineComment,
+			At:        comment.CreatedAt,
+			Title:     fmt.Sprintf("Comment on %q", comment.BlogTitle),
+			Details:   comment.Content,
+			RefID:     comment.ID,
+			ContactID: contactId,
+		})
+	}
+	return entries, nil
+}
+
+// firstLine — заголовок нотатки: перший рядок, обрізаний до 80 символів
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if runes := []rune(line); len(runes) > 80 {
+		return string(runes[:79]) + "…"
+	}
+	return line
+}
@@ -0,0 +1,29 @@
+package contact
+
+import (
+	"backend/modules/contact/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	contactGroup := r.Group("/contacts")
+	{
+		contactGroup.GET("/", handlers.GetContactsHandler)
+		contactGroup.POST("/", handlers.CreateContactHandler)
+		contactGroup.POST("/import", handlers.ImportContactsHandler)
+		contactGroup.GET("/:id", handlers.GetContactHandler)
+		contactGroup.PATCH("/:id", handlers.UpdateContactHandler)
+		contactGroup.DELETE("/:id", handlers.DeleteContactHandler)
+		contactGroup.PUT("/:id/tags", handlers.SetContactTagsHandler)
+		contactGroup.GET("/:id/timeline", handlers.GetContactTimelineHandler)
+		contactGroup.GET("/:id/orders", handlers.GetContactOrdersHandler)
+		contactGroup.PUT("/:id/orders/:orderId", handlers.LinkContactOrderHandler)
+		contactGroup.DELETE("/:id/orders/:orderId", handlers.UnlinkContactOrderHandler)
+		contactGroup.PUT("/:id/events/:eventId", handlers.LinkContactEventHandler)
+		contactGroup.DELETE("/:id/events/:eventId", handlers.UnlinkContactEventHandler)
+		contactGroup.GET("/:id/notes", handlers.GetContactNotesHandler)
+		contactGroup.POST("/:id/notes", handlers.CreateContactNoteHandler)
+		contactGroup.PATCH("/:id/notes/:noteId", handlers.UpdateContactNoteHandler)
+		contactGroup.DELETE("/:id/notes/:noteId", handlers.DeleteContactNoteHandler)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"backend/modules/contact/models"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Колонки файлу імпорту; назви нечутливі до регістру, пробіли замінюються на "_"
+var importColumns = map[string]bool{
+	"kind": true, "name": true, "first_name": true, "last_name": true, "position": true,
+	"email": true, "phone": true, "website": true, "tax_id": true, "company": true,
+	"street": true, "city": true, "postal_code": true, "country": true, "tags": true, "note": true,
+}
+
+// ParseImportRows розбирає таблицю з рядком заголовків. Рядки з помилками не потрапляють
+// до результату, а повертаються окремо з номером рядка у файлі
+func ParseImportRows(rows [][]string) ([]models.ImportRow, []models.ImportRowError, error) {
+	if len(rows) == 0 {
+		return nil, nil, errors.New("the file is empty")
+	}
+
+	columns := make(map[string]int)
+	for index, name := range rows[0] {
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if name == "" {
+			continue
+		}
+		if !importColumns[name] {
+			return nil, nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns[name] = index
+	}
+	_, hasName := columns["name"]
+	_, hasFirstName := columns["first_name"]
+	_, hasLastName := columns["last_name"]
+	_, hasEmail := columns["email"]
+	if !hasName && !hasFirstName && !hasLastName && !hasEmail {
+		return nil, nil, errors.New("the file must contain a name, first_name, last_name or email column")
+	}
+
+	var parsed []models.ImportRow
+	var rowErrors []models.ImportRowError
+	for index := 1; index < len(rows); index++ {
+		cells := rows[index]
+		value := func(column string) string {
+			position, ok := columns[column]
+			if !ok || position >= len(cells) {
+				return ""
+			}
+			return strings.TrimSpace(cells[position])
+		}
+
+		row := models.ImportRow{
+			Line:      index + 1,
+			Kind:      models.Kind(strings.ToLower(value("kind"))),
+			Name:      value("name"),
+			FirstName: value("first_name"),
+			LastName:  value("last_name"),
+			Position:  value("position"),
+			Email:     strings.ToLower(value("email")),
+			Phone:     value("phone"),
+			Website:   value("website"),
+			TaxID:     value("tax_id"),
+			Company:   value("company"),
+			Address: models.AddressPost{
+				Street:     value("street"),
+				City:       value("city"),
+				PostalCode: value("postal_code"),
+				Country:    strings.ToUpper(value("country")),
+				IsPrimary:  true,
+			},
+			Tags: splitList(value("tags")),
+			Note: value("note"),
+		}
+		if isBlank(row) {
+			continue
+		}
+
+		if row.Kind == "" {
+			row.Kind = models.KindCompany
+			if row.FirstName != "" || row.LastName != "" {
+				row.Kind = models.KindPerson
+			}
+		}
+		if err := validateRow(&row); err != nil {
+			rowErrors = append(rowErrors, models.ImportRowError{Row: row.Line, Error: err.Error()})
+			continue
+		}
+		parsed = append(parsed, row)
+	}
+	return parsed, rowErrors, nil
+}
+
+func validateRow(row *models.ImportRow) error {
+	if !row.Kind.Valid() {
+		return fmt.Errorf("invalid kind %q", row.Kind)
+	}
+	if row.Name == "" && row.Kind == models.KindPerson {
+		row.Name = strings.TrimSpace(row.FirstName + " " + row.LastName)
+	}
+	if row.Name == "" && row.Email == "" {
+		return errors.New("name or email is required")
+	}
+	if row.Kind == models.KindCompany && row.Company != "" {
+		return errors.New("a company cannot belong to another company")
+	}
+	if row.Email != "" && !strings.Contains(row.Email, "@") {
+		return fmt.Errorf("invalid email %q", row.Email)
+	}
+	if len(row.Address.Country) > 2 {
+		return fmt.Errorf("country must be a two-letter code, got %q", row.Address.Country)
+	}
+	return nil
+}
+
+func isBlank(row models.ImportRow) bool {
+	return row.Kind == "" && row.Name == "" && row.FirstName == "" && row.LastName == "" &&
+		row.Email == "" && row.Phone == "" && row.TaxID == "" && row.Company == "" && row.Note == ""
+}
+
+// splitList ділить клітинку зі списком тегів за комами або крапками з комою
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"backend/modules/contact/models"
+	"sort"
+)
+
+// MergeTimeline об'єднує записи з різних джерел від найновіших до найстаріших і лишає limit перших.
+// Кожне джерело має містити щонайменше limit+1 найновіших записів (або всі, якщо їх менше),
+// тоді надлишок означає, що є наступна сторінка
+func MergeTimeline(limit int, sources ...[]models.TimelineEntry) models.Timeline {
+	merged := []models.TimelineEntry{}
+	for _, source := range sources {
+		merged = append(merged, source...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].At.After(merged[j].At)
+	})
+
+	timeline := models.Timeline{Data: merged}
+	if len(merged) > limit {
+		timeline.Data = merged[:limit]
+		next := timeline.Data[limit-1].At
+		timeline.Next = &next
+	}
+	return timeline
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/document/models"
+	"backend/modules/document/repository"
+	"backend/modules/document/service"
+	orderModels "backend/modules/order/models"
+	orderRepo "backend/modules/order/repository"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"mime"
+	"net/http"
+)
+
+func GetOrderDocumentsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	order, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return
+	}
+
+	documents, err := repository.GetOrderDocuments(db, order.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": documents, "count": len(documents)})
+}
+
+// DownloadDocumentHandler віддає PDF документа власнику замовлення або адміністратору
+func DownloadDocumentHandler(ctx *gin.Context) {
+	document, ok := getAccessibleDocument(ctx)
+	if !ok {
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName})
+	ctx.Header("Content-Disposition", disposition)
+	ctx.Data(http.StatusOK, "application/pdf", document.Content)
+}
+
+// IssueInvoiceHandler виставляє фактуру для оплаченого замовлення вручну; доступно лише адміністраторам.
+// Якщо фактура вже існує, повертається вона.
+func IssueInvoiceHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	document, created, err := service.Issue(db, id, models.TypeInvoice)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+	if errors.Is(err, repository.ErrNotInvoiceable) || errors.Is(err, repository.ErrCompanyNotConfigured) || errors.Is(err, repository.ErrEmptyOrder) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !created {
+		ctx.JSON(http.StatusOK, document)
+		return
+	}
+	go service.Deliver(db, document)
+	ctx.JSON(http.StatusCreated, document)
+}
+
+// EmailDocumentHandler повторно надсилає документ покупцю; доступно лише адміністраторам
+func EmailDocumentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	document, ok := getAccessibleDocument(ctx)
+	if !ok {
+		return
+	}
+
+	if err := service.Email(db, document); err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, document)
+}
+
+func getAccessibleDocument(ctx *gin.Context) (*models.Document, bool) {
+	db := postgres.DB
+	order, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	id, err := uuid.Parse(ctx.Param("documentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return nil, false
+	}
+
+	document, err := repository.GetDocument(db, order.ID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return document, true
+}
+
+// getAccessibleOrder завантажує замовлення :id, доступне власнику або адміністратору
+func getAccessibleOrder(ctx *gin.Context) (*orderModels.Order, bool) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, false
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return nil, false
+	}
+
+	order, err := orderRepo.GetOrder(db, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && order.UserID != user.ID && !isAdmin(user)) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return order, true
+}
+
+func isAdmin(user *userModels.User) bool {
+	return user.IsAdmin || user.IsSuperUser
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/document/models"
+	"backend/modules/document/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func GetCompanySettingsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	settings, err := repository.GetCompanySettings(db)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateCompanySettingsHandler змінює реквізити продавця; вже виставлені документи не змінюються
+func UpdateCompanySettingsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	var update models.CompanySettingsUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := repository.UpdateCompanySettings(db, &update)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeInvoice      Type = "invoice"
+	TypeConfirmation Type = "confirmation"
+)
+
+// Document — виставлений документ замовлення. Вміст PDF зберігається разом із записом,
+// тож завантаження не залежить від сховища файлів і не змінюється після зміни реквізитів.
+// Копія в приватному бакеті документів не має публічного URL і не віддається клієнтам.
+type Document struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_documents_order_type" json:"order_id"`
+	Type      Type       `gorm:"type:varchar(16);not null;uniqueIndex:idx_documents_order_type;uniqueIndex:idx_documents_type_year_sequence" json:"type"`
+	Year      int        `gorm:"not null;uniqueIndex:idx_documents_type_year_sequence" json:"year"`
+	Sequence  int64      `gorm:"not null;uniqueIndex:idx_documents_type_year_sequence" json:"sequence"`
+	Number    string     `gorm:"type:varchar(64);not null" json:"number"`
+	Currency  string     `gorm:"type:varchar(3);not null" json:"currency"`
+	Net       int64      `gorm:"not null" json:"net"`
+	Vat       int64      `gorm:"not null" json:"vat"`
+	Gross     int64      `gorm:"not null" json:"gross"`
+	FileName  string     `gorm:"type:varchar(255);not null" json:"file_name"`
+	ObjectKey string     `gorm:"type:text" json:"-"`
+	Content   []byte     `gorm:"type:bytea;not null" json:"-"`
+	IssuedAt  time.Time  `gorm:"not null" json:"issued_at"`
+	EmailedAt *time.Time `json:"emailed_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// DocumentSequence — останній виданий номер документа певного типу в межах року
+type DocumentSequence struct {
+	Type Type  `gorm:"type:varchar(16);primaryKey"`
+	Year int   `gorm:"primaryKey;autoIncrement:false"`
+	Last int64 `gorm:"not null"`
+}
+
+func (document *Document) BeforeCreate(*gorm.DB) error {
+	if document.ID == uuid.Nil {
+		document.ID = uuid.New()
+	}
+	return nil
+}
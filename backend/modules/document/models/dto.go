@@ -0,0 +1,44 @@
+package models
+
+import (
+	orderModels "backend/modules/order/models"
+	"time"
+)
+
+// DocumentData — дані, які отримують шаблони документів з web/templates
+type DocumentData struct {
+	Type      Type
+	Title     string
+	Number    string
+	IssuedAt  time.Time
+	SaleDate  time.Time
+	Seller    CompanySettings
+	Order     *orderModels.Order
+	Currency  string
+	Lines     []DocumentLine
+	Breakdown []VatRow
+	Net       int64
+	Vat       int64
+	Gross     int64
+}
+
+// DocumentLine — позиція документа; ціни в магазині включають ПДВ, тож нетто виводиться з брутто
+type DocumentLine struct {
+	Position  int
+	Title     string
+	Sku       string
+	Quantity  int
+	VatRate   int
+	UnitGross int64
+	Net       int64
+	Vat       int64
+	Gross     int64
+}
+
+// VatRow — підсумок за однією ставкою ПДВ
+type VatRow struct {
+	Rate  int
+	Net   int64
+	Vat   int64
+	Gross int64
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const (
+	DefaultVatRate            = 23
+	DefaultInvoicePrefix      = "FV"
+	DefaultConfirmationPrefix = "ZAM"
+)
+
+// CompanySettings — реквізити продавця для документів; у таблиці завжди один запис з ID 1
+type CompanySettings struct {
+	ID                 uint      `gorm:"primaryKey" json:"-"`
+	Name               string    `gorm:"type:varchar(255)" json:"name"`
+	Address            string    `gorm:"type:text" json:"address"`
+	TaxID              string    `gorm:"type:varchar(32)" json:"tax_id"`
+	Email              string    `gorm:"type:varchar(255)" json:"email"`
+	Phone              string    `gorm:"type:varchar(32)" json:"phone"`
+	BankAccount        string    `gorm:"type:varchar(64)" json:"bank_account"`
+	VatRate            int       `gorm:"not null;default:23" json:"vat_rate"`
+	InvoicePrefix      string    `gorm:"type:varchar(16);not null;default:'FV'" json:"invoice_prefix"`
+	ConfirmationPrefix string    `gorm:"type:varchar(16);not null;default:'ZAM'" json:"confirmation_prefix"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type CompanySettingsUpdate struct {
+	Name               *string `json:"name"`
+	Address            *string `json:"address"`
+	TaxID              *string `json:"tax_id"`
+	Email              *string `json:"email"`
+	Phone              *string `json:"phone"`
+	BankAccount        *string `json:"bank_account"`
+	VatRate            *int    `json:"vat_rate" binding:"omitempty,min=0,max=100"`
+	InvoicePrefix      *string `json:"invoice_prefix"`
+	ConfirmationPrefix *string `json:"confirmation_prefix"`
+}
+
+// Prefix повертає префікс нумерації для типу документа
+func (settings *CompanySettings) Prefix(documentType Type) string {
+	if documentType == TypeInvoice {
+		return settings.InvoicePrefix
+	}
+	return settings.ConfirmationPrefix
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"backend/modules/document/models"
+	orderModels "backend/modules/order/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotInvoiceable       = errors.New("invoices can only be issued for paid orders")
+	ErrCompanyNotConfigured = errors.New("the company name and tax ID must be set before issuing invoices")
+	ErrUnknownDocumentType  = errors.New("unknown document type")
+	ErrEmptyOrder           = errors.New("the order has no lines")
+)
+
+// BuildFunc створює документ для замовлення з уже призначеним номером
+type BuildFunc func(documentType models.Type, order *orderModels.Order, settings *models.CompanySettings, number string, issuedAt time.Time) (*models.Document, error)
+
+// IssueDocument виставляє документ типу documentType для замовлення. На замовлення існує
+// щонайбільше один документ кожного типу: повторний виклик повертає вже виставлений
+// документ і false. Номер береться з лічильника року в тій самій транзакції, тож
+// відкат не залишає пропусків у нумерації.
+func IssueDocument(db *gorm.DB, orderId uuid.UUID, documentType models.Type, issuedAt time.Time, build BuildFunc) (*models.Document, bool, error) {
+	if documentType != models.TypeInvoice && documentType != models.TypeConfirmation {
+		return nil, false, fmt.Errorf("%w: %q", ErrUnknownDocumentType, documentType)
+	}
+
+	var document *models.Document
+	created := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var order orderModels.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", orderId).First(&order).Error
+		if err != nil {
+			return err
+		}
+
+		var existing models.Document
+		err = tx.Where("order_id = ? AND type = ?", orderId, documentType).First(&existing).Error
+		if err == nil {
+			document = &existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if documentType == models.TypeInvoice && !invoiceable(order.Status) {
+			return ErrNotInvoiceable
+		}
+		if err = tx.Where("order_id = ?", orderId).Find(&order.Lines).Error; err != nil {
+			return err
+		}
+		if len(order.Lines) == 0 {
+			return ErrEmptyOrder
+		}
+
+		settings, err := GetCompanySettings(tx)
+		if err != nil {
+			return err
+		}
+		if documentType == models.TypeInvoice && (strings.TrimSpace(settings.Name) == "" || strings.TrimSpace(settings.TaxID) == "") {
+			return ErrCompanyNotConfigured
+		}
+
+		year := issuedAt.Year()
+		sequence, err := nextSequence(tx, documentType, year)
+		if err != nil {
+			return err
+		}
+
+		document, err = build(documentType, &order, settings, FormatNumber(settings.Prefix(documentType), year, sequence), issuedAt)
+		if err != nil {
+			return err
+		}
+		document.OrderID = order.ID
+		document.Type = documentType
+		document.Year = year
+		document.Sequence = sequence
+		document.IssuedAt = issuedAt
+		created = true
+		return tx.Create(document).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return document, created, nil
+}
+
+// FormatNumber будує номер документа виду FV/2026/00012
+func FormatNumber(prefix string, year int, sequence int64) string {
+	number := fmt.Sprintf("%d/%05d", year, sequence)
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		number = prefix + "/" + number
+	}
+	return number
+}
+
+// nextSequence атомарно збільшує лічильник типу документа в межах року; рядок лічильника
+// лишається заблокованим до кінця транзакції, тож паралельні виклики отримують різні номери
+func nextSequence(tx *gorm.DB, documentType models.Type, year int) (int64, error) {
+	var sequence int64
+	err := tx.Raw(`INSERT INTO document_sequences (type, year, last) VALUES (?, ?, 1)
+		ON CONFLICT (type, year) DO UPDATE SET last = document_sequences.last + 1
+		RETURNING last`, documentType, year).Scan(&sequence).Error
+	return sequence, err
+}
+
+func invoiceable(status orderModels.Status) bool {
+	return status == orderModels.StatusPaid || status == orderModels.StatusShipped || status == orderModels.StatusDelivered
+}
+
+// GetOrderDocuments повертає документи замовлення без вмісту PDF
+func GetOrderDocuments(db *gorm.DB, orderId uuid.UUID) ([]models.Document, error) {
+	documents := []models.Document{}
+	err := db.Omit("Content").Where("order_id = ?", orderId).Order("issued_at ASC").Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func GetDocument(db *gorm.DB, orderId uuid.UUID, id uuid.UUID) (*models.Document, error) {
+	var document models.Document
+	if err := db.Where("id = ? AND order_id = ?", id, orderId).First(&document).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func SetDocumentObjectKey(db *gorm.DB, id uuid.UUID, key string) error {
+	return db.Model(&models.Document{}).Where("id = ?", id).Update("object_key", key).Error
+}
+
+func MarkDocumentEmailed(db *gorm.DB, id uuid.UUID, at time.Time) error {
+	return db.Model(&models.Document{}).Where("id = ?", id).Update("emailed_at", at).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"backend/modules/document/models"
+	"errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+const companySettingsID = 1
+
+// GetCompanySettings повертає реквізити компанії; поки їх не збережено — значення за замовчуванням
+func GetCompanySettings(db *gorm.DB) (*models.CompanySettings, error) {
+	var settings models.CompanySettings
+	err := db.Where("id = ?", companySettingsID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.CompanySettings{
+			ID:                 companySettingsID,
+			VatRate:            models.DefaultVatRate,
+			InvoicePrefix:      models.DefaultInvoicePrefix,
+			ConfirmationPrefix: models.DefaultConfirmationPrefix,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func UpdateCompanySettings(db *gorm.DB, update *models.CompanySettingsUpdate) (*models.CompanySettings, error) {
+	settings, err := GetCompanySettings(db)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []struct {
+		value  *string
+		target *string
+	}{
+		{update.Name, &settings.Name},
+		{update.Address, &settings.Address},
+		{update.TaxID, &settings.TaxID},
+		{update.Email, &settings.Email},
+		{update.Phone, &settings.Phone},
+		{update.BankAccount, &settings.BankAccount},
+		{update.InvoicePrefix, &settings.InvoicePrefix},
+		{update.ConfirmationPrefix, &settings.ConfirmationPrefix},
+	}
+	for _, field := range fields {
+		if field.value != nil {
+			*field.target = strings.TrimSpace(*field.value)
+		}
+	}
+	if update.VatRate != nil {
+		settings.VatRate = *update.VatRate
+	}
+
+	if err = db.Save(settings).Error; err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
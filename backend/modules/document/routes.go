@@ -0,0 +1,19 @@
+package document
+
+import (
+	"backend/modules/document/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/orders/:id/documents", handlers.GetOrderDocumentsHandler)
+	r.GET("/orders/:id/documents/:documentId", handlers.DownloadDocumentHandler)
+	r.POST("/orders/:id/documents/:documentId/email", handlers.EmailDocumentHandler)
+	r.POST("/orders/:id/invoice", handlers.IssueInvoiceHandler)
+
+	settingsGroup := r.Group("/settings")
+	{
+		settingsGroup.GET("/company", handlers.GetCompanySettingsHandler)
+		settingsGroup.PUT("/company", handlers.UpdateCompanySettingsHandler)
+	}
+}
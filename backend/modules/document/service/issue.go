@@ -0,0 +1,129 @@
+package service
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/utils"
+	"backend/modules/document/models"
+	"backend/modules/document/repository"
+	mediaService "backend/modules/media/service"
+	orderModels "backend/modules/order/models"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+var titles = map[models.Type]string{
+	models.TypeInvoice:      "Faktura VAT",
+	models.TypeConfirmation: "Potwierdzenie zamówienia",
+}
+
+// Issue виставляє документ для замовлення; повторний виклик повертає вже виставлений документ і false
+func Issue(db *gorm.DB, orderId uuid.UUID, documentType models.Type) (*models.Document, bool, error) {
+	return repository.IssueDocument(db, orderId, documentType, time.Now(), build)
+}
+
+// IssueInBackground виставляє документ і надсилає його покупцю, не затримуючи відповідь API
+func IssueInBackground(orderId uuid.UUID, documentType models.Type) {
+	go func() {
+		db := postgres.DB
+		document, created, err := Issue(db, orderId, documentType)
+		if err != nil {
+			log.Printf("❌ Failed to issue %s for order %s: %v", documentType, orderId, err)
+			return
+		}
+		if created {
+			Deliver(db, document)
+		}
+	}()
+}
+
+// Deliver зберігає копію PDF у приватному бакеті документів і надсилає його покупцю. Кроки, що вже
+// виконані, пропускаються, а помилки лише логуються: документ завжди доступний для завантаження з API.
+func Deliver(db *gorm.DB, document *models.Document) {
+	bucketName := os.Getenv("BUCKET_NAME_DOCUMENTS")
+	if document.ObjectKey == "" && bucketName != "" {
+		key, err := mediaService.UploadPrivateBytes(bucketName, document.FileName, document.Content)
+		if err != nil {
+			log.Printf("⚠️ Failed to store document %s: %v", document.Number, err)
+		} else if err = repository.SetDocumentObjectKey(db, document.ID, key); err != nil {
+			log.Printf("⚠️ Failed to save the object key of document %s: %v", document.Number, err)
+		} else {
+			document.ObjectKey = key
+		}
+	}
+
+	if document.EmailedAt == nil {
+		if err := Email(db, document); err != nil {
+			log.Printf("⚠️ Failed to email document %s: %v", document.Number, err)
+		}
+	}
+}
+
+// Email надсилає документ на адресу покупця із замовлення
+func Email(db *gorm.DB, document *models.Document) error {
+	var order orderModels.Order
+	if err := db.Where("id = ?", document.OrderID).First(&order).Error; err != nil {
+		return err
+	}
+
+	title := titles[document.Type]
+	subject := fmt.Sprintf("%s %s", title, document.Number)
+	message := fmt.Sprintf(`
+		<h2>%s %s</h2>
+		<p>Dzień dobry %s,</p>
+		<p>w załączniku przesyłamy dokument do zamówienia nr %d.</p>
+	`, title, document.Number, order.FullName, order.Number)
+
+	attachment := utils.Attachment{Name: document.FileName, Data: document.Content}
+	if err := utils.SendEmail(order.Email, subject, message, true, attachment); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	document.EmailedAt = &now
+	return repository.MarkDocumentEmailed(db, document.ID, now)
+}
+
+// build збирає дані документа, рахує ПДВ і рендерить PDF за шаблоном
+func build(documentType models.Type, order *orderModels.Order, settings *models.CompanySettings, number string, issuedAt time.Time) (*models.Document, error) {
+	data := &models.DocumentData{
+		Type:     documentType,
+		Title:    titles[documentType],
+		Number:   number,
+		IssuedAt: issuedAt,
+		SaleDate: order.CreatedAt,
+		Seller:   *settings,
+		Order:    order,
+		Currency: order.Currency,
+	}
+	if order.PaidAt != nil {
+		data.SaleDate = *order.PaidAt
+	}
+
+	data.Lines = BuildLines(order.Lines, settings.VatRate, order.Currency)
+	data.Breakdown = Breakdown(data.Lines, order.Currency)
+	for _, row := range data.Breakdown {
+		data.Net += row.Net
+		data.Vat += row.Vat
+		data.Gross += row.Gross
+	}
+
+	content, err := Render(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Document{
+		Number:   number,
+		Currency: order.Currency,
+		Net:      data.Net,
+		Vat:      data.Vat,
+		Gross:    data.Gross,
+		FileName: strings.ReplaceAll(number, "/", "-") + ".pdf",
+		Content:  content,
+	}, nil
+}
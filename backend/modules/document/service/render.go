@@ -0,0 +1,73 @@
+package service
+
+import (
+	"backend/internal/services/money"
+	"backend/internal/services/pdf"
+	"backend/modules/document/models"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateDir — каталог шаблонів документів відносно робочого каталогу сервера
+var TemplateDir = "web/templates"
+
+var templateFiles = map[models.Type]string{
+	models.TypeInvoice:      "invoice.tmpl",
+	models.TypeConfirmation: "order-confirmation.tmpl",
+}
+
+var templateFuncs = template.FuncMap{
+	"amount": FormatAmount,
+	"date": func(value time.Time) string {
+		return value.Format("2006-01-02")
+	},
+	"cell": func(value string) string {
+		return strings.Join(strings.Fields(strings.ReplaceAll(value, "|", "/")), " ")
+	},
+	"text": func(value string) string {
+		return `\` + strings.Join(strings.Fields(value), " ")
+	},
+	"lines": func(value string) []string {
+		return strings.Split(strings.TrimSpace(value), "\n")
+	},
+}
+
+// Render заповнює шаблон документа й верстає його в PDF.
+// Шаблон читається при кожному виклику, тож правки в web/templates не потребують перезапуску.
+func Render(data *models.DocumentData) ([]byte, error) {
+	name := templateFiles[data.Type]
+	tmpl, err := template.New(name).Funcs(templateFuncs).ParseFiles(filepath.Join(TemplateDir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var markup strings.Builder
+	if err = tmpl.Execute(&markup, data); err != nil {
+		return nil, err
+	}
+	return pdf.Render(data.Title+" "+data.Number, markup.String())
+}
+
+// FormatAmount форматує суму по-польськи: "1 234,56" з нерозривним пробілом між тисячами
+func FormatAmount(amount int64, currency string) string {
+	decimal := money.New(amount, currency).Decimal()
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign, decimal = "-", decimal[1:]
+	}
+	whole, fraction, _ := strings.Cut(decimal, ".")
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteRune(' ')
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction == "" {
+		return sign + grouped.String()
+	}
+	return sign + grouped.String() + "," + fraction
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"backend/internal/services/money"
+	"backend/modules/document/models"
+	orderModels "backend/modules/order/models"
+	"math/big"
+	"sort"
+)
+
+// SplitGross виділяє з суми брутто нетто та ПДВ за ставкою rate (у відсотках)
+func SplitGross(gross int64, rate int, currency string) (net int64, vat int64) {
+	net = money.New(gross, currency).MulRat(big.NewRat(100, int64(100+rate))).Amount
+	return net, gross - net
+}
+
+// BuildLines перетворює позиції замовлення на позиції документа; позиції без ставки
+// отримують ставку компанії за замовчуванням
+func BuildLines(lines []orderModels.OrderLine, defaultRate int, currency string) []models.DocumentLine {
+	result := make([]models.DocumentLine, 0, len(lines))
+	for i, line := range lines {
+		rate := defaultRate
+		if line.VatRate != nil {
+			rate = *line.VatRate
+		}
+		sku := ""
+		if line.Sku != nil {
+			sku = *line.Sku
+		}
+		net, vat := SplitGross(line.Total, rate, currency)
+		result = append(result, models.DocumentLine{
+			Position:  i + 1,
+			Title:     line.Title,
+			Sku:       sku,
+			Quantity:  line.Quantity,
+			VatRate:   rate,
+			UnitGross: line.Price,
+			Net:       net,
+			Vat:       vat,
+			Gross:     line.Total,
+		})
+	}
+	return result
+}
+
+// Breakdown підсумовує брутто за кожною ставкою й рахує ПДВ від суми за ставкою,
+// а не від окремих позицій, тож підсумки не накопичують похибку округлення.
+// Ставки впорядковано від найбільшої.
+func Breakdown(lines []models.DocumentLine, currency string) []models.VatRow {
+	gross := make(map[int]int64)
+	for _, line := range lines {
+		gross[line.VatRate] += line.Gross
+	}
+
+	rows := make([]models.VatRow, 0, len(gross))
+	for rate, amount := range gross {
+		net, vat := SplitGross(amount, rate, currency)
+		rows = append(rows, models.VatRow{Rate: rate, Net: net, Vat: vat, Gross: amount})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Rate > rows[j].Rate
+	})
+	return rows
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/modules/feed/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const cacheControl = "public, max-age=300"
+
+func RSSHandler(ctx *gin.Context) {
+	blogFeed(ctx, service.FormatRSS, "application/rss+xml; charset=utf-8")
+}
+
+func AtomHandler(ctx *gin.Context) {
+	blogFeed(ctx, service.FormatAtom, "application/atom+xml; charset=utf-8")
+}
+
+func blogFeed(ctx *gin.Context, format service.Format, contentType string) {
+	db := postgres.DB
+	language := ctx.Param("language")
+	if !service.IsEnabledLanguage(language) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Unsupported language"})
+		return
+	}
+
+	body, err := service.BlogFeed(db, format, language)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Cache-Control", cacheControl)
+	ctx.Data(http.StatusOK, contentType, body)
+}
+
+func SitemapHandler(ctx *gin.Context) {
+	db := postgres.DB
+	body, err := service.Sitemap(db)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Cache-Control", cacheControl)
+	ctx.Data(http.StatusOK, "application/xml; charset=utf-8", body)
+}
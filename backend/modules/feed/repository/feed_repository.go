@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	blogModels "backend/modules/blog/models"
+	mediaModels "backend/modules/media/models"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// Page — опублікований блог або товар для sitemap
+type Page struct {
+	ID                 uuid.UUID
+	TranslationGroupID uuid.UUID
+	Language           string
+	Slug               *string
+	UpdatedAt          time.Time
+}
+
+// GetPublishedPages повертає опубліковані записи таблиці разом із групою перекладів
+func GetPublishedPages(db *gorm.DB, table string) ([]Page, error) {
+	var pages []Page
+	err := db.Table(table).
+		Select("id, translation_group_id, language, slug, updated_at").
+		Where("state = ?", entities.StatePublished).
+		Order("position ASC, language ASC").
+		Scan(&pages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pages {
+		if pages[i].TranslationGroupID == uuid.Nil {
+			pages[i].TranslationGroupID = pages[i].ID
+		}
+	}
+	return pages, nil
+}
+
+// GetFeedBlogs повертає останні опубліковані блоги мовою language разом з авторами
+func GetFeedBlogs(db *gorm.DB, language string, limit int) ([]blogModels.Blog, error) {
+	var blogs []blogModels.Blog
+	err := db.Preload("User").
+		Where("language = ? AND state = ?", language, entities.StatePublished).
+		Order("COALESCE(publish_at, created_at) DESC").
+		Limit(limit).
+		Find(&blogs).Error
+	return blogs, err
+}
+
+// GetBlogImages повертає зображення блогів: власні та спільні для групи перекладів
+func GetBlogImages(db *gorm.DB, blogs []blogModels.Blog) (map[uuid.UUID][]mediaModels.Media, error) {
+	var contentIDs []uuid.UUID
+	for _, blog := range blogs {
+		contentIDs = append(contentIDs, blog.ID)
+		if blog.TranslationGroupID != uuid.Nil && blog.TranslationGroupID != blog.ID {
+			contentIDs = append(contentIDs, blog.TranslationGroupID)
+		}
+	}
+
+	images := make(map[uuid.UUID][]mediaModels.Media)
+	if len(contentIDs) == 0 {
+		return images, nil
+	}
+
+	var media []mediaModels.Media
+	err := db.Where("content_id IN (?) AND type LIKE ?", contentIDs, "image/%").Order("created_at ASC").Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byContent := make(map[uuid.UUID][]mediaModels.Media)
+	for _, m := range media {
+		byContent[m.ContentId] = append(byContent[m.ContentId], m)
+	}
+	for _, blog := range blogs {
+		if blog.TranslationGroupID != uuid.Nil && blog.TranslationGroupID != blog.ID {
+			images[blog.ID] = append(images[blog.ID], byContent[blog.TranslationGroupID]...)
+		}
+		images[blog.ID] = append(images[blog.ID], byContent[blog.ID]...)
+	}
+	return images, nil
+}
+
+// ContentVersion повертає відбиток стану таблиць: змінюється при створенні, зміні чи видаленні записів
+func ContentVersion(db *gorm.DB, tables ...string) (string, error) {
+	var parts []string
+	for _, table := range tables {
+		column := "updated_at"
+		if table == "media" {
+			column = "created_at"
+		}
+
+		var state struct {
+			Count   int64
+			Changed *time.Time
+		}
+		err := db.Table(table).Select(fmt.Sprintf("COUNT(*) AS count, MAX(%s) AS changed", column)).Scan(&state).Error
+		if err != nil {
+			return "", err
+		}
+
+		changed := int64(0)
+		if state.Changed != nil {
+			changed = state.Changed.UnixNano()
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d:%d", table, state.Count, changed))
+	}
+	return strings.Join(parts, "|"), nil
+}
@@ -0,0 +1,16 @@
+package feed
+
+import (
+	"backend/modules/feed/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes — публічні стрічки та sitemap, доступні без токена
+func RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/sitemap.xml", handlers.SitemapHandler)
+	feedGroup := r.Group("/feeds/:language")
+	{
+		feedGroup.GET("/rss.xml", handlers.RSSHandler)
+		feedGroup.GET("/atom.xml", handlers.AtomHandler)
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"backend/internal/services/feed"
+	"backend/internal/services/utils"
+	blogModels "backend/modules/blog/models"
+	"backend/modules/feed/repository"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const feedSize = 50
+
+type Format string
+
+const (
+	FormatRSS  Format = "rss"
+	FormatAtom Format = "atom"
+)
+
+// Кеш стрічок і sitemap; документ генерується заново, коли змінюється версія контенту
+var cache = feed.NewCache()
+
+// SiteURL — адреса публічного сайту з SITE_URL або APP_URL
+func SiteURL() string {
+	site := os.Getenv("SITE_URL")
+	if site == "" {
+		site = os.Getenv("APP_URL")
+	}
+	return strings.TrimRight(site, "/")
+}
+
+func siteName() string {
+	if name := os.Getenv("SITE_NAME"); name != "" {
+		return name
+	}
+	return "Blog"
+}
+
+// Шляхи сторінок на публічному сайті
+func blogURL(language string, slug *string, id uuid.UUID) string {
+	return pageURL(language, "blog", slug, id)
+}
+
+func itemURL(language string, slug *string, id uuid.UUID) string {
+	return pageURL(language, "items", slug, id)
+}
+
+func pageURL(language, section string, slug *string, id uuid.UUID) string {
+	path := id.String()
+	if slug != nil && *slug != "" {
+		path = *slug
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", SiteURL(), language, section, path)
+}
+
+// FeedURL — адреса стрічки для rel="self"; будується з SITE_URL, а не із заголовків запиту
+func FeedURL(format Format, language string) string {
+	return fmt.Sprintf("%s/feeds/%s/%s.xml", SiteURL(), language, format)
+}
+
+// BlogFeed повертає RSS або Atom стрічку опублікованих блогів мовою language
+func BlogFeed(db *gorm.DB, format Format, language string) ([]byte, error) {
+	version, err := repository.ContentVersion(db, "blogs", "media", "users")
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s:%s", format, language)
+	return cache.Get(key, version, func() ([]byte, error) {
+		blogs, err := repository.GetFeedBlogs(db, language, feedSize)
+		if err != nil {
+			return nil, err
+		}
+		images, err := repository.GetBlogImages(db, blogs)
+		if err != nil {
+			return nil, err
+		}
+
+		channel := feed.Channel{
+			Title:       siteName(),
+			Link:        fmt.Sprintf("%s/%s/blog", SiteURL(), language),
+			SelfLink:    FeedURL(format, language),
+			Description: siteName(),
+			Language:    language,
+		}
+		entries := make([]feed.Entry, 0, len(blogs))
+		for _, blog := range blogs {
+			entry := blogEntry(&blog)
+			for _, image := range images[blog.ID] {
+				entry.Enclosures = append(entry.Enclosures, feed.Enclosure{URL: image.Url, Type: image.Type})
+			}
+			if entry.Updated.After(channel.Updated) {
+				channel.Updated = entry.Updated
+			}
+			entries = append(entries, entry)
+		}
+		if channel.Updated.IsZero() {
+			channel.Updated = time.Now()
+		}
+
+		if format == FormatAtom {
+			return feed.Atom(channel, entries)
+		}
+		return feed.RSS(channel, entries)
+	})
+}
+
+func blogEntry(blog *blogModels.Blog) feed.Entry {
+	published := blog.CreatedAt
+	if blog.PublishAt != nil {
+		published = *blog.PublishAt
+	}
+	return feed.Entry{
+		ID:        "urn:uuid:" + blog.ID.String(),
+		Title:     blog.Title,
+		Link:      blogURL(blog.Language, blog.Slug, blog.ID),
+		Author:    blog.User.FullName,
+		Summary:   blog.Excerpt,
+		Content:   blog.ContentHTML,
+		Published: published,
+		Updated:   blog.UpdatedAt,
+	}
+}
+
+// Sitemap повертає sitemap.xml з опублікованими блогами й товарами та їхніми мовними версіями
+func Sitemap(db *gorm.DB) ([]byte, error) {
+	version, err := repository.ContentVersion(db, "blogs", "items")
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.Get("sitemap", version+"|"+SiteURL(), func() ([]byte, error) {
+		blogs, err := repository.GetPublishedPages(db, "blogs")
+		if err != nil {
+			return nil, err
+		}
+		items, err := repository.GetPublishedPages(db, "items")
+		if err != nil {
+			return nil, err
+		}
+
+		urls := sitemapURLs(blogs, blogURL)
+		urls = append(urls, sitemapURLs(items, itemURL)...)
+		return feed.Sitemap(urls)
+	})
+}
+
+// sitemapURLs додає до кожної сторінки посилання на всі опубліковані переклади її групи
+func sitemapURLs(pages []repository.Page, link func(string, *string, uuid.UUID) string) []feed.URL {
+	groups := make(map[string][]feed.Alternate)
+	for _, page := range pages {
+		key := page.TranslationGroupID.String()
+		groups[key] = append(groups[key], feed.Alternate{Language: page.Language, Href: link(page.Language, page.Slug, page.ID)})
+	}
+
+	urls := make([]feed.URL, 0, len(pages))
+	for _, page := range pages {
+		urls = append(urls, feed.URL{
+			Loc:        link(page.Language, page.Slug, page.ID),
+			LastMod:    page.UpdatedAt,
+			Alternates: groups[page.TranslationGroupID.String()],
+		})
+	}
+	return urls
+}
+
+// IsEnabledLanguage перевіряє, що для мови публікується стрічка
+func IsEnabledLanguage(language string) bool {
+	return slices.Contains(utils.EnabledLanguages(), language)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/item/repository"
+	propRepo "backend/modules/property/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"slices"
+)
+
+func BulkItemsHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	var request entities.BulkRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err := request.Validate(entities.BulkSetState, entities.BulkSetCategory, entities.BulkSetLanguage,
+		entities.BulkDelete, entities.BulkShiftPrice)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.Operation == entities.BulkSetLanguage && !slices.Contains(utils2.EnabledLanguages(), request.Language) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported language"})
+		return
+	}
+
+	response, err := repository.BulkItems(db, user.ID, user.IsSuperUser, &request)
+	if errors.Is(err, propRepo.ErrInvalidFilter) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	"backend/internal/services/spreadsheet"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/item/models"
+	"backend/modules/item/repository"
+	propRepo "backend/modules/property/repository"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Максимальний розмір файлу імпорту
+const importMaxFileSize = 20 << 20
+
+func ExportItemsHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	format, err := spreadsheet.ParseFormat(ctx.DefaultQuery("format", "csv"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Без параметра language вивантажуються всі мови
+	params := &entities.Parameters{Language: ctx.Query("language")}
+	if categoryParam := ctx.Query("category_id"); categoryParam != "" {
+		categoryId, err := uuid.Parse(categoryParam)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return
+		}
+		params.CategoryID = categoryId
+	}
+	params.Attributes = ParseAttributeFilters(ctx.Request.URL.Query())
+
+	rows, err := repository.ExportItems(db, user.ID, user.IsSuperUser, params)
+	if errors.Is(err, propRepo.ErrInvalidFilter) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileName := fmt.Sprintf("items-%s.%s", time.Now().Format("20060102"), format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Status(http.StatusOK)
+	if err = spreadsheet.Write(ctx.Writer, format, rows); err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
+func ImportItemsHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	if fileHeader.Size > importMaxFileSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+		return
+	}
+
+	formatName := ctx.PostForm("format")
+	if formatName == "" {
+		formatName = fileHeader.Filename
+	}
+	format, err := spreadsheet.ParseFormat(formatName)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, importMaxFileSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := spreadsheet.Read(data, format)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(rows) < 2 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "The file has no data rows"})
+		return
+	}
+
+	job := &models.ImportJob{
+		OwnerID:        user.ID,
+		FileName:       fileHeader.Filename,
+		Language:       ctx.DefaultPostForm("language", "pl"),
+		DryRun:         ctx.PostForm("dry_run") == "true",
+		DownloadImages: ctx.PostForm("download_images") == "true",
+		Total:          len(rows) - 1,
+	}
+	if err = repository.CreateImportJob(db, job); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Горутина змінює job, тому відповідаємо копією
+	accepted := *job
+	go repository.RunImportJob(db, job, rows, user.IsSuperUser)
+
+	ctx.JSON(http.StatusAccepted, accepted)
+}
+
+func GetImportJobHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	jobId, err := uuid.Parse(ctx.Param("jobId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := repository.GetImportJob(db, jobId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Import job not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if job.OwnerID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Access denied"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
@@ -3,13 +3,22 @@ package handlers
 import (
 	"backend/internal/db/postgres"
 	"backend/internal/entities"
+	internalRepo "backend/internal/repository"
+	"backend/internal/services/money"
 	utils2 "backend/internal/services/utils"
+	categoryRepo "backend/modules/category/repository"
 	"backend/modules/item/models"
 	"backend/modules/item/repository"
+	propRepo "backend/modules/property/repository"
+	tagRepo "backend/modules/tag/repository"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 func CreateItemHandler(ctx *gin.Context) {
@@ -28,6 +37,10 @@ func CreateItemHandler(ctx *gin.Context) {
 	item.OwnerID = userID
 
 	newItem, err := repository.CreateItem(db, &item)
+	if errors.Is(err, internalRepo.ErrSlugTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -80,8 +93,7 @@ func GetAvailableLanguages(ctx *gin.Context) {
 func GetAvailableCategories(ctx *gin.Context) {
 	db := postgres.DB
 
-	var categories []string
-	err := db.Model(&models.Items{}).Distinct("category").Pluck("category", &categories).Error
+	categories, err := categoryRepo.GetCategoryTree(db, ctx.DefaultQuery("language", "pl"))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -103,20 +115,24 @@ func UpdateItemByIdHandler(ctx *gin.Context) {
 		return
 	}
 
+	// Доступ перевіряється до оновлення: разом зі зміною фіксуються рух складу, ревізія та події
+	if _, ok = getOwnedItem(ctx, id); !ok {
+		return
+	}
+
 	var update models.ItemUpdate
 	if err := ctx.ShouldBindJSON(&update); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	item, err := repository.UpdateItemById(db, id, &update)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	item, err := repository.UpdateItemById(db, id, user.ID, &update)
+	if errors.Is(err, internalRepo.ErrSlugTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		return
 	}
-
-	if item.OwnerID != user.ID && !user.IsSuperUser {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Access denied"})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -136,6 +152,26 @@ func GetAllItemsHandler(ctx *gin.Context) {
 
 	isSuperUser, _ := utils2.GetIsSuperUser(db, user.ID)
 
+	params, ok := ParseItemParameters(ctx)
+	if !ok {
+		return
+	}
+
+	items, err := repository.GetAllItems(db, user.ID, isSuperUser, params)
+	if errors.Is(err, propRepo.ErrInvalidFilter) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+// ParseItemParameters читає фільтри списку товарів із query; при помилці відповідь уже надіслана
+func ParseItemParameters(ctx *gin.Context) (*entities.Parameters, bool) {
 	language := ctx.DefaultQuery("language", "pl")
 	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
@@ -146,13 +182,58 @@ func GetAllItemsHandler(ctx *gin.Context) {
 		Limit:    limit,
 	}
 
-	items, err := repository.GetAllItems(db, user.ID, isSuperUser, params)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+	if categoryParam := ctx.Query("category_id"); categoryParam != "" {
+		categoryId, err := uuid.Parse(categoryParam)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return nil, false
+		}
+		params.CategoryID = categoryId
 	}
 
-	ctx.JSON(http.StatusOK, items)
+	if state := ctx.Query("state"); state != "" {
+		params.State = entities.PublicationState(state)
+		if !params.State.Valid() {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid state"})
+			return nil, false
+		}
+	}
+
+	params.Attributes = ParseAttributeFilters(ctx.Request.URL.Query())
+	params.Tags = tagRepo.ParseTagSlugs(ctx.Query("tags"))
+	params.TagsMatchAny = ctx.Query("tags_match") == "any"
+
+	var ok bool
+	if params.MinPrice, ok = parsePriceParam(ctx, "min_price"); !ok {
+		return nil, false
+	}
+	if params.MaxPrice, ok = parsePriceParam(ctx, "max_price"); !ok {
+		return nil, false
+	}
+	// Ціновий фільтр і діапазони завжди в одній валюті, за замовчуванням базовій
+	params.Currency = money.BaseCurrency()
+	if currency := ctx.Query("currency"); currency != "" {
+		params.Currency = money.NormalizeCurrency(currency)
+		if !money.IsSupported(params.Currency) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid currency"})
+			return nil, false
+		}
+	}
+
+	params.WithFacets = ctx.Query("with_facets") == "true"
+	if facets := ctx.Query("facets"); facets != "" {
+		params.Facets = splitList(facets)
+	}
+	if bucketsParam := ctx.Query("price_buckets"); bucketsParam != "" {
+		buckets, err := ParsePriceBuckets(bucketsParam)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid price_buckets"})
+			return nil, false
+		}
+		params.PriceBuckets = buckets
+	}
+
+	return params, true
 }
 
 func DeleteItemByIdHandler(ctx *gin.Context) {
@@ -187,3 +268,79 @@ func DeleteItemByIdHandler(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, gin.H{"success": "Item deleted"})
 }
+
+// getOwnedItem завантажує товар і перевіряє, що поточний користувач має до нього доступ
+func getOwnedItem(ctx *gin.Context, itemId uuid.UUID) (*models.ItemGet, bool) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, false
+	}
+
+	item, err := repository.GetItemById(db, itemId)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return nil, false
+	}
+
+	if item.OwnerID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Access denied"})
+		return nil, false
+	}
+	return item, true
+}
+
+// parsePriceParam читає ціну в мінорних одиницях; відсутній параметр — nil
+func parsePriceParam(ctx *gin.Context, name string) (*int64, bool) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	price, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || price < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return nil, false
+	}
+	return &price, true
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// ParsePriceBuckets читає межі цінових діапазонів виду "0,5000,10000"
+func ParsePriceBuckets(value string) ([]int64, error) {
+	var bounds []int64
+	for _, part := range splitList(value) {
+		bound, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if bound < 0 {
+			return nil, fmt.Errorf("negative price bound %d", bound)
+		}
+		bounds = append(bounds, bound)
+	}
+	return bounds, nil
+}
+
+// ParseAttributeFilters збирає фільтри виду ?attr.color=red&attr.color=blue або ?attr.size=S,M
+func ParseAttributeFilters(query url.Values) map[string][]string {
+	filters := make(map[string][]string)
+	for key, values := range query {
+		code, found := strings.CutPrefix(key, "attr.")
+		if !found || code == "" {
+			continue
+		}
+		for _, value := range values {
+			filters[code] = append(filters[code], splitList(value)...)
+		}
+	}
+	return filters
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/money"
+	"backend/modules/item/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func SetItemPricesHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var prices []money.Money
+	if err := ctx.ShouldBindJSON(&prices); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, ok := getOwnedItem(ctx, itemId)
+	if !ok {
+		return
+	}
+
+	// Прайс-лист спільний для всіх перекладів товару
+	saved, err := repository.SetItemPrices(db, item.TranslationGroupID, prices)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"prices": saved})
+}
+
+func GetItemPriceHandler(ctx *gin.Context) {
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	item, ok := getOwnedItem(ctx, itemId)
+	if !ok {
+		return
+	}
+
+	price, err := repository.ResolveItemPrice(item, ctx.Query("currency"), money.DefaultRates())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"price": price, "formatted": price.String()})
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/item/repository"
+	propModel "backend/modules/property/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func GetItemPropertiesHandler(ctx *gin.Context) {
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	item, ok := getOwnedItem(ctx, itemId)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"properties": item.Properties})
+}
+
+func SetItemPropertiesHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var values propModel.PropertyValuesPut
+	if err := ctx.ShouldBindJSON(&values); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	properties, err := repository.SetItemProperties(db, itemId, user.ID, values)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"properties": properties})
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/item/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// GetItemBySlugHandler шукає запис за мовою та slug; за застарілим slug відповідає 301 на актуальну адресу
+func GetItemBySlugHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	item, redirected, err := repository.GetItemBySlug(db, ctx.Param("language"), ctx.Param("slug"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if item.OwnerID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if redirected {
+		// Мова береться із запису, а не з запиту: старий slug міг належати запису іншої мови
+		base := path.Dir(path.Dir(ctx.Request.URL.Path))
+		ctx.Header("Location", path.Join(base, url.PathEscape(item.Language), url.PathEscape(*item.Slug)))
+		ctx.JSON(http.StatusMovedPermanently, gin.H{"slug": *item.Slug})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
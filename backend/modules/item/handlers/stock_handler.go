@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/item/models"
+	"backend/modules/item/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+func CreateStockMovementHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	userID, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var movement models.StockMovementPost
+	if err := ctx.ShouldBindJSON(&movement); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Резерви створюються й знімаються лише замовленнями
+	if movement.Type == models.MovementReservation || movement.Type == models.MovementRelease {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Reservations are managed by orders"})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+
+	record, err := repository.RecordStockMovement(db, itemId, userID, &movement)
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, record)
+}
+
+func GetStockMovementsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+	if skip < 0 {
+		skip = 0
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	movements, err := repository.GetStockMovements(db, itemId, skip, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, movements)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	tagModels "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func GetItemTagsHandler(ctx *gin.Context) {
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	item, ok := getOwnedItem(ctx, itemId)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": item.Tags})
+}
+
+// SetItemTagsHandler замінює теги товару; теги кожної мовної версії незалежні
+func SetItemTagsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var put tagModels.TagsPut
+	if err := ctx.ShouldBindJSON(&put); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+
+	tags, err := tagRepo.SetEntityTags(db, tagModels.EntityItems, itemId, put.Tags)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
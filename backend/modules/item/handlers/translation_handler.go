@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	internalRepo "backend/internal/repository"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/item/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+func GetItemTranslationsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+
+	translations, err := repository.GetItemTranslations(db, itemId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"translations": translations})
+}
+
+func CreateItemTranslationHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var post entities.TranslationPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+
+	translation, err := repository.CreateItemTranslation(db, itemId, &post)
+	if errors.Is(err, internalRepo.ErrTranslationExists) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, translation)
+}
+
+func DeleteItemTranslationHandler(ctx *gin.Context) {
+	db := postgres.DB
+	itemId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	if _, ok := getOwnedItem(ctx, itemId); !ok {
+		return
+	}
+
+	err = repository.DeleteItemTranslation(db, itemId, ctx.Param("language"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Translation not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Translation deleted"})
+}
+
+func GetMissingItemTranslationsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	languages := utils2.ParseLanguages(ctx.Query("languages"))
+
+	report, err := repository.GetMissingItemTranslations(db, user.ID, user.IsSuperUser, languages)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"languages": languages, "data": report, "count": len(report)})
+}
@@ -1,53 +1,110 @@
 package models
 
 import (
+	"backend/internal/entities"
+	"backend/internal/services/money"
 	"backend/modules/property/models"
+	tagModels "backend/modules/tag/models"
 	"github.com/google/uuid"
+	"time"
 )
 
 type ItemsPost struct {
-	ID       uuid.UUID
-	Title    string    `json:"title"`
-	Content  string    `json:"content"`
-	Price    float64   `json:"price"`
-	Quantity int       `json:"quantity"`
-	Position int       `json:"position"`
-	Language string    `json:"language"`
-	ItemUrl  string    `json:"item_url"`
-	Category string    `json:"category"`
-	Status   bool      `json:"status"`
-	OwnerID  uuid.UUID `json:"owner_id"`
+	ID                uuid.UUID
+	Title             string     `json:"title"`
+	Content           string     `json:"content"`
+	Price             int64      `json:"price"`
+	Currency          string     `json:"currency"`
+	Quantity          int        `json:"quantity"`
+	Position          int        `json:"position"`
+	Language          string     `json:"language"`
+	Sku               *string    `json:"sku"`
+	Slug              *string    `json:"slug"`
+	ItemUrl           string     `json:"item_url"`
+	CategoryID        *uuid.UUID `json:"category_id"`
+	LowStockThreshold int        `json:"low_stock_threshold"`
+	VatRate           *int       `json:"vat_rate"`
+	OwnerID           uuid.UUID  `json:"owner_id"`
+	entities.SEO
+	entities.Publication
 }
 
 type ItemGet struct {
-	ID       uuid.UUID
-	Title    string             `json:"title"`
-	Content  string             `json:"content"`
-	Price    float64            `json:"price"`
-	Quantity int                `json:"quantity"`
-	Position int                `json:"position"`
-	Language string             `json:"language"`
-	ItemUrl  string             `json:"item_url"`
-	Category string             `json:"category"`
-	Status   bool               `json:"status"`
-	Property models.PropertyGet `json:"property"`
-	OwnerID  uuid.UUID          `json:"owner_id"`
-	Images   []string           `json:"images"`
+	ID                 uuid.UUID
+	Title              string               `json:"title"`
+	Content            string               `json:"content"`
+	Price              int64                `json:"price"`
+	Currency           string               `json:"currency"`
+	Prices             []money.Money        `json:"prices"`
+	Quantity           int                  `json:"quantity"`
+	Position           int                  `json:"position"`
+	Language           string               `json:"language"`
+	Sku                *string              `json:"sku"`
+	Slug               *string              `json:"slug"`
+	ItemUrl            string               `json:"item_url"`
+	CategoryID         *uuid.UUID           `json:"category_id"`
+	LowStockThreshold  int                  `json:"low_stock_threshold"`
+	VatRate            *int                 `json:"vat_rate"`
+	Properties         []models.PropertyGet `json:"properties"`
+	OwnerID            uuid.UUID            `json:"owner_id"`
+	TranslationGroupID uuid.UUID            `json:"translation_group_id"`
+	Images             []string             `json:"images"`
+	Tags               []tagModels.TagGet   `json:"tags"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+	entities.SEO
+	entities.Publication
 }
 
 type ItemUpdate struct {
-	Title    *string  `json:"title"`
-	Content  *string  `json:"content"`
-	Price    *float64 `json:"price"`
-	Quantity *int     `json:"quantity"`
-	Position *int     `json:"position"`
-	ItemUrl  *string  `json:"item_url"`
-	Category *string  `json:"category"`
-	Language *string  `json:"language"`
-	Status   *bool    `json:"status"`
+	Title             *string    `json:"title"`
+	Content           *string    `json:"content"`
+	Price             *int64     `json:"price"`
+	Currency          *string    `json:"currency"`
+	Quantity          *int       `json:"quantity"`
+	Position          *int       `json:"position"`
+	ItemUrl           *string    `json:"item_url"`
+	CategoryID        *uuid.UUID `json:"category_id"`
+	Language          *string    `json:"language"`
+	Sku               *string    `json:"sku"`
+	Slug              *string    `json:"slug"`
+	LowStockThreshold *int       `json:"low_stock_threshold"`
+	VatRate           *int       `json:"vat_rate"` // -1 повертає ставку за замовчуванням
+	StockReason       *string    `json:"stock_reason"`
+	// Коментар до ревізії, яку створить оновлення
+	RevisionComment string `json:"-"`
+	entities.SEOUpdate
+	entities.PublicationUpdate
 }
 
 type ItemGetAll struct {
-	Data  []*ItemGet
+	Data   []*ItemGet
+	Count  int
+	Total  int64
+	Tags   []tagModels.TagGet
+	Facets *ItemFacets `json:",omitempty"`
+}
+
+// PriceBucket — діапазон ціни [From, To); To відсутній для останнього діапазону
+type PriceBucket struct {
+	From  int64  `json:"from"`
+	To    *int64 `json:"to"`
+	Count int64  `json:"count"`
+}
+
+type ItemFacets struct {
+	Attributes map[string][]models.FacetValue `json:"attributes"`
+	Price      []PriceBucket                  `json:"price"`
+	// Валюта, в якій пораховані цінові діапазони
+	Currency string `json:"currency"`
+}
+
+type StockMovementPost struct {
+	Type     MovementType `json:"type" binding:"required"`
+	Quantity int          `json:"quantity" binding:"required"`
+	Reason   string       `json:"reason"`
+}
+
+type StockMovementGetAll struct {
+	Data  []*StockMovement
 	Count int
 }
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ItemCreated — створено товар
+type ItemCreated struct {
+	Item    *ItemsPost `json:"item"`
+	ActorID uuid.UUID  `json:"actor_id"`
+}
+
+func (ItemCreated) EventName() string { return "item.created" }
+
+// ItemUpdated — змінено товар
+type ItemUpdated struct {
+	Item    *ItemGet  `json:"item"`
+	ActorID uuid.UUID `json:"actor_id"`
+}
+
+func (ItemUpdated) EventName() string { return "item.updated" }
+
+// StockLow — залишок товару опустився до порогу
+type StockLow struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Title     string    `json:"title"`
+	Quantity  int       `json:"quantity"`
+	Threshold int       `json:"threshold"`
+}
+
+func (StockLow) EventName() string { return "item.stock_low" }
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"backend/internal/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"
+	ImportRunning   ImportStatus = "running"
+	ImportCompleted ImportStatus = "completed"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportJob — фонове завантаження каталогу з CSV/XLSX
+type ImportJob struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	OwnerID        uuid.UUID     `gorm:"type:uuid;not null;index" json:"owner_id"`
+	FileName       string        `json:"file_name"`
+	Language       string        `gorm:"not null" json:"language"`
+	DryRun         bool          `gorm:"default:false" json:"dry_run"`
+	DownloadImages bool          `gorm:"default:false" json:"download_images"`
+	Status         ImportStatus  `gorm:"type:varchar(16);not null;default:'pending'" json:"status"`
+	Total          int           `json:"total"`
+	Processed      int           `json:"processed"`
+	Created        int           `json:"created"`
+	Updated        int           `json:"updated"`
+	Failed         int           `json:"failed"`
+	Errors         entities.JSON `json:"errors"`
+	Error          string        `json:"error,omitempty"`
+	StartedAt      *time.Time    `json:"started_at"`
+	FinishedAt     *time.Time    `json:"finished_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func (job *ImportJob) BeforeCreate(*gorm.DB) error {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	return nil
+}
+
+// ImportRowError — помилка конкретного рядка файлу (нумерація як у таблиці, з заголовком)
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"backend/internal/entities"
 	"backend/modules/user/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -8,25 +9,36 @@ import (
 )
 
 type Items struct {
-	ID        uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
-	Title     string      `gorm:"not null" json:"title"`
-	Content   string      `gorm:"not null" json:"content"`
-	Price     float64     `gorm:"not null" json:"price"`
-	Quantity  int         `gorm:"not null" json:"quantity"`
-	Position  int         `gorm:"not null" json:"position"`
-	Language  string      `gorm:"not null" json:"language"`
-	ItemUrl   string      `gorm:"default:null" json:"item_url"`
-	Category  string      `gorm:"default:null" json:"category"`
-	Status    bool        `gorm:"default:false" json:"status"`
-	OwnerID   uuid.UUID   `gorm:"not null;index" json:"-"`
-	User      models.User `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
+	Title              string      `gorm:"not null" json:"title"`
+	Content            string      `gorm:"not null" json:"content"`
+	Price              int64       `gorm:"not null;default:0" json:"price"`
+	Currency           string      `gorm:"type:varchar(3);not null;default:'PLN'" json:"currency"`
+	Quantity           int         `gorm:"not null" json:"quantity"`
+	Position           int         `gorm:"not null" json:"position"`
+	Language           string      `gorm:"not null;uniqueIndex:idx_items_sku_language;uniqueIndex:idx_items_slug_language" json:"language"`
+	Sku                *string     `gorm:"type:varchar(64);uniqueIndex:idx_items_sku_language" json:"sku"`
+	Slug               *string     `gorm:"type:varchar(255);uniqueIndex:idx_items_slug_language" json:"slug"`
+	ItemUrl            string      `gorm:"default:null" json:"item_url"`
+	CategoryID         *uuid.UUID  `gorm:"type:uuid;index" json:"category_id"`
+	LowStockThreshold  int         `gorm:"default:0" json:"low_stock_threshold"`
+	VatRate            *int        `json:"vat_rate"`
+	TranslationGroupID uuid.UUID   `gorm:"type:uuid;index" json:"translation_group_id"`
+	OwnerID            uuid.UUID   `gorm:"not null;index" json:"-"`
+	User               models.User `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	entities.SEO
+	entities.Publication
 }
 
 func (item *Items) BeforeCreate(*gorm.DB) error {
-	if item.ID != uuid.Nil {
+	if item.ID == uuid.Nil {
 		item.ID = uuid.New()
 	}
+	// Новий товар без перекладів сам утворює групу
+	if item.TranslationGroupID == uuid.Nil {
+		item.TranslationGroupID = item.ID
+	}
 	return nil
 }
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ItemPrice — явна ціна товару в іншій валюті (у мінімальних одиницях)
+type ItemPrice struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ItemID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_item_price_currency" json:"item_id"`
+	Currency string    `gorm:"type:varchar(3);not null;uniqueIndex:idx_item_price_currency" json:"currency"`
+	Amount   int64     `gorm:"not null" json:"amount"`
+}
+
+func (price *ItemPrice) BeforeCreate(*gorm.DB) error {
+	if price.ID == uuid.Nil {
+		price.ID = uuid.New()
+	}
+	return nil
+}
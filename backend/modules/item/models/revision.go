@@ -0,0 +1,23 @@
+package models
+
+import (
+	"backend/internal/entities"
+	"github.com/google/uuid"
+)
+
+// ItemSnapshot — поля товару, що зберігаються в ревізії.
+// Кількість не входить: залишки змінюються лише через журнал руху.
+type ItemSnapshot struct {
+	Title             string     `json:"title"`
+	Content           string     `json:"content"`
+	Sku               *string    `json:"sku"`
+	Price             int64      `json:"price"`
+	Currency          string     `json:"currency"`
+	ItemUrl           string     `json:"item_url"`
+	CategoryID        *uuid.UUID `json:"category_id"`
+	LowStockThreshold int        `json:"low_stock_threshold"`
+	VatRate           *int       `json:"vat_rate"`
+	entities.SEO
+	entities.Publication
+	Properties map[string]interface{} `json:"properties"`
+}
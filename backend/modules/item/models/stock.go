@@ -0,0 +1,59 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MovementType string
+
+const (
+	MovementReceipt     MovementType = "receipt"
+	MovementSale        MovementType = "sale"
+	MovementAdjustment  MovementType = "adjustment"
+	MovementReturn      MovementType = "return"
+	MovementReservation MovementType = "reservation" // резерв під замовлення
+	MovementRelease     MovementType = "release"     // зняття резерву при скасуванні замовлення
+)
+
+// StockMovement — запис журналу складу, кожна зміна Items.Quantity проходить через нього
+type StockMovement struct {
+	ID            uuid.UUID    `gorm:"type:uuid;primaryKey" json:"id"`
+	ItemID        uuid.UUID    `gorm:"type:uuid;not null;index" json:"item_id"`
+	Type          MovementType `gorm:"type:varchar(20);not null" json:"type"`
+	Quantity      int          `gorm:"not null" json:"quantity"`
+	QuantityAfter int          `gorm:"not null" json:"quantity_after"`
+	Reason        string       `gorm:"default:null" json:"reason"`
+	UserID        uuid.UUID    `gorm:"type:uuid;not null;index" json:"user_id"`
+	CreatedAt     time.Time    `gorm:"not null;index" json:"created_at"`
+}
+
+func (movement *StockMovement) BeforeCreate(*gorm.DB) error {
+	if movement.ID == uuid.Nil {
+		movement.ID = uuid.New()
+	}
+	return nil
+}
+
+// Delta повертає знакову зміну кількості для типу руху
+func (t MovementType) Delta(quantity int) (int, bool) {
+	switch t {
+	case MovementReceipt, MovementReturn, MovementRelease:
+		if quantity <= 0 {
+			return 0, false
+		}
+		return quantity, true
+	case MovementSale, MovementReservation:
+		if quantity <= 0 {
+			return 0, false
+		}
+		return -quantity, true
+	case MovementAdjustment:
+		if quantity == 0 {
+			return 0, false
+		}
+		return quantity, true
+	}
+	return 0, false
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/repository"
+	"backend/internal/services/money"
+	"backend/modules/item/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"math/big"
+)
+
+// BulkItems виконує масову операцію над товарами з перевіркою власника для кожного ID
+func BulkItems(db *gorm.DB, userId uuid.UUID, isSuperUser bool, request *entities.BulkRequest) (*entities.BulkResponse, error) {
+	var factor *big.Rat
+	if request.Operation == entities.BulkShiftPrice {
+		var err error
+		if factor, err = request.PriceFactor(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Ціна спільна для групи перекладів, тому зсуваємо її один раз на групу
+	shiftedGroups := make(map[uuid.UUID]bool)
+
+	resolve := func(tx *gorm.DB) ([]uuid.UUID, error) {
+		return bulkItemIDs(tx, userId, isSuperUser, request.Filter)
+	}
+
+	return repository.RunBulk(db, request, resolve, func(tx *gorm.DB, id uuid.UUID) error {
+		var item models.Items
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&item).Error
+		if err != nil {
+			return err
+		}
+		if item.OwnerID != userId && !isSuperUser {
+			return repository.ErrBulkAccessDenied
+		}
+
+		switch request.Operation {
+		case entities.BulkSetState:
+			update := &models.ItemUpdate{PublicationUpdate: entities.PublicationUpdate{State: request.State}}
+			return applyItemUpdate(tx, &item, userId, update)
+		case entities.BulkSetCategory:
+			return applyItemUpdate(tx, &item, userId, &models.ItemUpdate{CategoryID: request.CategoryID})
+		case entities.BulkSetLanguage:
+			return applyItemUpdate(tx, &item, userId, &models.ItemUpdate{Language: &request.Language})
+		case entities.BulkShiftPrice:
+			groupId := translationGroupID(&item)
+			if shiftedGroups[groupId] {
+				return nil
+			}
+			if err = shiftItemPrice(tx, &item, userId, factor); err != nil {
+				return err
+			}
+			shiftedGroups[groupId] = true
+			return nil
+		case entities.BulkDelete:
+			return DeleteItemById(tx, id)
+		}
+		return errors.New("unsupported operation")
+	})
+}
+
+// bulkItemIDs вибирає ID товарів за фільтром у межах доступних користувачу записів
+func bulkItemIDs(db *gorm.DB, userId uuid.UUID, isSuperUser bool, filter *entities.BulkFilter) ([]uuid.UUID, error) {
+	parameters := &entities.Parameters{Language: filter.Language, Attributes: filter.Attributes}
+	if filter.CategoryID != nil {
+		parameters.CategoryID = *filter.CategoryID
+	}
+	query, err := filterItems(db, userId, isSuperUser, parameters, exceptNone)
+	if err != nil {
+		return nil, err
+	}
+	if filter.State != "" {
+		query = query.Where("items.state = ?", filter.State)
+	}
+
+	var ids []uuid.UUID
+	err = query.Order("items.position ASC").Limit(entities.BulkMaxItems+1).Pluck("items.id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return repository.LimitBulkIDs(ids)
+}
+
+// shiftItemPrice змінює базову ціну та ціни в інших валютах на один множник
+func shiftItemPrice(tx *gorm.DB, item *models.Items, userId uuid.UUID, factor *big.Rat) error {
+	price := money.New(item.Price, item.Currency).MulRat(factor).Amount
+	if err := applyItemUpdate(tx, item, userId, &models.ItemUpdate{Price: &price}); err != nil {
+		return err
+	}
+
+	var prices []models.ItemPrice
+	if err := tx.Where("item_id = ?", translationGroupID(item)).Find(&prices).Error; err != nil {
+		return err
+	}
+	for _, itemPrice := range prices {
+		amount := money.New(itemPrice.Amount, itemPrice.Currency).MulRat(factor).Amount
+		if err := tx.Model(&itemPrice).Update("amount", amount).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"backend/internal/services/events"
+	"backend/modules/item/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// publishItemCreated публікує item.created у транзакції створення
+func publishItemCreated(tx *gorm.DB, item *models.Items, actorId uuid.UUID) error {
+	return events.Publish(tx, models.ItemCreated{Item: toItemsPost(item), ActorID: actorId})
+}
+
+// publishItemUpdated публікує item.updated зі станом товару, який бачить транзакція зміни
+func publishItemUpdated(tx *gorm.DB, itemId uuid.UUID, actorId uuid.UUID) error {
+	item, err := GetItemById(tx, itemId)
+	if err != nil {
+		return err
+	}
+	return events.Publish(tx, models.ItemUpdated{Item: item, ActorID: actorId})
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/money"
+	categoryModel "backend/modules/category/models"
+	"backend/modules/item/models"
+	mediaModel "backend/modules/media/models"
+	propRepo "backend/modules/property/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Колонки файлу каталогу; після них ідуть властивості у вигляді attr.<code>
+var catalogueColumns = []string{
+	"id", "translation_group_id", "sku", "language", "title", "content", "price", "currency",
+	"quantity", "low_stock_threshold", "category", "state", "publish_at", "unpublish_at", "item_url", "images",
+}
+
+const (
+	attributeColumnPrefix = "attr."
+	imageSeparator        = "|"
+)
+
+// ExportItems повертає таблицю товарів для вивантаження; перший рядок — заголовок
+func ExportItems(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *entities.Parameters) ([][]string, error) {
+	query, err := filterItems(db, userId, isSuperUser, parameters, exceptNone)
+	if err != nil {
+		return nil, err
+	}
+	var items []*models.Items
+	err = query.Order("items.translation_group_id ASC, items.language ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := propRepo.GetAttributes(db, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Той самий код може бути в атрибутах різних категорій; імпорт зіставляє колонку з атрибутом
+	// категорії товару за кодом, тож колонка на код одна
+	var codes []string
+	for _, attribute := range schema.Data {
+		if !slices.Contains(codes, attribute.Code) {
+			codes = append(codes, attribute.Code)
+		}
+	}
+	header := append([]string(nil), catalogueColumns...)
+	for _, code := range codes {
+		header = append(header, attributeColumnPrefix+code)
+	}
+
+	var categories []categoryModel.Category
+	if err = db.Select("id", "slug").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	categorySlugs := make(map[uuid.UUID]string, len(categories))
+	for _, category := range categories {
+		categorySlugs[category.ID] = category.Slug
+	}
+
+	var contentIDs []uuid.UUID
+	var groupIDs []uuid.UUID
+	for _, item := range items {
+		contentIDs = append(contentIDs, sharedContentIDs(item)...)
+		groupIDs = append(groupIDs, translationGroupID(item))
+	}
+
+	var media []*mediaModel.Media
+	if len(contentIDs) > 0 {
+		err = db.Where("content_id IN (?)", contentIDs).Order("created_at ASC").Find(&media).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	mediaMap := make(map[uuid.UUID][]string)
+	for _, m := range media {
+		mediaMap[m.ContentId] = append(mediaMap[m.ContentId], m.Url)
+	}
+
+	propertyMap, err := propRepo.GetPropertyValues(db, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{header}
+	for _, item := range items {
+		groupId := translationGroupID(item)
+
+		var images []string
+		for _, contentId := range sharedContentIDs(item) {
+			images = append(images, mediaMap[contentId]...)
+		}
+		sku := ""
+		if item.Sku != nil {
+			sku = *item.Sku
+		}
+		category := ""
+		if item.CategoryID != nil {
+			category = categorySlugs[*item.CategoryID]
+		}
+
+		row := []string{
+			item.ID.String(),
+			groupId.String(),
+			sku,
+			item.Language,
+			item.Title,
+			item.Content,
+			money.New(item.Price, item.Currency).Decimal(),
+			item.Currency,
+			strconv.Itoa(item.Quantity),
+			strconv.Itoa(item.LowStockThreshold),
+			category,
+			string(item.State),
+			formatTime(item.PublishAt),
+			formatTime(item.UnpublishAt),
+			item.ItemUrl,
+			strings.Join(images, imageSeparator),
+		}
+
+		values := make(map[string]string)
+		for _, property := range propertyMap[groupId] {
+			values[property.Code] = formatPropertyValue(property.Value)
+		}
+		for _, code := range codes {
+			row = append(row, values[code])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func formatPropertyValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	}
+	return ""
+}
+
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
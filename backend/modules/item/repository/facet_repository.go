@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/money"
+	categoryRepo "backend/modules/category/repository"
+	"backend/modules/item/models"
+	propModel "backend/modules/property/models"
+	propRepo "backend/modules/property/repository"
+	tagModel "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+)
+
+// Фасети за замовчуванням для вітрини
+var DefaultFacets = []string{"brand", "color", "material", "size"}
+
+// Межі цінових діапазонів за замовчуванням (мінорні одиниці)
+var DefaultPriceBuckets = []int64{0, 5000, 10000, 25000, 50000, 100000}
+
+// Позначки для filterItems: які фільтри не застосовувати
+const (
+	exceptNone  = ""
+	exceptPrice = "#price"
+)
+
+// filterItems будує запит вибірки товарів з усіма фільтрами, крім except.
+// Фасет не обмежується власним фільтром, щоб показувати альтернативні значення.
+func filterItems(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *entities.Parameters, except string) (*gorm.DB, error) {
+	query := db.Model(&models.Items{})
+
+	// Якщо не суперюзер, додаємо фільтр за власником
+	if !isSuperUser {
+		query = query.Where("items.owner_id = ?", userId)
+	}
+
+	// Фільтр за мовою
+	if parameters.Language != "" {
+		query = query.Where("items.language = ?", parameters.Language)
+	}
+
+	// Фільтр за категорією разом з усіма підкатегоріями
+	if parameters.CategoryID != uuid.Nil {
+		categoryIDs, err := categoryRepo.GetSubtreeIDs(db, parameters.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("items.category_id IN (?)", categoryIDs)
+	}
+
+	if parameters.State != "" {
+		query = query.Where("items.state = ?", parameters.State)
+	}
+
+	query = tagRepo.FilterByTags(query, tagModel.EntityItems, "items.id", parameters.Tags, parameters.TagsMatchAny)
+
+	// Діапазон ціни лише в одній валюті; товари без ціни в ній не потрапляють у вибірку
+	if except != exceptPrice && (parameters.MinPrice != nil || parameters.MaxPrice != nil) {
+		price, args := priceExpr(priceCurrency(parameters))
+		query = query.Where(price+" IS NOT NULL", args...)
+		if parameters.MinPrice != nil {
+			query = query.Where(price+" >= ?", append(args, *parameters.MinPrice)...)
+		}
+		if parameters.MaxPrice != nil {
+			query = query.Where(price+" <= ?", append(args, *parameters.MaxPrice)...)
+		}
+	}
+
+	// Фільтр за значеннями динамічних властивостей
+	filters := make(map[string][]string, len(parameters.Attributes))
+	for code, values := range parameters.Attributes {
+		if code != except {
+			filters[code] = values
+		}
+	}
+	if len(filters) > 0 {
+		var err error
+		query, err = propRepo.ApplyAttributeFilters(db, query, "items.translation_group_id", filters)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
+
+// priceCurrency повертає валюту цінового фільтра, за замовчуванням базову валюту магазину
+func priceCurrency(parameters *entities.Parameters) string {
+	if currency := money.NormalizeCurrency(parameters.Currency); currency != "" {
+		return currency
+	}
+	return money.BaseCurrency()
+}
+
+// priceExpr повертає SQL-вираз ціни товару у валюті: з прайс-листа або базову ціну,
+// якщо товар оцінено в цій валюті. Для інших товарів вираз дає NULL.
+func priceExpr(currency string) (string, []interface{}) {
+	expr := "COALESCE((SELECT item_prices.amount FROM item_prices " +
+		"WHERE item_prices.item_id IN (items.id, items.translation_group_id) AND item_prices.currency = ? LIMIT 1), " +
+		"CASE WHEN items.currency = ? THEN items.price END)"
+	return expr, []interface{}{currency, currency}
+}
+
+// GetItemFacets рахує фасети атрибутів і цінові діапазони для поточних фільтрів
+func GetItemFacets(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *entities.Parameters) (*models.ItemFacets, error) {
+	facets := &models.ItemFacets{
+		Attributes: make(map[string][]propModel.FacetValue),
+		Currency:   priceCurrency(parameters),
+	}
+
+	codes := parameters.Facets
+	if codes == nil {
+		codes = DefaultFacets
+	}
+	for _, code := range codes {
+		query, err := filterItems(db, userId, isSuperUser, parameters, code)
+		if err != nil {
+			return nil, err
+		}
+		values, err := propRepo.GetFacetCounts(db, query, "items.translation_group_id", code)
+		if err != nil {
+			return nil, err
+		}
+		if values != nil {
+			facets.Attributes[code] = values
+		}
+	}
+
+	buckets := parameters.PriceBuckets
+	if buckets == nil {
+		buckets = DefaultPriceBuckets
+	}
+	if len(buckets) > 0 {
+		query, err := filterItems(db, userId, isSuperUser, parameters, exceptPrice)
+		if err != nil {
+			return nil, err
+		}
+		facets.Price, err = getPriceBuckets(query, facets.Currency, buckets)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return facets, nil
+}
+
+// getPriceBuckets групує вибірку за діапазонами ціни у валюті одним запитом
+func getPriceBuckets(query *gorm.DB, currency string, bounds []int64) ([]models.PriceBucket, error) {
+	bounds = SortPriceBounds(bounds)
+	price, priceArgs := priceExpr(currency)
+
+	// CASE від найбільшої межі: номер діапазону, до якого потрапляє ціна
+	var cases []string
+	var args []interface{}
+	for i := len(bounds) - 1; i >= 0; i-- {
+		cases = append(cases, fmt.Sprintf("WHEN %s >= ? THEN %d", price, i))
+		args = append(args, priceArgs...)
+		args = append(args, bounds[i])
+	}
+	bucketExpr := fmt.Sprintf("CASE %s ELSE -1 END", strings.Join(cases, " "))
+
+	var rows []struct {
+		Bucket int
+		Count  int64
+	}
+	err := query.
+		Select("("+bucketExpr+") AS bucket, COUNT(*) AS count", args...).
+		Group("bucket").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Bucket] = row.Count
+	}
+	return BuildPriceBuckets(bounds, counts), nil
+}
+
+// SortPriceBounds повертає відсортовану копію меж без повторів
+func SortPriceBounds(bounds []int64) []int64 {
+	sorted := append([]int64(nil), bounds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	result := sorted[:0]
+	for i, bound := range sorted {
+		if i == 0 || bound != sorted[i-1] {
+			result = append(result, bound)
+		}
+	}
+	return result
+}
+
+// BuildPriceBuckets будує діапазони [bounds[i], bounds[i+1]) з кількостями за номером діапазону.
+// Межі мають бути відсортовані; ціни нижче першої межі (номер -1) не враховуються.
+func BuildPriceBuckets(bounds []int64, counts map[int]int64) []models.PriceBucket {
+	result := make([]models.PriceBucket, 0, len(bounds))
+	for i, from := range bounds {
+		bucket := models.PriceBucket{From: from, Count: counts[i]}
+		if i+1 < len(bounds) {
+			to := bounds[i+1]
+			bucket.To = &to
+		}
+		result = append(result, bucket)
+	}
+	return result
+}
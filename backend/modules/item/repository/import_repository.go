@@ -0,0 +1,580 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/repository"
+	"backend/internal/services/money"
+	categoryModel "backend/modules/category/models"
+	"backend/modules/item/models"
+	mediaModel "backend/modules/media/models"
+	"backend/modules/media/service"
+	propModel "backend/modules/property/models"
+	propRepo "backend/modules/property/repository"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Кількість рядків в одній транзакції; після кожної порції оновлюється прогрес
+	importChunkSize = 100
+	// Скільки помилок рядків зберігати у звіті
+	importMaxErrors = 1000
+)
+
+// errDryRun відкочує транзакцію пробного імпорту
+var errDryRun = errors.New("dry run")
+
+func CreateImportJob(db *gorm.DB, job *models.ImportJob) error {
+	job.Status = models.ImportPending
+	return repository.CreateEssence(db, job)
+}
+
+func GetImportJob(db *gorm.DB, id uuid.UUID) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := repository.GetByID(db, id, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// itemImporter тримає стан одного імпорту: відповідність колонок, довідники та звіт
+type itemImporter struct {
+	db          *gorm.DB
+	job         *models.ImportJob
+	isSuperUser bool
+	columns     map[string]int
+	attributes  map[string]int
+	categories  map[string]uuid.UUID
+	errors      []models.ImportRowError
+}
+
+// Дії, які виконуються лише після фіксації порції
+type importImages struct {
+	row        int
+	groupId    uuid.UUID
+	contentIDs []uuid.UUID
+	urls       []string
+}
+
+// RunImportJob виконує імпорт рядків таблиці; викликається у фоновій горутині.
+// Пробний імпорт проходить усі перевірки в одній транзакції, яку потім відкочує.
+func RunImportJob(db *gorm.DB, job *models.ImportJob, rows [][]string, isSuperUser bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			finishImportJob(db, job, nil, fmt.Errorf("import panicked: %v", r))
+		}
+	}()
+
+	importer, err := newItemImporter(db, job, rows, isSuperUser)
+	if err != nil {
+		finishImportJob(db, job, nil, err)
+		return
+	}
+
+	now := time.Now()
+	job.Status = models.ImportRunning
+	job.StartedAt = &now
+	if err = db.Save(job).Error; err != nil {
+		log.Printf("❌ Failed to start import job %s: %v", job.ID, err)
+		return
+	}
+
+	if job.DryRun {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := importer.process(tx, rows); err != nil {
+				return err
+			}
+			return errDryRun
+		})
+		if errors.Is(err, errDryRun) {
+			err = nil
+		}
+	} else {
+		err = importer.process(db, rows)
+	}
+	finishImportJob(db, job, importer.errors, err)
+}
+
+func finishImportJob(db *gorm.DB, job *models.ImportJob, rowErrors []models.ImportRowError, err error) {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = models.ImportCompleted
+	if err != nil {
+		job.Status = models.ImportFailed
+		job.Error = err.Error()
+	}
+	if rowErrors != nil {
+		job.Errors, _ = entities.NewJSON(rowErrors)
+	}
+	if saveErr := db.Save(job).Error; saveErr != nil {
+		log.Printf("❌ Failed to finish import job %s: %v", job.ID, saveErr)
+	}
+}
+
+func newItemImporter(db *gorm.DB, job *models.ImportJob, rows [][]string, isSuperUser bool) (*itemImporter, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+
+	importer := &itemImporter{
+		db:          db,
+		job:         job,
+		isSuperUser: isSuperUser,
+		columns:     make(map[string]int),
+		attributes:  make(map[string]int),
+		categories:  make(map[string]uuid.UUID),
+	}
+
+	schema, err := propRepo.GetAttributes(db, nil)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(schema.Data))
+	for _, attribute := range schema.Data {
+		known[attribute.Code] = true
+	}
+
+	for index, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if code, found := strings.CutPrefix(name, attributeColumnPrefix); found {
+			if !known[code] {
+				return nil, fmt.Errorf("unknown attribute column %q", name)
+			}
+			importer.attributes[code] = index
+			continue
+		}
+		importer.columns[name] = index
+	}
+
+	_, hasId := importer.columns["id"]
+	_, hasSku := importer.columns["sku"]
+	_, hasTitle := importer.columns["title"]
+	if !hasId && !hasSku && !hasTitle {
+		return nil, errors.New("the file must contain an id, sku or title column")
+	}
+
+	var categories []categoryModel.Category
+	if err = db.Select("id", "slug").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		importer.categories[category.Slug] = category.ID
+	}
+	return importer, nil
+}
+
+// process обробляє рядки порціями: кожна порція — окрема транзакція, кожен рядок — точка збереження
+func (imp *itemImporter) process(db *gorm.DB, rows [][]string) error {
+	for start := 1; start < len(rows); start += importChunkSize {
+		end := min(start+importChunkSize, len(rows))
+
+		var images []importImages
+		created, updated := 0, 0
+
+		err := db.Transaction(func(chunkTx *gorm.DB) error {
+			for index := start; index < end; index++ {
+				line := index + 1
+				if isBlankRow(rows[index]) {
+					continue
+				}
+				var rowImages *importImages
+				var rowCreated bool
+				err := chunkTx.Transaction(func(rowTx *gorm.DB) error {
+					var err error
+					rowCreated, rowImages, err = imp.importRow(rowTx, line, rows[index])
+					return err
+				})
+				if err != nil {
+					imp.addError(line, err)
+					continue
+				}
+				if rowCreated {
+					created++
+				} else {
+					updated++
+				}
+				if rowImages != nil {
+					images = append(images, *rowImages)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if !imp.job.DryRun {
+			for _, task := range images {
+				imp.attachImages(&task)
+			}
+		}
+
+		imp.job.Processed = end - 1
+		imp.job.Created += created
+		imp.job.Updated += updated
+		err = imp.db.Model(imp.job).Updates(map[string]interface{}{
+			"processed": imp.job.Processed,
+			"created":   imp.job.Created,
+			"updated":   imp.job.Updated,
+			"failed":    imp.job.Failed,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *itemImporter) addError(line int, err error) {
+	imp.job.Failed++
+	if len(imp.errors) < importMaxErrors {
+		imp.errors = append(imp.errors, models.ImportRowError{Row: line, Error: err.Error()})
+	}
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// value повертає значення колонки; порожня клітинка означає «не змінювати»
+func (imp *itemImporter) value(cells []string, column string) (string, bool) {
+	index, ok := imp.columns[column]
+	if !ok || index >= len(cells) {
+		return "", false
+	}
+	value := strings.TrimSpace(cells[index])
+	return value, value != ""
+}
+
+// importRow створює або оновлює товар за id чи артикулом
+func (imp *itemImporter) importRow(tx *gorm.DB, line int, cells []string) (bool, *importImages, error) {
+	language := imp.job.Language
+	if value, ok := imp.value(cells, "language"); ok {
+		language = value
+	}
+
+	// Шукаємо наявний товар: спершу за id, потім за артикулом у мові рядка
+	var item models.Items
+	found := false
+	if value, ok := imp.value(cells, "id"); ok {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid id %q", value)
+		}
+		locked, err := lockItemGroup(tx, id)
+		if err != nil {
+			return false, nil, fmt.Errorf("product %s: %w", id, err)
+		}
+		item, found = *locked, true
+	} else if sku, ok := imp.value(cells, "sku"); ok {
+		err := tx.Select("id").Where("sku = ? AND language = ?", sku, language).First(&item).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil, err
+		}
+		if err == nil {
+			locked, err := lockItemGroup(tx, item.ID)
+			if err != nil {
+				return false, nil, err
+			}
+			item, found = *locked, true
+		}
+	}
+
+	// Ціна без валюти в рядку рахується у валюті товару
+	currency := money.DefaultCurrency
+	if found {
+		currency = item.Currency
+	}
+	update, err := imp.buildUpdate(cells, currency)
+	if err != nil {
+		return false, nil, err
+	}
+	if _, ok := imp.value(cells, "language"); ok && found {
+		update.Language = &language
+	}
+
+	created := !found
+	if found {
+		if item.OwnerID != imp.job.OwnerID && !imp.isSuperUser {
+			return false, nil, errors.New("access denied")
+		}
+	} else {
+		if err = imp.createItem(tx, &item, language, update); err != nil {
+			return false, nil, err
+		}
+	}
+
+	before := item.Quantity
+	if err = applyItemChanges(tx, &item, imp.job.OwnerID, update); err != nil {
+		return false, nil, err
+	}
+	if !created {
+		if err = notifyIfLowStock(tx, item, before); err != nil {
+			return false, nil, err
+		}
+	}
+
+	groupId := translationGroupID(&item)
+	properties := make(propModel.PropertyValuesPut)
+	for code, index := range imp.attributes {
+		if index < len(cells) {
+			if value := strings.TrimSpace(cells[index]); value != "" {
+				properties[code] = value
+			}
+		}
+	}
+	if len(properties) > 0 {
+		if _, err = propRepo.SetPropertyValues(tx, groupId, item.CategoryID, properties); err != nil {
+			return false, nil, err
+		}
+	}
+
+	// Одна ревізія й одна подія на рядок — після полів і властивостей
+	if created {
+		err = recordItemRevision(tx, &item, imp.job.OwnerID, "created")
+		if err == nil {
+			err = publishItemCreated(tx, &item, imp.job.OwnerID)
+		}
+	} else {
+		err = recordItemRevision(tx, &item, imp.job.OwnerID, update.RevisionComment)
+		if err == nil {
+			err = publishItemUpdated(tx, item.ID, imp.job.OwnerID)
+		}
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	var images *importImages
+	if value, ok := imp.value(cells, "images"); ok {
+		images = &importImages{row: line, groupId: groupId, contentIDs: sharedContentIDs(&item)}
+		for _, url := range strings.Split(value, imageSeparator) {
+			if url = strings.TrimSpace(url); url != "" {
+				images.urls = append(images.urls, url)
+			}
+		}
+	}
+
+	return created, images, nil
+}
+
+// createItem створює новий товар або, якщо артикул уже є в іншій мові, його переклад
+func (imp *itemImporter) createItem(tx *gorm.DB, item *models.Items, language string, update *models.ItemUpdate) error {
+	if update.Title == nil {
+		return errors.New("title is required for a new product")
+	}
+
+	if update.Sku != nil {
+		var source models.Items
+		err := tx.Where("sku = ?", strings.TrimSpace(*update.Sku)).First(&source).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			if source.OwnerID != imp.job.OwnerID && !imp.isSuperUser {
+				return errors.New("access denied")
+			}
+			post := &entities.TranslationPost{Language: language, Title: *update.Title}
+			if update.Content != nil {
+				post.Content = *update.Content
+			}
+			translation, err := CreateItemTranslation(tx, source.ID, post)
+			if err != nil {
+				return err
+			}
+			locked, err := lockItemGroup(tx, translation.ID)
+			if err != nil {
+				return err
+			}
+			*item = *locked
+			return nil
+		}
+	}
+
+	// Новий товар стає останнім у своїй мові; решту полів застосовує applyItemChanges
+	var maxPosition *int
+	err := tx.Model(&models.Items{}).Where("language = ?", language).Select("MAX(position)").Scan(&maxPosition).Error
+	if err != nil {
+		return err
+	}
+	*item = models.Items{
+		Title:    *update.Title,
+		Language: language,
+		Currency: money.DefaultCurrency,
+		OwnerID:  imp.job.OwnerID,
+	}
+	if maxPosition != nil {
+		item.Position = *maxPosition + 1
+	}
+	if update.Currency != nil {
+		item.Currency = *update.Currency
+	}
+	if update.Sku != nil {
+		item.Sku = update.Sku
+	}
+	// Початковий залишок проводиться як надходження
+	if update.Quantity != nil {
+		item.Quantity = *update.Quantity
+		update.Quantity = nil
+	}
+	if _, err = insertItem(tx, item, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// buildUpdate перетворює клітинки рядка на зміни товару
+func (imp *itemImporter) buildUpdate(cells []string, currency string) (*models.ItemUpdate, error) {
+	update := &models.ItemUpdate{}
+	var problems []string
+
+	if value, ok := imp.value(cells, "title"); ok {
+		update.Title = &value
+	}
+	if value, ok := imp.value(cells, "content"); ok {
+		update.Content = &value
+	}
+	if value, ok := imp.value(cells, "sku"); ok {
+		update.Sku = &value
+	}
+	if value, ok := imp.value(cells, "item_url"); ok {
+		update.ItemUrl = &value
+	}
+
+	if value, ok := imp.value(cells, "currency"); ok {
+		currency = money.NormalizeCurrency(value)
+		update.Currency = &currency
+	}
+	if value, ok := imp.value(cells, "price"); ok {
+		price, err := money.Parse(value, currency)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("price: %v", err))
+		} else {
+			update.Price = &price.Amount
+		}
+	}
+
+	for _, column := range []string{"quantity", "low_stock_threshold"} {
+		value, ok := imp.value(cells, column)
+		if !ok {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid number %q", column, value))
+			continue
+		}
+		if column == "quantity" {
+			update.Quantity = &number
+			reason := "import"
+			update.StockReason = &reason
+		} else {
+			update.LowStockThreshold = &number
+		}
+	}
+
+	if value, ok := imp.value(cells, "state"); ok {
+		state := entities.PublicationState(strings.ToLower(value))
+		if !state.Valid() {
+			problems = append(problems, fmt.Sprintf("state: unknown state %q", value))
+		} else {
+			update.State = &state
+		}
+	}
+	for _, column := range []string{"publish_at", "unpublish_at"} {
+		value, ok := imp.value(cells, column)
+		if !ok {
+			continue
+		}
+		moment, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: expected RFC 3339 time, got %q", column, value))
+			continue
+		}
+		if column == "publish_at" {
+			update.PublishAt = &moment
+		} else {
+			update.UnpublishAt = &moment
+		}
+	}
+
+	// Категорію можна вказати slug-ом або ідентифікатором
+	if value, ok := imp.value(cells, "category"); ok {
+		if id, found := imp.categories[value]; found {
+			update.CategoryID = &id
+		} else if id, err := uuid.Parse(value); err == nil {
+			update.CategoryID = &id
+		} else {
+			problems = append(problems, fmt.Sprintf("category: unknown category %q", value))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return update, nil
+}
+
+// attachImages додає зображення з файлу; вже прив'язані URL пропускаються
+func (imp *itemImporter) attachImages(task *importImages) {
+	var existing []string
+	err := imp.db.Model(&mediaModel.Media{}).Where("content_id IN (?)", task.contentIDs).Pluck("url", &existing).Error
+	if err != nil {
+		imp.addImageError(task.row, err)
+		return
+	}
+	known := make(map[string]bool, len(existing))
+	for _, url := range existing {
+		known[url] = true
+	}
+
+	for _, url := range task.urls {
+		if known[url] {
+			continue
+		}
+		media := mediaModel.Media{ContentId: task.groupId, Url: url, Type: mime.TypeByExtension(path.Ext(url))}
+
+		if imp.job.DownloadImages {
+			data, contentType, fileName, err := service.FetchRemoteImage(url)
+			if err != nil {
+				imp.addImageError(task.row, err)
+				continue
+			}
+			media.Url, err = service.UploadBytes(fileName, data)
+			if err != nil {
+				imp.addImageError(task.row, err)
+				continue
+			}
+			media.Type = contentType
+		}
+
+		if err = imp.db.Create(&media).Error; err != nil {
+			imp.addImageError(task.row, err)
+			continue
+		}
+		known[url] = true
+	}
+}
+
+// Помилки зображень не скасовують рядок, лише потрапляють у звіт
+func (imp *itemImporter) addImageError(line int, err error) {
+	if len(imp.errors) < importMaxErrors {
+		imp.errors = append(imp.errors, models.ImportRowError{Row: line, Error: "images: " + err.Error()})
+	}
+}
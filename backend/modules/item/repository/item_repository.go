@@ -3,54 +3,137 @@ package repository
 import (
 	"backend/internal/entities"
 	"backend/internal/repository"
+	"backend/internal/services/events"
+	"backend/internal/services/money"
+	"backend/internal/services/revision"
+	categoryModel "backend/modules/category/models"
 	"backend/modules/item/models"
 	mediaModel "backend/modules/media/models"
-	"backend/modules/media/service"
-	propModel "backend/modules/property/models"
 	propRepo "backend/modules/property/repository"
+	tagModel "backend/modules/tag/models"
+	tagRepo "backend/modules/tag/repository"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strings"
+	"time"
 )
 
 func CreateItem(db *gorm.DB, i *models.Items) (*models.ItemsPost, error) {
+	return insertItem(db, i, true)
+}
+
+// insertItem створює товар; без complete ревізію й подію item.created записує викликач, коли товар уже заповнений повністю
+func insertItem(db *gorm.DB, i *models.Items, complete bool) (*models.ItemsPost, error) {
 	if i.Title == "" {
 		return nil, errors.New("the product title cannot be empty")
 	}
-	err := repository.GetPosition(db, i.Position, &models.Items{})
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	if i.Price < 0 {
+		return nil, errors.New("the product price cannot be negative")
+	}
+	if i.VatRate != nil && !validVatRate(*i.VatRate) {
+		return nil, ErrInvalidVatRate
+	}
+	if i.Currency == "" {
+		i.Currency = money.DefaultCurrency
+	}
+	i.Currency = money.NormalizeCurrency(i.Currency)
+	if !money.IsSupported(i.Currency) {
+		return nil, fmt.Errorf("unsupported currency %q", i.Currency)
+	}
+	if i.CategoryID != nil {
+		if err := checkCategoryExists(db, *i.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if err := i.Publication.Init(time.Now()); err != nil {
+		return nil, err
+	}
+	i.Sku = normalizeSku(i.Sku)
+	if err := checkSkuAvailable(db, i.Sku, i.TranslationGroupID); err != nil {
 		return nil, err
 	}
+	if err := i.SEO.Validate(); err != nil {
+		return nil, err
+	}
+	requestedSlug := i.Slug
+	i.Slug = nil
+
+	// Початковий залишок проводимо через журнал як надходження
+	quantity := i.Quantity
+	i.Quantity = 0
 
-	// Якщо позиція існує, зсуваємо всі наступні
-	if err == nil {
-		if shiftErr := repository.ShiftPositions[models.Items](db, i.Position, i.Language); shiftErr != nil {
-			return nil, shiftErr
+	// Позиції, slug, запис, ревізія, початковий залишок і подія зберігаються разом
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := assignItemSlug(tx, i, requestedSlug, i.Language); err != nil {
+			return err
+		}
+		err := repository.GetPosition(tx, i.Position, &models.Items{})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
 		}
-	}
 
-	err = repository.CreateEssence(db, i)
+		// Якщо позиція існує, зсуваємо всі наступні
+		if err == nil {
+			if shiftErr := repository.ShiftPositions[models.Items](tx, i.Position, i.Language); shiftErr != nil {
+				return shiftErr
+			}
+		}
+
+		if err := repository.CreateEssence(tx, i); err != nil {
+			return err
+		}
+		if complete {
+			if err := recordItemRevision(tx, i, i.OwnerID, "created"); err != nil {
+				return err
+			}
+		}
+		if quantity != 0 {
+			_, err = applyStockMovement(tx, i, i.OwnerID, &models.StockMovementPost{
+				Type:     models.MovementReceipt,
+				Quantity: quantity,
+				Reason:   "initial stock",
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if !complete {
+			return nil
+		}
+		return publishItemCreated(tx, i, i.OwnerID)
+	})
 	if err != nil {
 		return nil, err
 	}
+	return toItemsPost(i), nil
+}
+
+func toItemsPost(i *models.Items) *models.ItemsPost {
 	return &models.ItemsPost{
-		ID:       i.ID,
-		Title:    i.Title,
-		Content:  i.Content,
-		Price:    i.Price,
-		Position: i.Position,
-		Quantity: i.Quantity,
-		Language: i.Language,
-		ItemUrl:  i.ItemUrl,
-		Category: i.Category,
-		Status:   i.Status,
-		OwnerID:  i.OwnerID,
-	}, nil
+		ID:                i.ID,
+		Title:             i.Title,
+		Content:           i.Content,
+		Price:             i.Price,
+		Currency:          i.Currency,
+		Position:          i.Position,
+		Quantity:          i.Quantity,
+		Language:          i.Language,
+		Sku:               i.Sku,
+		Slug:              i.Slug,
+		ItemUrl:           i.ItemUrl,
+		CategoryID:        i.CategoryID,
+		SEO:               i.SEO,
+		Publication:       i.Publication,
+		LowStockThreshold: i.LowStockThreshold,
+		VatRate:           i.VatRate,
+		OwnerID:           i.OwnerID,
+	}
 }
 
 func GetItemById(db *gorm.DB, itemId uuid.UUID) (*models.ItemGet, error) {
 	var item models.Items
-	var property propModel.Property
 	var media []*mediaModel.Media
 
 	// Get product
@@ -59,63 +142,101 @@ func GetItemById(db *gorm.DB, itemId uuid.UUID) (*models.ItemGet, error) {
 		return nil, err
 	}
 
-	// Get property by product ID
-	err = repository.GetAllContentByID(db, itemId, &property)
+	// Властивості, медіа та ціни спільні для всієї групи перекладів
+	groupId := translationGroupID(&item)
+
+	// Get properties by translation group
+	propertiesMap, err := propRepo.GetPropertyValues(db, []uuid.UUID{groupId})
 	if err != nil {
 		return nil, err
 	}
 
-	//Get Media by product ID
-	err = repository.GetAllMediaByID(db, itemId, &media)
+	//Get Media by product ID and translation group
+	err = db.Where("content_id IN (?)", sharedContentIDs(&item)).Find(&media).Error
 	if err != nil {
 		return nil, err
 	}
-	mediaMap := make(map[uuid.UUID][]string)
+	var images []string
 	for _, m := range media {
-		mediaMap[m.ContentId] = append(mediaMap[m.ContentId], m.Url)
+		images = append(images, m.Url)
+	}
+
+	pricesMap, err := GetPricesByItemIds(db, []uuid.UUID{groupId})
+	if err != nil {
+		return nil, err
+	}
+
+	tagsMap, err := tagRepo.GetEntityTags(db, tagModel.EntityItems, []uuid.UUID{item.ID})
+	if err != nil {
+		return nil, err
 	}
 	return &models.ItemGet{
-		ID:       item.ID,
-		Title:    item.Title,
-		Content:  item.Content,
-		Price:    item.Price,
-		Quantity: item.Quantity,
-		Position: item.Position,
-		Language: item.Language,
-		ItemUrl:  item.ItemUrl,
-		Category: item.Category,
-		Status:   item.Status,
-		Property: propModel.PropertyGet{
-			ID:        property.ID,
-			Height:    property.Height,
-			Weight:    property.Weight,
-			Width:     property.Width,
-			Color:     property.Color,
-			Material:  property.Material,
-			Brand:     property.Brand,
-			Size:      property.Size,
-			Motif:     property.Motif,
-			Style:     property.Style,
-			ContentID: property.ContentId,
-		},
-		OwnerID: item.OwnerID,
-		Images:  mediaMap[item.ID],
+		ID:                 item.ID,
+		Title:              item.Title,
+		Content:            item.Content,
+		Price:              item.Price,
+		Currency:           item.Currency,
+		Prices:             pricesMap[groupId],
+		Quantity:           item.Quantity,
+		Position:           item.Position,
+		Language:           item.Language,
+		Sku:                item.Sku,
+		Slug:               item.Slug,
+		ItemUrl:            item.ItemUrl,
+		CategoryID:         item.CategoryID,
+		SEO:                item.SEO,
+		Publication:        item.Publication,
+		LowStockThreshold:  item.LowStockThreshold,
+		VatRate:            item.VatRate,
+		Properties:         propertiesMap[groupId],
+		OwnerID:            item.OwnerID,
+		TranslationGroupID: groupId,
+		Images:             images,
+		Tags:               tagsMap[item.ID],
+		UpdatedAt:          item.UpdatedAt,
 	}, nil
 
 }
 
-func UpdateItemById(db *gorm.DB, itemId uuid.UUID, updateItem *models.ItemUpdate) (*models.ItemGet, error) {
-	var item *models.Items
+func UpdateItemById(db *gorm.DB, itemId uuid.UUID, userId uuid.UUID, updateItem *models.ItemUpdate) (*models.ItemGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		item, err := lockItemGroup(tx, itemId)
+		if err != nil {
+			return err
+		}
+		before := item.Quantity
 
-	err := repository.GetByID(db, itemId, &item)
+		if err = applyItemUpdate(tx, item, userId, updateItem); err != nil {
+			return err
+		}
+		if err = notifyIfLowStock(tx, *item, before); err != nil {
+			return err
+		}
+		return publishItemUpdated(tx, itemId, userId)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return GetItemById(db, itemId)
+}
+
+// applyItemUpdate застосовує зміни до заблокованого товару в межах транзакції і записує ревізію
+func applyItemUpdate(tx *gorm.DB, item *models.Items, userId uuid.UUID, updateItem *models.ItemUpdate) error {
+	if err := applyItemChanges(tx, item, userId, updateItem); err != nil {
+		return err
+	}
+	return recordItemRevision(tx, item, userId, updateItem.RevisionComment)
+}
+
+// applyItemChanges — applyItemUpdate без ревізії, для викликачів, що записують одну ревізію на кілька змін
+func applyItemChanges(tx *gorm.DB, item *models.Items, userId uuid.UUID, updateItem *models.ItemUpdate) error {
+	var err error
+	previousLanguage := item.Language
 	if updateItem.Position != nil && *updateItem.Position != item.Position {
-		err = repository.ShiftPositions[models.Items](db, *updateItem.Position, item.Language)
+		err = repository.ShiftPositions[models.Items](tx, *updateItem.Position, item.Language)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		item.Position = *updateItem.Position
 	}
@@ -127,65 +248,132 @@ func UpdateItemById(db *gorm.DB, itemId uuid.UUID, updateItem *models.ItemUpdate
 		item.Content = *updateItem.Content
 	}
 	if updateItem.Price != nil {
+		if *updateItem.Price < 0 {
+			return errors.New("the product price cannot be negative")
+		}
 		item.Price = *updateItem.Price
 	}
-	if updateItem.Quantity != nil {
-		item.Quantity = *updateItem.Quantity
+	if updateItem.Currency != nil {
+		currency := money.NormalizeCurrency(*updateItem.Currency)
+		if !money.IsSupported(currency) {
+			return fmt.Errorf("unsupported currency %q", currency)
+		}
+		item.Currency = currency
 	}
 	if updateItem.ItemUrl != nil {
 		item.ItemUrl = *updateItem.ItemUrl
 	}
-	if updateItem.Category != nil {
-		item.Category = *updateItem.Category
+	if updateItem.CategoryID != nil {
+		if err := checkCategoryExists(tx, *updateItem.CategoryID); err != nil {
+			return err
+		}
+		item.CategoryID = updateItem.CategoryID
 	}
-	if updateItem.Language != nil {
+	if updateItem.Language != nil && *updateItem.Language != item.Language {
+		err = repository.CheckTranslationAvailable[models.Items](tx, translationGroupID(item), *updateItem.Language, item.ID)
+		if err != nil {
+			return err
+		}
 		item.Language = *updateItem.Language
 	}
-	if updateItem.Status != nil {
-		item.Status = *updateItem.Status
-	}
-
-	err = db.Save(&item).Error
-	if err != nil {
-		return nil, err
+	if updateItem.Sku != nil {
+		item.Sku = normalizeSku(updateItem.Sku)
+		if err := checkSkuAvailable(tx, item.Sku, translationGroupID(item)); err != nil {
+			return err
+		}
 	}
-
-	return GetItemById(db, itemId)
-}
-
-func DeleteItemById(db *gorm.DB, id uuid.UUID) error {
-	var item models.Items
-	var property propModel.Property
-	var mediaList []mediaModel.Media
-
-	err := repository.DeleteByID(db, id, &item)
-	if err != nil {
+	if err = assignItemSlug(tx, item, updateItem.Slug, previousLanguage); err != nil {
 		return err
 	}
-
-	// Delete property by content_id
-	err = repository.DeleteContentByID(db, id, &property)
-	if err != nil {
+	if err = item.SEO.Apply(&updateItem.SEOUpdate); err != nil {
 		return err
 	}
-
-	err = repository.GetAllMediaByID(db, id, &mediaList)
-	if err != nil {
+	if err = item.Publication.Apply(&updateItem.PublicationUpdate, time.Now()); err != nil {
 		return err
 	}
-	for _, media := range mediaList {
-		err = service.DeleteImageInBucket(media.Url)
+	if updateItem.LowStockThreshold != nil {
+		item.LowStockThreshold = *updateItem.LowStockThreshold
+	}
+	if updateItem.VatRate != nil {
+		switch rate := *updateItem.VatRate; {
+		case rate == -1:
+			item.VatRate = nil
+		case validVatRate(rate):
+			item.VatRate = &rate
+		default:
+			return ErrInvalidVatRate
+		}
+	}
+
+	// Зміна кількості напряму записується в журнал як коригування
+	if updateItem.Quantity != nil && *updateItem.Quantity != item.Quantity {
+		reason := "manual update"
+		if updateItem.StockReason != nil && *updateItem.StockReason != "" {
+			reason = *updateItem.StockReason
+		}
+		_, err = applyStockMovement(tx, item, userId, &models.StockMovementPost{
+			Type:     models.MovementAdjustment,
+			Quantity: *updateItem.Quantity - item.Quantity,
+			Reason:   reason,
+		})
 		if err != nil {
 			return err
 		}
 	}
-	// Delete media by content_id
-	err = repository.DeleteContentByID(db, id, &mediaModel.Media{})
-	if err != nil {
+
+	if err = tx.Save(item).Error; err != nil {
 		return err
 	}
+	return syncSharedFields(tx, item)
+}
 
-	return nil
+// DeleteItemById видаляє мовну версію товару. Медіа, властивості та інші дані сторонніх модулів
+// прибирають підписники події content.deleted у тій самій транзакції
+func DeleteItemById(db *gorm.DB, id uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var item models.Items
+
+		err := repository.GetByID(tx, id, &item)
+		if err != nil {
+			return err
+		}
+		groupId := translationGroupID(&item)
+
+		siblings, err := repository.CountTranslations[models.Items](tx, groupId, id)
+		if err != nil {
+			return err
+		}
+
+		err = repository.DeleteByID(tx, id, &item)
+		if err != nil {
+			return err
+		}
+		if err = revision.DeleteAll(tx, revision.EntityItem, id); err != nil {
+			return err
+		}
+		if err = repository.DeleteSlugRedirects[models.Items](tx, id); err != nil {
+			return err
+		}
+		if err = tagRepo.DeleteEntityTags(tx, tagModel.EntityItems, id); err != nil {
+			return err
+		}
+
+		// Ціни належать групі й видаляються лише разом з останнім перекладом.
+		// Журнал руху складу лишається: це облікова історія, і після видалення товару
+		if siblings == 0 {
+			err = tx.Where("item_id = ?", groupId).Delete(&models.ItemPrice{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return events.Publish(tx, events.ContentDeleted{
+			Entity:             events.EntityItems,
+			ID:                 id,
+			TranslationGroupID: groupId,
+			LastTranslation:    siblings == 0,
+		})
+	})
 }
 
 func GetAllItems(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *entities.Parameters) (*models.ItemGetAll, error) {
@@ -209,36 +397,43 @@ func GetAllItems(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *en
 
 	response := &models.ItemGetAll{}
 
-	// Формуємо базовий запит
-	query := db
+	// Формуємо базовий запит з усіма фільтрами
+	query, err := filterItems(db, userId, isSuperUser, parameters, exceptNone)
+	if err != nil {
+		return nil, err
+	}
 
-	// Якщо не суперюзер, додаємо фільтр за власником
-	if !isSuperUser {
-		query = query.Where("owner_id = ?", userId)
+	if err = query.Session(&gorm.Session{}).Count(&response.Total).Error; err != nil {
+		return nil, err
 	}
 
-	// Фільтр за мовою
-	if parameters.Language != "" {
-		query = query.Where("language = ?", parameters.Language)
+	// Кількість використань тегів рахуємо по всій вибірці, а не лише по сторінці
+	response.Tags, err = tagRepo.CountTags(db, tagModel.EntityItems, query.Session(&gorm.Session{}).Select("items.id"))
+	if err != nil {
+		return nil, err
 	}
 
 	// Пагінація
-	query = query.Order("position ASC").Offset(parameters.Skip).Limit(parameters.Limit)
+	query = query.Order("items.position ASC").Offset(parameters.Skip).Limit(parameters.Limit)
 
 	// Виконання запиту
-	err := query.Find(&items).Error
+	err = query.Find(&items).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Отримуємо медіа
+	// Отримуємо медіа: власні та спільні для групи перекладів
+	var contentIDs []uuid.UUID
+	var groupIDs []uuid.UUID
 	var itemIDs []uuid.UUID
 	for _, item := range items {
+		contentIDs = append(contentIDs, sharedContentIDs(item)...)
+		groupIDs = append(groupIDs, translationGroupID(item))
 		itemIDs = append(itemIDs, item.ID)
 	}
 
-	if len(itemIDs) > 0 {
-		err = db.Where("content_id IN (?)", itemIDs).Find(&media).Error
+	if len(contentIDs) > 0 {
+		err = db.Where("content_id IN (?)", contentIDs).Find(&media).Error
 		if err != nil {
 			return nil, err
 		}
@@ -250,37 +445,114 @@ func GetAllItems(db *gorm.DB, userId uuid.UUID, isSuperUser bool, parameters *en
 		mediaMap[m.ContentId] = append(mediaMap[m.ContentId], m.Url)
 	}
 
-	// Отримуємо властивості
-	propertyMap := make(map[uuid.UUID]propModel.PropertyGet)
-	for _, item := range items {
-		property, err := propRepo.GetPropertyByItemId(db, item.ID)
-		if err != nil {
-			return nil, err
-		}
-		if property != nil {
-			propertyMap[item.ID] = *property
-		}
+	pricesMap, err := GetPricesByItemIds(db, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Отримуємо властивості одним запитом
+	propertyMap, err := propRepo.GetPropertyValues(db, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tagsMap, err := tagRepo.GetEntityTags(db, tagModel.EntityItems, itemIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	// Формуємо відповідь
 	for _, item := range items {
+		var images []string
+		for _, contentId := range sharedContentIDs(item) {
+			images = append(images, mediaMap[contentId]...)
+		}
+		groupId := translationGroupID(item)
+
 		response.Data = append(response.Data, &models.ItemGet{
-			ID:       item.ID,
-			Title:    item.Title,
-			Content:  item.Content,
-			Price:    item.Price,
-			Quantity: item.Quantity,
-			Position: item.Position,
-			Language: item.Language,
-			ItemUrl:  item.ItemUrl,
-			Category: item.Category,
-			Status:   item.Status,
-			Property: propertyMap[item.ID],
-			OwnerID:  item.OwnerID,
-			Images:   mediaMap[item.ID],
+			ID:                 item.ID,
+			Title:              item.Title,
+			Content:            item.Content,
+			Price:              item.Price,
+			Currency:           item.Currency,
+			Prices:             pricesMap[groupId],
+			Quantity:           item.Quantity,
+			Position:           item.Position,
+			Language:           item.Language,
+			Sku:                item.Sku,
+			Slug:               item.Slug,
+			ItemUrl:            item.ItemUrl,
+			CategoryID:         item.CategoryID,
+			SEO:                item.SEO,
+			Publication:        item.Publication,
+			LowStockThreshold:  item.LowStockThreshold,
+			VatRate:            item.VatRate,
+			Properties:         propertyMap[groupId],
+			OwnerID:            item.OwnerID,
+			TranslationGroupID: groupId,
+			Images:             images,
+			Tags:               tagsMap[item.ID],
+			UpdatedAt:          item.UpdatedAt,
 		})
 	}
 
 	response.Count = len(items)
+
+	if parameters.WithFacets {
+		response.Facets, err = GetItemFacets(db, userId, isSuperUser, parameters)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return response, nil
 }
+
+func checkCategoryExists(db *gorm.DB, categoryId uuid.UUID) error {
+	err := repository.GetByID(db, categoryId, &categoryModel.Category{})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("category not found")
+	}
+	return err
+}
+
+var ErrInvalidVatRate = errors.New("the VAT rate must be between 0 and 100 percent")
+
+// validVatRate — ставка ПДВ у відсотках; відсутня ставка означає ставку компанії за замовчуванням
+func validVatRate(rate int) bool {
+	return rate >= 0 && rate <= 100
+}
+
+// ErrSkuTaken — артикул уже використовує інший товар
+var ErrSkuTaken = errors.New("the SKU is already used by another product")
+
+// normalizeSku прибирає пробіли; порожній артикул зберігається як NULL
+func normalizeSku(sku *string) *string {
+	if sku == nil {
+		return nil
+	}
+	value := strings.TrimSpace(*sku)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// checkSkuAvailable перевіряє, що артикул не зайнятий товаром з іншої групи перекладів.
+// Переклади одного товару мають спільний артикул.
+func checkSkuAvailable(db *gorm.DB, sku *string, groupId uuid.UUID) error {
+	if sku == nil {
+		return nil
+	}
+	query := db.Model(&models.Items{}).Where("sku = ?", *sku)
+	if groupId != uuid.Nil {
+		query = query.Where("translation_group_id <> ?", groupId)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrSkuTaken
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"backend/internal/services/money"
+	"backend/modules/item/models"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SetItemPrices повністю замінює прайс-лист товару
+func SetItemPrices(db *gorm.DB, itemId uuid.UUID, prices []money.Money) ([]money.Money, error) {
+	seen := make(map[string]bool)
+	for i := range prices {
+		prices[i].Currency = money.NormalizeCurrency(prices[i].Currency)
+		if err := prices[i].Validate(); err != nil {
+			return nil, err
+		}
+		if prices[i].Amount < 0 {
+			return nil, fmt.Errorf("price in %s cannot be negative", prices[i].Currency)
+		}
+		if seen[prices[i].Currency] {
+			return nil, fmt.Errorf("duplicate price for %s", prices[i].Currency)
+		}
+		seen[prices[i].Currency] = true
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item_id = ?", itemId).Delete(&models.ItemPrice{}).Error; err != nil {
+			return err
+		}
+		for _, price := range prices {
+			err := tx.Create(&models.ItemPrice{
+				ItemID:   itemId,
+				Currency: price.Currency,
+				Amount:   price.Amount,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// GetPricesByItemIds повертає прайс-листи для кількох товарів одним запитом
+func GetPricesByItemIds(db *gorm.DB, itemIds []uuid.UUID) (map[uuid.UUID][]money.Money, error) {
+	pricesMap := make(map[uuid.UUID][]money.Money)
+	if len(itemIds) == 0 {
+		return pricesMap, nil
+	}
+
+	var prices []models.ItemPrice
+	err := db.Where("item_id IN (?)", itemIds).Order("currency ASC").Find(&prices).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range prices {
+		pricesMap[p.ItemID] = append(pricesMap[p.ItemID], money.New(p.Amount, p.Currency))
+	}
+	return pricesMap, nil
+}
+
+// ResolveItemPrice повертає ціну у вказаній валюті: з прайс-листа або конвертацією базової ціни
+func ResolveItemPrice(item *models.ItemGet, currency string, rates money.RateSource) (money.Money, error) {
+	currency = money.NormalizeCurrency(currency)
+	base := money.New(item.Price, item.Currency)
+	if currency == "" || currency == base.Currency {
+		return base, nil
+	}
+
+	for _, price := range item.Prices {
+		if price.Currency == currency {
+			return price, nil
+		}
+	}
+	return money.Convert(base, currency, rates)
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/revision"
+	"backend/modules/item/models"
+	propModel "backend/modules/property/models"
+	propRepo "backend/modules/property/repository"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// itemSnapshot збирає поля товару та властивості групи для ревізії
+func itemSnapshot(tx *gorm.DB, item *models.Items) (*models.ItemSnapshot, error) {
+	groupId := translationGroupID(item)
+	propertyMap, err := propRepo.GetPropertyValues(tx, []uuid.UUID{groupId})
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]interface{}, len(propertyMap[groupId]))
+	for _, property := range propertyMap[groupId] {
+		properties[property.Code] = property.Value
+	}
+
+	return &models.ItemSnapshot{
+		Title:             item.Title,
+		Content:           item.Content,
+		Sku:               item.Sku,
+		Price:             item.Price,
+		Currency:          item.Currency,
+		ItemUrl:           item.ItemUrl,
+		CategoryID:        item.CategoryID,
+		LowStockThreshold: item.LowStockThreshold,
+		VatRate:           item.VatRate,
+		SEO:               item.SEO,
+		Publication:       item.Publication,
+		Properties:        properties,
+	}, nil
+}
+
+func recordItemRevision(tx *gorm.DB, item *models.Items, authorId uuid.UUID, comment string) error {
+	snapshot, err := itemSnapshot(tx, item)
+	if err != nil {
+		return err
+	}
+	_, err = revision.Record(tx, revision.EntityItem, item.ID, authorId, snapshot, comment)
+	return err
+}
+
+func GetItemRevisions(db *gorm.DB, itemId uuid.UUID) ([]entities.RevisionGet, error) {
+	return revision.List(db, revision.EntityItem, itemId)
+}
+
+func GetItemRevision(db *gorm.DB, itemId uuid.UUID, number int) (*entities.Revision, error) {
+	return revision.Get(db, revision.EntityItem, itemId, number)
+}
+
+func CompareItemRevisions(db *gorm.DB, itemId uuid.UUID, from int, to int) (*entities.RevisionDiff, error) {
+	return revision.Compare(db, revision.EntityItem, itemId, from, to)
+}
+
+// RestoreItemRevision повертає поля та властивості товару до стану ревізії.
+// Стан публікації не відновлюється, щоб не обходити правила переходів.
+func RestoreItemRevision(db *gorm.DB, itemId uuid.UUID, number int, userId uuid.UUID) (*models.ItemGet, error) {
+	var snapshot models.ItemSnapshot
+	if _, err := revision.Restore(db, revision.EntityItem, itemId, number, &snapshot); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var item models.Items
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", itemId).First(&item).Error
+		if err != nil {
+			return err
+		}
+		groupId := translationGroupID(&item)
+
+		// Властивості, яких не було в ревізії, видаляємо
+		values := make(propModel.PropertyValuesPut, len(snapshot.Properties))
+		current, err := propRepo.GetPropertyValues(tx, []uuid.UUID{groupId})
+		if err != nil {
+			return err
+		}
+		for _, property := range current[groupId] {
+			values[property.Code] = nil
+		}
+		for code, value := range snapshot.Properties {
+			values[code] = value
+		}
+		if len(values) > 0 {
+			if _, err = propRepo.SetPropertyValues(tx, groupId, snapshot.CategoryID, values); err != nil {
+				return err
+			}
+		}
+
+		item.CategoryID = nil
+		sku := ""
+		if snapshot.Sku != nil {
+			sku = *snapshot.Sku
+		}
+		vatRate := -1
+		if snapshot.VatRate != nil {
+			vatRate = *snapshot.VatRate
+		}
+		return applyItemUpdate(tx, &item, userId, &models.ItemUpdate{
+			Title:             &snapshot.Title,
+			Content:           &snapshot.Content,
+			Sku:               &sku,
+			Price:             &snapshot.Price,
+			Currency:          &snapshot.Currency,
+			ItemUrl:           &snapshot.ItemUrl,
+			CategoryID:        snapshot.CategoryID,
+			LowStockThreshold: &snapshot.LowStockThreshold,
+			VatRate:           &vatRate,
+			RevisionComment:   fmt.Sprintf("restored from revision %d", number),
+			SEOUpdate: entities.SEOUpdate{
+				MetaTitle:       &snapshot.MetaTitle,
+				MetaDescription: &snapshot.MetaDescription,
+				OGImage:         &snapshot.OGImage,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetItemById(db, itemId)
+}
+
+// SetItemProperties змінює властивості групи перекладів і записує ревізію товару
+func SetItemProperties(db *gorm.DB, itemId uuid.UUID, userId uuid.UUID, values propModel.PropertyValuesPut) ([]propModel.PropertyGet, error) {
+	var properties []propModel.PropertyGet
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var item models.Items
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", itemId).First(&item).Error
+		if err != nil {
+			return err
+		}
+
+		// Властивості спільні для всіх перекладів товару, схема береться з його категорії
+		properties, err = propRepo.SetPropertyValues(tx, translationGroupID(&item), item.CategoryID, values)
+		if err != nil {
+			return err
+		}
+		return recordItemRevision(tx, &item, userId, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"backend/internal/repository"
+	"backend/internal/services/utils"
+	"backend/modules/item/models"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// assignItemSlug задає slug товару в його мові. Явний slug має бути вільним;
+// порожній генерується з заголовка; без запиту наявний slug зберігається і лише перевіряється
+// на унікальність (наприклад, після зміни мови). Попередня адреса стає перенаправленням.
+func assignItemSlug(tx *gorm.DB, item *models.Items, requested *string, previousLanguage string) error {
+	previous := ""
+	if item.Slug != nil {
+		previous = *item.Slug
+	}
+
+	var slug string
+	var err error
+	switch {
+	case requested != nil && strings.TrimSpace(*requested) != "":
+		slug, err = repository.CheckSlugAvailable[models.Items](tx, utils.Slugify(*requested), item.Language, item.ID)
+	case requested == nil && previous != "":
+		slug, err = repository.UniqueSlug[models.Items](tx, previous, item.Language, item.ID)
+	default:
+		slug, err = repository.UniqueSlug[models.Items](tx, utils.Slugify(item.Title), item.Language, item.ID)
+	}
+	if err != nil {
+		return err
+	}
+	item.Slug = &slug
+
+	if previous != "" && (previous != slug || previousLanguage != item.Language) {
+		return repository.SaveSlugRedirect[models.Items](tx, item.ID, previousLanguage, previous)
+	}
+	return nil
+}
+
+// GetItemBySlug повертає товар за slug; redirected означає, що slug застарів і треба перейти на item.Slug
+func GetItemBySlug(db *gorm.DB, language string, slug string) (item *models.ItemGet, redirected bool, err error) {
+	var found models.Items
+	redirected, err = repository.FindBySlug(db, language, slug, &found)
+	if err != nil {
+		return nil, false, err
+	}
+	item, err = GetItemById(db, found.ID)
+	return item, redirected, err
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"backend/internal/services/events"
+	"backend/modules/item/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"slices"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// RecordStockMovement записує рух по складу та синхронізує Items.Quantity в одній транзакції
+func RecordStockMovement(db *gorm.DB, itemId uuid.UUID, userId uuid.UUID, movement *models.StockMovementPost) (*models.StockMovement, error) {
+	var record *models.StockMovement
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Блокуємо всі переклади товару, щоб паралельні рухи не перезаписали кількість
+		item, err := lockItemGroup(tx, itemId)
+		if err != nil {
+			return err
+		}
+		before := item.Quantity
+
+		record, err = applyStockMovement(tx, item, userId, movement)
+		if err != nil {
+			return err
+		}
+		return notifyIfLowStock(tx, *item, before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// StockGroups повертає групу перекладів кожного з товарів; видалених товарів у результаті немає.
+// Залишок і блокування спільні для групи, тож за нею впорядковуються блокування кількох товарів
+func StockGroups(db *gorm.DB, itemIds []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var items []models.Items
+	if err := db.Select("id, translation_group_id").Where("id IN ?", itemIds).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	groups := make(map[uuid.UUID]uuid.UUID, len(items))
+	for i := range items {
+		groups[items[i].ID] = translationGroupID(&items[i])
+	}
+	return groups, nil
+}
+
+// lockItemGroup блокує FOR UPDATE усі переклади товару в порядку ID і повертає товар, прочитаний
+// під блокуванням. Залишок спільний для групи, тож рух по будь-якому перекладу чекає на інші;
+// єдиний порядок блокування не дає транзакціям чекати одна на одну навхрест
+func lockItemGroup(tx *gorm.DB, itemId uuid.UUID) (*models.Items, error) {
+	var item models.Items
+	if err := tx.Select("id, translation_group_id").Where("id = ?", itemId).First(&item).Error; err != nil {
+		return nil, err
+	}
+
+	for {
+		groupId := translationGroupID(&item)
+		var group []models.Items
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("translation_group_id = ? OR id = ?", groupId, groupId).
+			Order("id").
+			Find(&group).Error
+		if err != nil {
+			return nil, err
+		}
+
+		index := slices.IndexFunc(group, func(member models.Items) bool { return member.ID == itemId })
+		if index < 0 {
+			// Товар перейшов до іншої групи, поки ми чекали на блокування
+			if err = tx.Select("id, translation_group_id").Where("id = ?", itemId).First(&item).Error; err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return &group[index], nil
+	}
+}
+
+// applyStockMovement застосовує рух до товару, група якого заблокована lockItemGroup, в межах транзакції
+func applyStockMovement(tx *gorm.DB, item *models.Items, userId uuid.UUID, movement *models.StockMovementPost) (*models.StockMovement, error) {
+	delta, ok := movement.Type.Delta(movement.Quantity)
+	if !ok {
+		return nil, fmt.Errorf("invalid quantity %d for movement type %q", movement.Quantity, movement.Type)
+	}
+
+	quantity := item.Quantity + delta
+	if quantity < 0 {
+		return nil, ErrInsufficientStock
+	}
+
+	// Залишок спільний для всіх перекладів товару
+	groupId := translationGroupID(item)
+	err := tx.Model(&models.Items{}).Where("translation_group_id = ? OR id = ?", groupId, item.ID).Update("quantity", quantity).Error
+	if err != nil {
+		return nil, err
+	}
+	item.Quantity = quantity
+
+	record := &models.StockMovement{
+		ItemID:        groupId,
+		Type:          movement.Type,
+		Quantity:      delta,
+		QuantityAfter: quantity,
+		Reason:        movement.Reason,
+		UserID:        userId,
+	}
+	if err = tx.Create(record).Error; err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func GetStockMovements(db *gorm.DB, itemId uuid.UUID, skip int, limit int) (*models.StockMovementGetAll, error) {
+	var item models.Items
+	var movements []*models.StockMovement
+
+	err := db.Select("id, translation_group_id").Where("id = ?", itemId).First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Where("item_id = ?", translationGroupID(&item)).
+		Order("created_at DESC").
+		Offset(skip).Limit(limit).
+		Find(&movements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StockMovementGetAll{
+		Data:  movements,
+		Count: len(movements),
+	}, nil
+}
+
+// notifyIfLowStock публікує item.stock_low у транзакції руху лише в момент перетину порогу зверху вниз
+func notifyIfLowStock(tx *gorm.DB, item models.Items, before int) error {
+	if item.LowStockThreshold <= 0 {
+		return nil
+	}
+	if before <= item.LowStockThreshold || item.Quantity > item.LowStockThreshold {
+		return nil
+	}
+	log.Printf("📉 Item '%s' reached low stock: %d (threshold %d)", item.Title, item.Quantity, item.LowStockThreshold)
+	return events.Publish(tx, models.StockLow{
+		ItemID:    item.ID,
+		OwnerID:   item.OwnerID,
+		Title:     item.Title,
+		Quantity:  item.Quantity,
+		Threshold: item.LowStockThreshold,
+	})
+}
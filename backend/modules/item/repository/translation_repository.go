@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/repository"
+	"backend/modules/item/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+func translationGroupID(item *models.Items) uuid.UUID {
+	if item.TranslationGroupID == uuid.Nil {
+		return item.ID
+	}
+	return item.TranslationGroupID
+}
+
+// sharedContentIDs повертає ключі медіа товару: спільний ключ групи та власний ID перекладу
+func sharedContentIDs(item *models.Items) []uuid.UUID {
+	groupId := translationGroupID(item)
+	if groupId == item.ID {
+		return []uuid.UUID{item.ID}
+	}
+	return []uuid.UUID{groupId, item.ID}
+}
+
+// syncSharedFields копіює нетекстові поля на всі інші переклади групи
+func syncSharedFields(tx *gorm.DB, item *models.Items) error {
+	return tx.Model(&models.Items{}).
+		Where("translation_group_id = ? AND id <> ?", translationGroupID(item), item.ID).
+		Updates(map[string]interface{}{
+			"price":               item.Price,
+			"currency":            item.Currency,
+			"quantity":            item.Quantity,
+			"low_stock_threshold": item.LowStockThreshold,
+			"vat_rate":            item.VatRate,
+			"category_id":         item.CategoryID,
+			"item_url":            item.ItemUrl,
+			"sku":                 item.Sku,
+		}).Error
+}
+
+func GetItemTranslations(db *gorm.DB, itemId uuid.UUID) ([]entities.TranslationGet, error) {
+	var item models.Items
+	err := repository.GetByID(db, itemId, &item)
+	if err != nil {
+		return nil, err
+	}
+	return repository.GetTranslations[models.Items](db, translationGroupID(&item))
+}
+
+// CreateItemTranslation додає нову мовну версію, успадковуючи спільні поля з вихідного товару
+func CreateItemTranslation(db *gorm.DB, itemId uuid.UUID, post *entities.TranslationPost) (*models.ItemGet, error) {
+	var source models.Items
+	err := repository.GetByID(db, itemId, &source)
+	if err != nil {
+		return nil, err
+	}
+	groupId := translationGroupID(&source)
+
+	if post.Language == source.Language {
+		return nil, repository.ErrTranslationExists
+	}
+	err = repository.CheckTranslationAvailable[models.Items](db, groupId, post.Language, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	publication := entities.Publication{State: post.State}
+	if err = publication.Init(time.Now()); err != nil {
+		return nil, err
+	}
+
+	translation := &models.Items{
+		Title:              post.Title,
+		Content:            post.Content,
+		Price:              source.Price,
+		Currency:           source.Currency,
+		Quantity:           source.Quantity,
+		Language:           post.Language,
+		Sku:                source.Sku,
+		ItemUrl:            source.ItemUrl,
+		CategoryID:         source.CategoryID,
+		Publication:        publication,
+		LowStockThreshold:  source.LowStockThreshold,
+		VatRate:            source.VatRate,
+		TranslationGroupID: groupId,
+		OwnerID:            source.OwnerID,
+	}
+
+	// Переклад, його позиція, slug і перша ревізія записуються разом
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Позиції в кожній мові незалежні: без явної позиції переклад стає останнім
+		if post.Position != nil {
+			translation.Position = *post.Position
+			err = repository.GetPosition(tx.Where("language = ?", post.Language), translation.Position, &models.Items{})
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil {
+				if shiftErr := repository.ShiftPositions[models.Items](tx, translation.Position, translation.Language); shiftErr != nil {
+					return shiftErr
+				}
+			}
+		} else {
+			var maxPosition *int
+			err = tx.Model(&models.Items{}).Where("language = ?", post.Language).Select("MAX(position)").Scan(&maxPosition).Error
+			if err != nil {
+				return err
+			}
+			if maxPosition != nil {
+				translation.Position = *maxPosition + 1
+			}
+		}
+
+		if err = assignItemSlug(tx, translation, nil, translation.Language); err != nil {
+			return err
+		}
+		if err = repository.CreateEssence(tx, translation); err != nil {
+			return err
+		}
+		return recordItemRevision(tx, translation, translation.OwnerID, "created")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetItemById(db, translation.ID)
+}
+
+// DeleteItemTranslation видаляє мовну версію з групи; останню версію видаляти треба через DeleteItemById
+func DeleteItemTranslation(db *gorm.DB, itemId uuid.UUID, language string) error {
+	var item models.Items
+	err := repository.GetByID(db, itemId, &item)
+	if err != nil {
+		return err
+	}
+
+	var translation models.Items
+	err = db.Where("translation_group_id = ? AND language = ?", translationGroupID(&item), language).First(&translation).Error
+	if err != nil {
+		return err
+	}
+
+	siblings, err := repository.CountTranslations[models.Items](db, translationGroupID(&item), translation.ID)
+	if err != nil {
+		return err
+	}
+	if siblings == 0 {
+		return errors.New("cannot remove the only language version of an item")
+	}
+
+	return DeleteItemById(db, translation.ID)
+}
+
+func GetMissingItemTranslations(db *gorm.DB, userId uuid.UUID, isSuperUser bool, languages []string) ([]entities.MissingTranslation, error) {
+	query := db.Model(&models.Items{})
+	if !isSuperUser {
+		query = query.Where("owner_id = ?", userId)
+	}
+	return repository.FindMissingTranslations(query, languages)
+}
@@ -9,11 +9,32 @@ func RegisterRoutes(r *gin.RouterGroup) {
 	itemGroup := r.Group("/items")
 	{
 		itemGroup.POST("/", handlers.CreateItemHandler)
+		itemGroup.POST("/bulk", handlers.BulkItemsHandler)
 		itemGroup.GET("/", handlers.GetAllItemsHandler)
+		itemGroup.GET("/export", handlers.ExportItemsHandler)
+		itemGroup.POST("/import", handlers.ImportItemsHandler)
+		itemGroup.GET("/import/:jobId", handlers.GetImportJobHandler)
+		itemGroup.GET("/slug/:language/:slug", handlers.GetItemBySlugHandler)
 		itemGroup.GET("/:id", handlers.GetItemByID)
 		itemGroup.PATCH("/:id", handlers.UpdateItemByIdHandler)
 		itemGroup.GET("/languages", handlers.GetAvailableLanguages)
 		itemGroup.GET("/categories", handlers.GetAvailableCategories)
 		itemGroup.DELETE("/:id", handlers.DeleteItemByIdHandler)
+		itemGroup.POST("/:id/stock", handlers.CreateStockMovementHandler)
+		itemGroup.GET("/:id/stock", handlers.GetStockMovementsHandler)
+		itemGroup.PUT("/:id/prices", handlers.SetItemPricesHandler)
+		itemGroup.GET("/:id/price", handlers.GetItemPriceHandler)
+		itemGroup.GET("/:id/properties", handlers.GetItemPropertiesHandler)
+		itemGroup.PUT("/:id/properties", handlers.SetItemPropertiesHandler)
+		itemGroup.GET("/:id/tags", handlers.GetItemTagsHandler)
+		itemGroup.PUT("/:id/tags", handlers.SetItemTagsHandler)
+		itemGroup.GET("/:id/revisions", handlers.GetItemRevisionsHandler)
+		itemGroup.GET("/:id/revisions/diff", handlers.CompareItemRevisionsHandler)
+		itemGroup.GET("/:id/revisions/:number", handlers.GetItemRevisionHandler)
+		itemGroup.POST("/:id/revisions/:number/restore", handlers.RestoreItemRevisionHandler)
+		itemGroup.GET("/:id/translations", handlers.GetItemTranslationsHandler)
+		itemGroup.POST("/:id/translations", handlers.CreateItemTranslationHandler)
+		itemGroup.DELETE("/:id/translations/:language", handlers.DeleteItemTranslationHandler)
+		itemGroup.GET("/translations/missing", handlers.GetMissingItemTranslationsHandler)
 	}
 }
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// MediaDeleted — запис медіа видалено; файл у бакеті прибирається після коміту
+type MediaDeleted struct {
+	ID        uuid.UUID `json:"id"`
+	ContentID uuid.UUID `json:"content_id"`
+	URL       string    `json:"url"`
+}
+
+func (MediaDeleted) EventName() string { return "media.deleted" }
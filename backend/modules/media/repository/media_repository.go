@@ -89,3 +89,18 @@ func DeleteInBucket(db *gorm.DB, id uuid.UUID) error {
 	}
 	return nil
 }
+
+// DeleteContentMedia видаляє записи медіа вказаного вмісту й повертає їх, щоб файли прибрали після коміту
+func DeleteContentMedia(db *gorm.DB, contentIDs []uuid.UUID) ([]models.Media, error) {
+	var mediaList []models.Media
+	if len(contentIDs) == 0 {
+		return mediaList, nil
+	}
+	if err := db.Where("content_id IN ?", contentIDs).Find(&mediaList).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("content_id IN ?", contentIDs).Delete(&models.Media{}).Error; err != nil {
+		return nil, err
+	}
+	return mediaList, nil
+}
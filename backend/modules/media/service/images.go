@@ -1,10 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/Backblaze/blazer/b2"
 	"github.com/gin-gonic/gin"
+	"io"
 	"mime/multipart"
 	"os"
 	"strings"
@@ -30,36 +32,66 @@ func UploadFile(ctx *gin.Context, fileHeader *multipart.FileHeader) (string, err
 		}
 	}(file)
 
-	// Генеруємо унікальне ім'я файлу
-	uniqueFileName := GenerateUniqueFileName(fileHeader.Filename)
+	return uploadPublic(bucketName, GenerateUniqueFileName(fileHeader.Filename), file)
+}
+
+// UploadBytes завантажує вміст, отриманий не з форми (імпорт)
+func UploadBytes(fileName string, data []byte) (string, error) {
+	return uploadPublic(os.Getenv("BUCKET_NAME_ITEMS"), GenerateUniqueFileName(fileName), bytes.NewReader(data))
+}
+
+// UploadPrivateBytes завантажує вміст у приватний бакет і повертає ключ об'єкта замість
+// публічного URL: такі файли віддаються лише через API після перевірки доступу
+func UploadPrivateBytes(bucketName string, fileName string, data []byte) (string, error) {
+	uniqueFileName := GenerateUniqueFileName(fileName)
+	if err := uploadObject(bucketName, uniqueFileName, bytes.NewReader(data)); err != nil {
+		return "", err
+	}
+	return uniqueFileName, nil
+}
+
+func uploadPublic(bucketName string, uniqueFileName string, content io.Reader) (string, error) {
+	if err := uploadObject(bucketName, uniqueFileName, content); err != nil {
+		return "", err
+	}
+
+	// Формуємо публічний URL
+	publicURL := fmt.Sprintf("https://f003.backblazeb2.com/file/%s/%s", bucketName, uniqueFileName)
+
+	return publicURL, nil
+}
+
+func uploadObject(bucketName string, uniqueFileName string, content io.Reader) error {
+	accountID := os.Getenv("BACKBLAZE_ID")
+	applicationKey := os.Getenv("BACKBLAZE_KEY")
+
+	if accountID == "" || applicationKey == "" || bucketName == "" {
+		return fmt.Errorf("backblaze credentials are not set")
+	}
+
 	fmt.Println(uniqueFileName)
 	// Створюємо клієнт Backblaze B2
 	b2Client, err := b2.NewClient(context.Background(), accountID, applicationKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to create B2 client: %v", err)
+		return fmt.Errorf("failed to create B2 client: %v", err)
 	}
 
 	// Отримуємо бакет
 	bucket, err := b2Client.Bucket(context.Background(), bucketName)
 	if err != nil {
-		return "", fmt.Errorf("failed to get bucket: %v", err)
+		return fmt.Errorf("failed to get bucket: %v", err)
 	}
 
 	// Завантажуємо файл у Backblaze B2
 	obj := bucket.Object(uniqueFileName)
 	w := obj.NewWriter(context.Background())
-	if _, err := w.ReadFrom(file); err != nil {
-		return "", fmt.Errorf("failed to upload file: %v", err)
+	if _, err := w.ReadFrom(content); err != nil {
+		return fmt.Errorf("failed to upload file: %v", err)
 	}
 	if err := w.Close(); err != nil {
-		return "", fmt.Errorf("failed to close writer: %v", err)
+		return fmt.Errorf("failed to close writer: %v", err)
 	}
-
-	// Формуємо публічний URL
-	publicURL := fmt.Sprintf("https://f003.backblazeb2.com/file/%s/%s", bucketName, uniqueFileName)
-
-	return publicURL, nil
-
+	return nil
 }
 
 // DeleteFile видаляє файл з Backblaze B2
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"backend/internal/services/safehttp"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Обмеження для файлів, що завантажуються за посиланням
+const remoteFileMaxSize = 10 << 20
+
+// Клієнт не з'єднується з внутрішніми адресами, зокрема після переспрямувань
+var remoteClient = safehttp.NewClient(30 * time.Second)
+
+var ErrRemoteFileTooLarge = errors.New("remote file is too large")
+
+// FetchRemoteImage завантажує зображення за URL і повертає вміст, тип та ім'я файлу
+func FetchRemoteImage(fileURL string) ([]byte, string, string, error) {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return nil, "", "", fmt.Errorf("invalid image URL %q", fileURL)
+	}
+
+	resp, err := remoteClient.Get(parsedURL.String())
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", fmt.Errorf("failed to download %s: %s", fileURL, resp.Status)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", "", fmt.Errorf("%s is not an image (%s)", fileURL, contentType)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, remoteFileMaxSize+1))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(data) > remoteFileMaxSize {
+		return nil, "", "", ErrRemoteFileTooLarge
+	}
+
+	fileName := path.Base(parsedURL.Path)
+	if fileName == "." || fileName == "/" {
+		fileName = "image"
+	}
+	return data, contentType, fileName, nil
+}
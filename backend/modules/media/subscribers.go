@@ -0,0 +1,30 @@
+package media
+
+import (
+	"backend/internal/services/events"
+	"backend/modules/media/models"
+	"backend/modules/media/repository"
+	"backend/modules/media/service"
+	"gorm.io/gorm"
+)
+
+func RegisterSubscribers(bus *events.Bus) {
+	// Записи медіа видаляються разом із вмістом, а файли з бакета — лише після коміту
+	events.Subscribe(bus, func(tx *gorm.DB, event events.ContentDeleted) error {
+		mediaList, err := repository.DeleteContentMedia(tx, event.ContentIDs())
+		if err != nil {
+			return err
+		}
+		for _, media := range mediaList {
+			err = bus.Publish(tx, models.MediaDeleted{ID: media.ID, ContentID: media.ContentId, URL: media.Url})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	events.SubscribeAsync(bus, "media.bucket_cleanup", func(db *gorm.DB, event models.MediaDeleted) error {
+		return service.DeleteImageInBucket(event.URL)
+	})
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/notification/models"
+	"backend/modules/notification/repository"
+	"backend/modules/notification/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+func GetNotificationsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	params := &models.NotificationParameters{
+		UnreadOnly: ctx.Query("unread") == "true",
+		Type:       ctx.Query("type"),
+		Skip:       max(skip, 0),
+		Limit:      limit,
+	}
+
+	notifications, err := repository.GetNotifications(db, userId, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, notifications)
+}
+
+func GetUnreadCountHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	unread, err := repository.CountUnread(db, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"unread": unread})
+}
+
+func MarkNotificationReadHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	notificationId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	notification, err := repository.MarkRead(db, userId, notificationId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	publishRead(userId, []uuid.UUID{notificationId})
+
+	ctx.JSON(http.StatusOK, notification)
+}
+
+func MarkAllNotificationsReadHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	updated, err := repository.MarkAllRead(db, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	publishRead(userId, nil)
+
+	ctx.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+func DeleteNotificationHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	notificationId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	err = repository.DeleteNotification(db, userId, notificationId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Notification deleted"})
+}
+
+func GetPreferencesHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	preferences, err := repository.GetPreferences(db, userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"preferences": preferences})
+}
+
+func SetPreferencesHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var put models.PreferencePut
+	if err := ctx.ShouldBindJSON(&put); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences, err := repository.SetPreferences(db, userId, put.Preferences)
+	if errors.Is(err, repository.ErrUnknownType) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"preferences": preferences})
+}
+
+// publishRead повідомляє інші вкладки користувача про прочитані сповіщення; без ids — прочитано все
+func publishRead(userId uuid.UUID, ids []uuid.UUID) {
+	unread, err := repository.CountUnread(postgres.DB, userId)
+	if err != nil {
+		return
+	}
+	service.Events.Publish(userId, service.Event{Name: "read", Data: gin.H{"ids": ids, "all": ids == nil, "unread": unread}})
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/presence"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/notification/repository"
+	"backend/modules/notification/service"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Інтервал коментаря-пінга, що не дає проксі закрити тихе з'єднання
+const heartbeatInterval = 25 * time.Second
+
+// Скільки пропущених сповіщень надсилається після перепідключення з Last-Event-ID
+const replayLimit = 100
+
+// StreamNotificationsHandler — потік Server-Sent Events з новими сповіщеннями користувача.
+// Події: "ready" з кількістю непрочитаних, "notification" з новим сповіщенням, "read" після позначення прочитаними,
+// "presence" при зміні статусу будь-якого користувача.
+// Після розриву EventSource надсилає Last-Event-ID, і сповіщення, створені за цей час, надсилаються повторно
+func StreamNotificationsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	// Відкритий потік тримає користувача щонайменше "away", доки вкладка не закриється
+	disconnect := presence.Default.Connect(userId)
+	defer disconnect()
+
+	// Підписуємось до читання пропущеного, щоб нічого не загубити між ними
+	events, cancel := service.Events.Subscribe(userId)
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	sent := make(map[string]bool)
+	if lastId, err := uuid.Parse(ctx.GetHeader("Last-Event-ID")); err == nil {
+		missed, err := repository.GetNotificationsAfter(db, userId, lastId, replayLimit)
+		if err == nil {
+			for _, notification := range missed {
+				id := notification.ID.String()
+				sent[id] = true
+				writeEvent(ctx.Writer, service.Event{Name: "notification", ID: id, Data: notification})
+			}
+		}
+	}
+	unread, err := repository.CountUnread(db, userId)
+	if err != nil {
+		writeEvent(ctx.Writer, service.Event{Name: "error", Data: gin.H{"error": err.Error()}})
+		return
+	}
+	writeEvent(ctx.Writer, service.Event{Name: "ready", Data: gin.H{"unread": unread}})
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event := <-events:
+			if event.ID != "" && sent[event.ID] {
+				continue
+			}
+			writeEvent(ctx.Writer, event)
+			ctx.Writer.Flush()
+		case <-heartbeat.C:
+			if _, err := io.WriteString(ctx.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event service.Event) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+	if event.ID != "" {
+		fmt.Fprintf(w, "id: %s\n", event.ID)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, data)
+}
@@ -0,0 +1,25 @@
+package models
+
+type NotificationGetAll struct {
+	Data   []Notification
+	Count  int
+	Total  int64
+	Unread int64
+}
+
+type NotificationParameters struct {
+	UnreadOnly bool
+	Type       string
+	Skip       int
+	Limit      int
+}
+
+// PreferencePut — нові канали для типів; не вказані типи не змінюються
+type PreferencePut struct {
+	Preferences []PreferenceGet `json:"preferences" binding:"required"`
+}
+
+type PreferenceGet struct {
+	Type string `json:"type"`
+	Channels
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"backend/internal/entities"
+	userModels "backend/modules/user/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+// Типи сповіщень
+const (
+	TypeCalendarReminder = "calendar.reminder"
+	TypeTaskAssigned     = "task.assigned"
+	TypeCommentPending   = "comment.pending"
+	TypeLowStock         = "stock.low"
+)
+
+// Channels — канали доставки сповіщення
+type Channels struct {
+	InApp bool `json:"in_app"`
+	Email bool `json:"email"`
+}
+
+// Defaults — канали кожного типу, поки користувач їх не змінив
+var Defaults = map[string]Channels{
+	TypeCalendarReminder: {InApp: true, Email: true},
+	TypeTaskAssigned:     {InApp: true, Email: false},
+	TypeCommentPending:   {InApp: true, Email: true},
+	TypeLowStock:         {InApp: true, Email: true},
+}
+
+// Types повертає відомі типи в алфавітному порядку
+func Types() []string {
+	types := make([]string, 0, len(Defaults))
+	for notificationType := range Defaults {
+		types = append(types, notificationType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Notification — сповіщення в застосунку; Payload містить дані для переходу до джерела (ID записів тощо)
+type Notification struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null;index:idx_notifications_user_created" json:"user_id"`
+	Type      string          `gorm:"type:varchar(64);not null" json:"type"`
+	Title     string          `gorm:"type:varchar(255);not null" json:"title"`
+	Body      string          `gorm:"type:text" json:"body"`
+	Link      string          `gorm:"type:text" json:"link"`
+	Payload   entities.JSON   `gorm:"type:jsonb" json:"payload"`
+	ReadAt    *time.Time      `gorm:"index" json:"read_at"`
+	User      userModels.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time       `gorm:"index:idx_notifications_user_created" json:"created_at"`
+}
+
+// NotificationPreference — вибір користувача для типу сповіщення; відсутній запис означає Defaults
+type NotificationPreference struct {
+	UserID    uuid.UUID       `gorm:"type:uuid;primaryKey" json:"-"`
+	Type      string          `gorm:"type:varchar(64);primaryKey" json:"type"`
+	InApp     bool            `gorm:"not null" json:"in_app"`
+	Email     bool            `gorm:"not null" json:"email"`
+	User      userModels.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+func (notification *Notification) BeforeCreate(*gorm.DB) error {
+	if notification.ID == uuid.Nil {
+		notification.ID = uuid.New()
+	}
+	return nil
+}
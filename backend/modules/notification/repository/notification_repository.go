@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"backend/modules/notification/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var ErrUnknownType = errors.New("unknown notification type")
+
+func CreateNotification(db *gorm.DB, notification *models.Notification) error {
+	return db.Create(notification).Error
+}
+
+func GetNotifications(db *gorm.DB, userId uuid.UUID, params *models.NotificationParameters) (*models.NotificationGetAll, error) {
+	query := db.Model(&models.Notification{}).Where("user_id = ?", userId)
+	if params.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	unread, err := CountUnread(db, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []models.Notification{}
+	err = query.Order("created_at DESC").Offset(params.Skip).Limit(params.Limit).Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return &models.NotificationGetAll{Data: notifications, Count: len(notifications), Total: total, Unread: unread}, nil
+}
+
+// GetNotificationsAfter повертає сповіщення, створені після вказаного, — для відновлення потоку після розриву
+func GetNotificationsAfter(db *gorm.DB, userId uuid.UUID, afterId uuid.UUID, limit int) ([]models.Notification, error) {
+	var after models.Notification
+	if err := db.Select("created_at").First(&after, "id = ? AND user_id = ?", afterId, userId).Error; err != nil {
+		return nil, err
+	}
+	notifications := []models.Notification{}
+	err := db.Where("user_id = ? AND created_at > ?", userId, after.CreatedAt).
+		Order("created_at").
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+func CountUnread(db *gorm.DB, userId uuid.UUID) (int64, error) {
+	var count int64
+	err := db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).Count(&count).Error
+	return count, err
+}
+
+func MarkRead(db *gorm.DB, userId uuid.UUID, id uuid.UUID) (*models.Notification, error) {
+	var notification models.Notification
+	if err := db.First(&notification, "id = ? AND user_id = ?", id, userId).Error; err != nil {
+		return nil, err
+	}
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := db.Model(&notification).Update("read_at", now).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &notification, nil
+}
+
+// MarkAllRead позначає прочитаними всі сповіщення користувача й повертає їх кількість
+func MarkAllRead(db *gorm.DB, userId uuid.UUID) (int64, error) {
+	result := db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func DeleteNotification(db *gorm.DB, userId uuid.UUID, id uuid.UUID) error {
+	result := db.Where("id = ? AND user_id = ?", id, userId).Delete(&models.Notification{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetPreferences повертає канали всіх типів сповіщень з урахуванням вибору користувача
+func GetPreferences(db *gorm.DB, userId uuid.UUID) ([]models.PreferenceGet, error) {
+	var saved []models.NotificationPreference
+	if err := db.Where("user_id = ?", userId).Find(&saved).Error; err != nil {
+		return nil, err
+	}
+	byType := make(map[string]models.Channels, len(saved))
+	for _, preference := range saved {
+		byType[preference.Type] = models.Channels{InApp: preference.InApp, Email: preference.Email}
+	}
+
+	result := make([]models.PreferenceGet, 0, len(models.Defaults))
+	for _, notificationType := range models.Types() {
+		channels, ok := byType[notificationType]
+		if !ok {
+			channels = models.Defaults[notificationType]
+		}
+		result = append(result, models.PreferenceGet{Type: notificationType, Channels: channels})
+	}
+	return result, nil
+}
+
+// GetChannels повертає канали одного типу для користувача
+func GetChannels(db *gorm.DB, userId uuid.UUID, notificationType string) (models.Channels, error) {
+	var preference models.NotificationPreference
+	err := db.Where("user_id = ? AND type = ?", userId, notificationType).Limit(1).Find(&preference).Error
+	if err != nil {
+		return models.Channels{}, err
+	}
+	if preference.Type == "" {
+		return models.Defaults[notificationType], nil
+	}
+	return models.Channels{InApp: preference.InApp, Email: preference.Email}, nil
+}
+
+func SetPreferences(db *gorm.DB, userId uuid.UUID, preferences []models.PreferenceGet) ([]models.PreferenceGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, preference := range preferences {
+			if _, ok := models.Defaults[preference.Type]; !ok {
+				return ErrUnknownType
+			}
+			record := models.NotificationPreference{
+				UserID: userId,
+				Type:   preference.Type,
+				InApp:  preference.InApp,
+				Email:  preference.Email,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+				DoUpdates: clause.AssignmentColumns([]string{"in_app", "email", "updated_at"}),
+			}).Create(&record).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPreferences(db, userId)
+}
@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"backend/modules/notification/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	notificationGroup := r.Group("/notifications")
+	{
+		notificationGroup.GET("/", handlers.GetNotificationsHandler)
+		notificationGroup.GET("/unread-count", handlers.GetUnreadCountHandler)
+		notificationGroup.POST("/read-all", handlers.MarkAllNotificationsReadHandler)
+		notificationGroup.POST("/:id/read", handlers.MarkNotificationReadHandler)
+		notificationGroup.DELETE("/:id", handlers.DeleteNotificationHandler)
+		notificationGroup.GET("/preferences", handlers.GetPreferencesHandler)
+		notificationGroup.PUT("/preferences", handlers.SetPreferencesHandler)
+	}
+}
+
+// RegisterStreamRoutes реєструє потік сповіщень; група має перевіряти токен з заголовка або параметра token,
+// бо EventSource у браузері не вміє надсилати заголовки
+func RegisterStreamRoutes(r *gin.RouterGroup) {
+	r.GET("/notifications/stream", handlers.StreamNotificationsHandler)
+}
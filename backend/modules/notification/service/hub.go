@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"sync"
+)
+
+// Event — повідомлення для відкритих потоків користувача
+type Event struct {
+	Name string
+	ID   string
+	Data any
+}
+
+// Розмір буфера кожного підписника; повільний клієнт втрачає події й отримує їх після перепідключення
+const subscriberBuffer = 32
+
+// Hub розсилає події відкритим з'єднанням користувачів у межах цього процесу
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan Event]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[uuid.UUID]map[chan Event]struct{})}
+}
+
+// Events — спільний хаб застосунку
+var Events = NewHub()
+
+// Subscribe реєструє з'єднання користувача; cancel треба викликати після його закриття
+func (h *Hub) Subscribe(userId uuid.UUID) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	h.mu.Lock()
+	if h.subscribers[userId] == nil {
+		h.subscribers[userId] = make(map[chan Event]struct{})
+	}
+	h.subscribers[userId][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[userId], ch)
+			if len(h.subscribers[userId]) == 0 {
+				delete(h.subscribers, userId)
+			}
+			h.mu.Unlock()
+		})
+	}
+}
+
+// Publish надсилає подію всім з'єднанням користувача, не чекаючи на повільних
+func (h *Hub) Publish(userId uuid.UUID, event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subscribers[userId] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Broadcast надсилає подію всім відкритим з'єднанням усіх користувачів
+func (h *Hub) Broadcast(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, subscribers := range h.subscribers {
+		for ch := range subscribers {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// Connected повертає кількість відкритих з'єднань користувача
+func (h *Hub) Connected(userId uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[userId])
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/utils"
+	"backend/modules/notification/models"
+	"backend/modules/notification/repository"
+	userRepo "backend/modules/user/repository"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Message — сповіщення для користувача. Лист надсилається, лише якщо задано EmailHTML
+// і користувач не вимкнув email для цього типу
+type Message struct {
+	UserID       uuid.UUID
+	Type         string
+	Title        string
+	Body         string
+	Link         string
+	Payload      any
+	EmailSubject string
+	EmailHTML    string
+}
+
+// Send доставляє сповіщення каналами, які вибрав користувач: записує його в центр сповіщень
+// з подією у відкриті потоки та надсилає лист. Викликати поза транзакцією — подія йде одразу після запису
+func Send(db *gorm.DB, message Message) error {
+	channels, err := repository.GetChannels(db, message.UserID, message.Type)
+	if err != nil {
+		return err
+	}
+
+	if channels.InApp {
+		notification := models.Notification{
+			UserID: message.UserID,
+			Type:   message.Type,
+			Title:  message.Title,
+			Body:   message.Body,
+			Link:   message.Link,
+		}
+		if message.Payload != nil {
+			payload, err := json.Marshal(message.Payload)
+			if err != nil {
+				return err
+			}
+			notification.Payload = entities.JSON(payload)
+		}
+		if err = repository.CreateNotification(db, &notification); err != nil {
+			return err
+		}
+		Events.Publish(message.UserID, Event{Name: "notification", ID: notification.ID.String(), Data: notification})
+	}
+
+	if channels.Email && message.EmailHTML != "" {
+		user, err := userRepo.GetUserById(db, message.UserID)
+		if err != nil {
+			return err
+		}
+		subject := message.EmailSubject
+		if subject == "" {
+			subject = message.Title
+		}
+		return utils.SendEmail(user.Email, subject, message.EmailHTML, true)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"backend/internal/services/presence"
+)
+
+// ForwardPresence розсилає зміни статусу присутності всім відкритим потокам як подію "presence"
+func ForwardPresence(tracker *presence.Tracker) {
+	tracker.Listen(func(change presence.Change) {
+		Events.Broadcast(Event{Name: "presence", Data: change})
+	})
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"backend/internal/services/utils"
+	calendarModels "backend/modules/calendar/models"
+	commentModels "backend/modules/comment/models"
+	itemModels "backend/modules/item/models"
+	"backend/modules/notification/models"
+	taskModels "backend/modules/task/models"
+	userRepo "backend/modules/user/repository"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"html"
+	"log"
+	"os"
+	"time"
+)
+
+// Обробники подій інших модулів. Вони виконуються воркером outbox після коміту і можуть повторюватися,
+// тому помилку повертають лише там, де повтор не надішле комусь те саме вдруге
+
+// LowStock сповіщає власника товару про низький залишок у центрі сповіщень і листом,
+// як він вибрав у налаштуваннях. Якщо задано STOCK_ALERT_EMAIL, лист іде туди, а власник отримує лише сповіщення
+func LowStock(db *gorm.DB, event itemModels.StockLow) error {
+	to := os.Getenv("STOCK_ALERT_EMAIL")
+	name := "team"
+
+	user, err := userRepo.GetUserById(db, event.OwnerID)
+	if err != nil && to == "" {
+		log.Printf("⚠️ Item '%s' has no owner email, low stock alert skipped.\n", event.Title)
+		return nil
+	}
+	if to == "" {
+		name = user.FullName
+	}
+
+	subject := fmt.Sprintf("📉 Low stock: %s", event.Title)
+	message := fmt.Sprintf(`
+		<h3>Hello, %s!</h3>
+		<p>The stock of <strong>%s</strong> dropped to <strong>%d</strong>, which is at or below the threshold of <strong>%d</strong>.</p>
+		<hr>
+		<p><em>This is an automated message. Do not reply to it.</em></p>`,
+		name, event.Title, event.Quantity, event.Threshold,
+	)
+
+	if to != "" {
+		if err := utils.SendEmail(to, subject, message, true); err != nil {
+			log.Printf("❌ Error sending low stock alert for '%s' (%s): %v\n", event.Title, to, err)
+		}
+	}
+	if user == nil {
+		return nil
+	}
+
+	notification := Message{
+		UserID:       event.OwnerID,
+		Type:         models.TypeLowStock,
+		Title:        fmt.Sprintf("Low stock: %s", event.Title),
+		Body:         fmt.Sprintf("%d left, threshold %d", event.Quantity, event.Threshold),
+		Link:         "/items",
+		Payload:      map[string]any{"item_id": event.ItemID, "quantity": event.Quantity, "threshold": event.Threshold},
+		EmailSubject: subject,
+	}
+	if to == "" {
+		notification.EmailHTML = message
+	}
+	if err := Send(db, notification); err != nil {
+		log.Printf("❌ Error sending low stock notification for '%s': %v\n", event.Title, err)
+	}
+	return nil
+}
+
+// CommentPending сповіщає адміністраторів про коментар, що чекає модерації: у центрі сповіщень
+// і листом, як кожен вибрав у налаштуваннях. Якщо задано COMMENT_MODERATION_EMAIL, лист іде лише туди
+func CommentPending(db *gorm.DB, event commentModels.CommentPending) error {
+	sharedEmail := os.Getenv("COMMENT_MODERATION_EMAIL")
+	admins, err := userRepo.GetAdmins(db)
+	if err != nil && sharedEmail == "" {
+		return err
+	}
+
+	subject := fmt.Sprintf("💬 New comment awaiting review: %s", event.BlogTitle)
+	message := fmt.Sprintf(`
+		<h3>New comment on "%s"</h3>
+		<p><strong>%s</strong> (%s) wrote:</p>
+		<blockquote>%s</blockquote>
+		<p>The comment is waiting in the moderation queue.</p>
+		<hr>
+		<p><em>This is an automated message. Do not reply to it.</em></p>`,
+		html.EscapeString(event.BlogTitle),
+		html.EscapeString(event.AuthorName),
+		html.EscapeString(event.AuthorEmail),
+		html.EscapeString(event.Content),
+	)
+
+	if sharedEmail != "" {
+		if err := utils.SendEmail(sharedEmail, subject, message, true); err != nil {
+			log.Printf("❌ Error sending comment notification to %s: %v\n", sharedEmail, err)
+		}
+	}
+
+	for _, admin := range admins {
+		notification := Message{
+			UserID:       admin.ID,
+			Type:         models.TypeCommentPending,
+			Title:        fmt.Sprintf("New comment on \"%s\"", event.BlogTitle),
+			Body:         fmt.Sprintf("%s: %s", event.AuthorName, excerpt(event.Content, 200)),
+			Link:         "/blog",
+			Payload:      map[string]any{"comment_id": event.CommentID, "blog_id": event.BlogID},
+			EmailSubject: subject,
+		}
+		if sharedEmail == "" {
+			notification.EmailHTML = message
+		}
+		if err := Send(db, notification); err != nil {
+			log.Printf("❌ Error sending comment notification to %s: %v\n", admin.Email, err)
+		}
+	}
+	return nil
+}
+
+// TaskAssigned сповіщає нових виконавців картки
+func TaskAssigned(db *gorm.DB, event taskModels.TaskAssigned) error {
+	for _, userId := range event.UserIDs {
+		err := Send(db, Message{
+			UserID:       userId,
+			Type:         models.TypeTaskAssigned,
+			Title:        "Task assigned: " + event.Title,
+			Body:         event.Description,
+			Link:         "/tasks",
+			Payload:      map[string]any{"card_id": event.CardID, "board_id": event.BoardID},
+			EmailSubject: fmt.Sprintf("📋 Task assigned: %s", event.Title),
+			EmailHTML: fmt.Sprintf(`
+		<h3>You have been assigned a task</h3>
+		<p><strong>%s</strong></p>
+		<p>%s</p>
+		<hr>
+		<p><em>This is an automated message. Do not reply to it.</em></p>`,
+				html.EscapeString(event.Title), html.EscapeString(event.Description)),
+		})
+		if err != nil {
+			log.Printf("❌ Error sending task notification for '%s': %v\n", event.Title, err)
+		}
+	}
+	return nil
+}
+
+// CalendarReminder нагадує власнику про подію календаря. Канали (центр сповіщень і лист)
+// вибирає сам користувач у налаштуваннях; при помилці нагадування повториться
+func CalendarReminder(db *gorm.DB, event calendarModels.ReminderDue) error {
+	user, err := userRepo.GetUserById(db, event.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("⚠️ Event '%s' has no user email, skipped.\n", event.Title)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	warsawLoc, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		return err
+	}
+	startsAt := event.StartDate.In(warsawLoc).Format("02.01.2006 15:04")
+
+	subject := fmt.Sprintf("🔔 Reminder.: %s", event.Title)
+	message := fmt.Sprintf(`
+		<h3>Hello, %s!</h3>
+		<p>We remind you that the event <strong>%s</strong> will begin <strong>%s</strong>.</p>
+		<p>Details: %s</p>
+		<hr>
+		<p><em>This is an automated message. Do not reply to it.</em></p>`,
+		user.FullName, event.Title, startsAt, event.Description,
+	)
+
+	err = Send(db, Message{
+		UserID:       event.UserID,
+		Type:         models.TypeCalendarReminder,
+		Title:        event.Title,
+		Body:         fmt.Sprintf("Starts %s", startsAt),
+		Link:         "/calendar",
+		Payload:      map[string]any{"event_id": event.EventID, "start_date": event.StartDate},
+		EmailSubject: subject,
+		EmailHTML:    message,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ A reminder has been sent: %s (%s)\n", event.Title, user.Email)
+	return nil
+}
+
+// excerpt обрізає текст до limit символів
+func excerpt(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
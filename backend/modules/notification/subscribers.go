@@ -0,0 +1,13 @@
+package notification
+
+import (
+	"backend/internal/services/events"
+	"backend/modules/notification/service"
+)
+
+func RegisterSubscribers(bus *events.Bus) {
+	events.SubscribeAsync(bus, "notification.low_stock", service.LowStock)
+	events.SubscribeAsync(bus, "notification.comment_pending", service.CommentPending)
+	events.SubscribeAsync(bus, "notification.task_assigned", service.TaskAssigned)
+	events.SubscribeAsync(bus, "notification.calendar_reminder", service.CalendarReminder)
+}
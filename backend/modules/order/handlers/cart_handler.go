@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	documentModels "backend/modules/document/models"
+	documentService "backend/modules/document/service"
+	itemRepo "backend/modules/item/repository"
+	"backend/modules/order/models"
+	"backend/modules/order/repository"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+func GetCartHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userID, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	cart, err := repository.GetCart(db, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cart)
+}
+
+// SetCartLineHandler встановлює кількість товару в кошику; 0 прибирає товар
+func SetCartLineHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userID, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	itemId, err := uuid.Parse(ctx.Param("itemId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var put models.CartLinePut
+	if err = ctx.ShouldBindJSON(&put); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := repository.SetCartLine(db, userID, itemId, put.Quantity)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+	if errors.Is(err, itemRepo.ErrInsufficientStock) || errors.Is(err, repository.ErrNotPurchasable) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cart)
+}
+
+func DeleteCartLineHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userID, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	itemId, err := uuid.Parse(ctx.Param("itemId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	cart, err := repository.SetCartLine(db, userID, itemId, 0)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cart)
+}
+
+func UpdateCartHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userID, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var update models.CartUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := repository.SetCartCurrency(db, userID, update.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cart)
+}
+
+func ClearCartHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userID, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.ClearCart(db, userID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Cart cleared"})
+}
+
+// CheckoutHandler оформлює замовлення з кошика та резервує товари
+func CheckoutHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	var post models.CheckoutPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := repository.Checkout(db, user, &post)
+	if errors.Is(err, itemRepo.ErrInsufficientStock) || errors.Is(err, repository.ErrNotPurchasable) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	documentService.IssueInBackground(order.ID, documentModels.TypeConfirmation)
+	ctx.JSON(http.StatusCreated, order)
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	documentModels "backend/modules/document/models"
+	documentService "backend/modules/document/service"
+	"backend/modules/order/models"
+	"backend/modules/order/repository"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GetOrdersHandler — адміністратори бачать усі замовлення, інші користувачі — лише власні.
+// Фільтри: ?status=, ?user_id=, ?q= (номер, email, ім'я), ?from=, ?to= (RFC 3339), ?skip=, ?limit=
+func GetOrdersHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+	filter := &models.OrderFilter{
+		Status: models.Status(ctx.Query("status")),
+		Search: ctx.Query("q"),
+		Skip:   skip,
+		Limit:  limit,
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	if isAdmin(user) {
+		if value := ctx.Query("user_id"); value != "" {
+			userId, err := uuid.Parse(value)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+				return
+			}
+			filter.UserID = &userId
+		}
+	} else {
+		filter.UserID = &user.ID
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(ctx, "from"); err != nil {
+		return
+	}
+	if filter.To, err = parseTimeParam(ctx, "to"); err != nil {
+		return
+	}
+
+	orders, err := repository.GetOrders(db, filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, orders)
+}
+
+func GetOrderHandler(ctx *gin.Context) {
+	order, _, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+// UpdateOrderStatusHandler змінює статус замовлення; доступно лише адміністраторам
+func UpdateOrderStatusHandler(ctx *gin.Context) {
+	db := postgres.DB
+	order, user, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return
+	}
+	if !isAdmin(user) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var update models.OrderStatusUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := repository.UpdateOrderStatus(db, order.ID, update.Status, &user.ID, update.Comment)
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if updated.Status == models.StatusPaid {
+		documentService.IssueInBackground(updated.ID, documentModels.TypeInvoice)
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// CancelOrderHandler дозволяє покупцю скасувати власне неоплачене замовлення
+func CancelOrderHandler(ctx *gin.Context) {
+	db := postgres.DB
+	order, user, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return
+	}
+	if order.Status != models.StatusNew && !isAdmin(user) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Only new orders can be cancelled"})
+		return
+	}
+
+	updated, err := repository.UpdateOrderStatus(db, order.ID, models.StatusCancelled, &user.ID, "cancelled by customer")
+	if errors.Is(err, repository.ErrInvalidTransition) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// getAccessibleOrder повертає замовлення, якщо воно належить користувачу або користувач — адміністратор
+func getAccessibleOrder(ctx *gin.Context) (*models.Order, *userModels.User, bool) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, nil, false
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return nil, nil, false
+	}
+
+	order, err := repository.GetOrder(db, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && order.UserID != user.ID && !isAdmin(user)) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return nil, nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+	return order, user, true
+}
+
+func isAdmin(user *userModels.User) bool {
+	return user.IsAdmin || user.IsSuperUser
+}
+
+func parseTimeParam(ctx *gin.Context, name string) (*time.Time, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + ", expected RFC 3339"})
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	itemModels "backend/modules/item/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Cart — кошик користувача; ціни рахуються під час перегляду, а фіксуються лише в замовленні
+type Cart struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Currency  string     `gorm:"type:varchar(3);not null;default:'PLN'" json:"currency"`
+	Lines     []CartLine `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CartLine struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	CartID    uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_cart_lines_cart_item" json:"cart_id"`
+	ItemID    uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_cart_lines_cart_item;index" json:"item_id"`
+	Item      itemModels.Items `gorm:"foreignKey:ItemID;constraint:OnDelete:CASCADE" json:"-"`
+	Quantity  int              `gorm:"not null" json:"quantity"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (cart *Cart) BeforeCreate(*gorm.DB) error {
+	if cart.ID == uuid.Nil {
+		cart.ID = uuid.New()
+	}
+	return nil
+}
+
+func (line *CartLine) BeforeCreate(*gorm.DB) error {
+	if line.ID == uuid.Nil {
+		line.ID = uuid.New()
+	}
+	return nil
+}
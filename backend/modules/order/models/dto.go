@@ -0,0 +1,66 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CartLineGet struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	Title       string    `json:"title"`
+	Sku         *string   `json:"sku"`
+	Slug        *string   `json:"slug"`
+	Image       string    `json:"image"`
+	Price       int64     `json:"price"`
+	Quantity    int       `json:"quantity"`
+	Total       int64     `json:"total"`
+	Available   int       `json:"available"`
+	Purchasable bool      `json:"purchasable"`
+}
+
+type CartGet struct {
+	ID       uuid.UUID      `json:"id"`
+	Currency string         `json:"currency"`
+	Lines    []*CartLineGet `json:"lines"`
+	Total    int64          `json:"total"`
+	Count    int            `json:"count"`
+}
+
+type CartLinePut struct {
+	Quantity int `json:"quantity" binding:"min=0"`
+}
+
+type CartUpdate struct {
+	Currency string `json:"currency" binding:"required"`
+}
+
+// CheckoutPost — дані покупця; порожні ім'я та email беруться з профілю
+type CheckoutPost struct {
+	Email           string `json:"email"`
+	FullName        string `json:"full_name"`
+	Phone           string `json:"phone"`
+	ShippingAddress string `json:"shipping_address" binding:"required"`
+	Note            string `json:"note"`
+}
+
+type OrderStatusUpdate struct {
+	Status  Status `json:"status" binding:"required"`
+	Comment string `json:"comment"`
+}
+
+// OrderFilter — фільтри списку замовлень; UserID обмежує вибірку замовленнями одного користувача
+type OrderFilter struct {
+	Status Status
+	UserID *uuid.UUID
+	Search string
+	From   *time.Time
+	To     *time.Time
+	Skip   int
+	Limit  int
+}
+
+type OrderGetAll struct {
+	Data  []*Order
+	Count int
+	Total int64
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusNew       Status = "new"
+	StatusPaid      Status = "paid"
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered"
+	StatusCancelled Status = "cancelled"
+	StatusRefunded  Status = "refunded"
+)
+
+// Дозволені переходи між статусами замовлення
+var statusTransitions = map[Status][]Status{
+	StatusNew:       {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusShipped, StatusCancelled, StatusRefunded},
+	StatusShipped:   {StatusDelivered, StatusRefunded},
+	StatusDelivered: {StatusRefunded},
+	StatusCancelled: {},
+	StatusRefunded:  {},
+}
+
+func (s Status) Valid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+func (s Status) CanTransition(to Status) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+// Order — замовлення; рядки зберігають назву й ціну товару на момент оформлення
+type Order struct {
+	ID              uuid.UUID    `gorm:"type:uuid;primaryKey" json:"id"`
+	Number          int64        `gorm:"autoIncrement;uniqueIndex" json:"number"`
+	UserID          uuid.UUID    `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status          Status       `gorm:"type:varchar(16);not null;default:'new';index" json:"status"`
+	Currency        string       `gorm:"type:varchar(3);not null" json:"currency"`
+	Total           int64        `gorm:"not null;default:0" json:"total"`
+	Email           string       `gorm:"type:varchar(255);not null;index" json:"email"`
+	FullName        string       `gorm:"type:varchar(255);not null" json:"full_name"`
+	Phone           string       `gorm:"type:varchar(32)" json:"phone"`
+	ShippingAddress string       `gorm:"type:text" json:"shipping_address"`
+	Note            string       `gorm:"type:text" json:"note"`
+	StockReserved   bool         `gorm:"not null;default:false" json:"stock_reserved"`
+	Lines           []OrderLine  `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"lines"`
+	Events          []OrderEvent `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"events,omitempty"`
+	PaidAt          *time.Time   `json:"paid_at"`
+	ShippedAt       *time.Time   `json:"shipped_at"`
+	DeliveredAt     *time.Time   `json:"delivered_at"`
+	CancelledAt     *time.Time   `json:"cancelled_at"`
+	RefundedAt      *time.Time   `json:"refunded_at"`
+	CreatedAt       time.Time    `gorm:"index" json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+type OrderLine struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID  uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	ItemID   uuid.UUID `gorm:"type:uuid;not null;index" json:"item_id"`
+	Title    string    `gorm:"not null" json:"title"`
+	Sku      *string   `gorm:"type:varchar(64)" json:"sku"`
+	Price    int64     `gorm:"not null" json:"price"`
+	Quantity int       `gorm:"not null" json:"quantity"`
+	Total    int64     `gorm:"not null" json:"total"`
+	VatRate  *int      `json:"vat_rate"`
+}
+
+// OrderEvent — запис історії статусів замовлення
+type OrderEvent struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"order_id"`
+	From      Status     `gorm:"type:varchar(16)" json:"from"`
+	To        Status     `gorm:"type:varchar(16);not null" json:"to"`
+	UserID    *uuid.UUID `gorm:"type:uuid" json:"user_id"`
+	Comment   string     `gorm:"type:text" json:"comment"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (order *Order) BeforeCreate(*gorm.DB) error {
+	if order.ID == uuid.Nil {
+		order.ID = uuid.New()
+	}
+	return nil
+}
+
+func (line *OrderLine) BeforeCreate(*gorm.DB) error {
+	if line.ID == uuid.Nil {
+		line.ID = uuid.New()
+	}
+	return nil
+}
+
+func (event *OrderEvent) BeforeCreate(*gorm.DB) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	return nil
+}
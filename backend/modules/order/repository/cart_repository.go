@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"backend/internal/services/money"
+	itemModels "backend/modules/item/models"
+	itemRepo "backend/modules/item/repository"
+	"backend/modules/order/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxCartLines = 100
+
+var (
+	ErrNotPurchasable = errors.New("the item is not available for sale")
+	ErrCartFull       = errors.New("the cart cannot contain more than 100 items")
+)
+
+func getOrCreateCart(db *gorm.DB, userId uuid.UUID) (*models.Cart, error) {
+	cart := models.Cart{UserID: userId, Currency: money.DefaultCurrency}
+	// Паралельні запити не створять другий кошик завдяки унікальному user_id
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&cart).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var saved models.Cart
+	if err = db.Where("user_id = ?", userId).First(&saved).Error; err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// GetCart повертає кошик з актуальними цінами у валюті кошика
+func GetCart(db *gorm.DB, userId uuid.UUID) (*models.CartGet, error) {
+	cart, err := getOrCreateCart(db, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []models.CartLine
+	if err = db.Where("cart_id = ?", cart.ID).Order("created_at ASC").Find(&lines).Error; err != nil {
+		return nil, err
+	}
+
+	response := &models.CartGet{ID: cart.ID, Currency: cart.Currency, Lines: make([]*models.CartLineGet, 0, len(lines))}
+	for _, line := range lines {
+		item, err := itemRepo.GetItemById(db, line.ItemID)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &models.CartLineGet{
+			ItemID:      item.ID,
+			Title:       item.Title,
+			Sku:         item.Sku,
+			Slug:        item.Slug,
+			Quantity:    line.Quantity,
+			Available:   item.Quantity,
+			Purchasable: item.IsPublished() && item.Quantity >= line.Quantity,
+		}
+		if len(item.Images) > 0 {
+			result.Image = item.Images[0]
+		}
+
+		price, err := itemRepo.ResolveItemPrice(item, cart.Currency, money.DefaultRates())
+		if err != nil {
+			// Без курсу товар не можна купити в цій валюті, але кошик показуємо
+			result.Purchasable = false
+		} else {
+			result.Price = price.Amount
+			result.Total = price.Amount * int64(line.Quantity)
+			response.Total += result.Total
+		}
+
+		response.Lines = append(response.Lines, result)
+		response.Count += line.Quantity
+	}
+	return response, nil
+}
+
+// SetCartLine встановлює кількість товару в кошику; нульова кількість прибирає рядок
+func SetCartLine(db *gorm.DB, userId uuid.UUID, itemId uuid.UUID, quantity int) (*models.CartGet, error) {
+	cart, err := getOrCreateCart(db, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if quantity <= 0 {
+		err = db.Where("cart_id = ? AND item_id = ?", cart.ID, itemId).Delete(&models.CartLine{}).Error
+		if err != nil {
+			return nil, err
+		}
+		return GetCart(db, userId)
+	}
+
+	var item itemModels.Items
+	if err = db.Where("id = ?", itemId).First(&item).Error; err != nil {
+		return nil, err
+	}
+	if !item.IsPublished() {
+		return nil, ErrNotPurchasable
+	}
+	// Остаточна перевірка залишку відбувається під час оформлення
+	if quantity > item.Quantity {
+		return nil, fmt.Errorf("%w: %d of %q available", itemRepo.ErrInsufficientStock, item.Quantity, item.Title)
+	}
+
+	var count int64
+	err = db.Model(&models.CartLine{}).Where("cart_id = ? AND item_id <> ?", cart.ID, itemId).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCartLines {
+		return nil, ErrCartFull
+	}
+
+	line := models.CartLine{CartID: cart.ID, ItemID: itemId, Quantity: quantity}
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cart_id"}, {Name: "item_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+	}).Create(&line).Error
+	if err != nil {
+		return nil, err
+	}
+	return GetCart(db, userId)
+}
+
+func SetCartCurrency(db *gorm.DB, userId uuid.UUID, currency string) (*models.CartGet, error) {
+	currency = money.NormalizeCurrency(currency)
+	if !money.IsSupported(currency) {
+		return nil, fmt.Errorf("%w: %q", money.ErrUnknownCurrency, currency)
+	}
+
+	cart, err := getOrCreateCart(db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Model(cart).Update("currency", currency).Error; err != nil {
+		return nil, err
+	}
+	return GetCart(db, userId)
+}
+
+func ClearCart(db *gorm.DB, userId uuid.UUID) error {
+	return db.Where("cart_id IN (?)", db.Model(&models.Cart{}).Select("id").Where("user_id = ?", userId)).
+		Delete(&models.CartLine{}).Error
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"backend/internal/services/money"
+	itemModels "backend/modules/item/models"
+	itemRepo "backend/modules/item/repository"
+	"backend/modules/order/models"
+	userModels "backend/modules/user/models"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxPageSize = 100
+
+var (
+	ErrEmptyCart         = errors.New("the cart is empty")
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	ErrInvalidCustomer   = errors.New("a valid email and full name are required")
+)
+
+// Checkout перетворює кошик на замовлення: фіксує назви й ціни, резервує залишки та очищує кошик.
+// Усе виконується в одній транзакції, тож при нестачі товару не змінюється нічого.
+func Checkout(db *gorm.DB, user *userModels.User, post *models.CheckoutPost) (*models.Order, error) {
+	order := &models.Order{
+		UserID:          user.ID,
+		Status:          models.StatusNew,
+		Email:           strings.TrimSpace(post.Email),
+		FullName:        strings.TrimSpace(post.FullName),
+		Phone:           strings.TrimSpace(post.Phone),
+		ShippingAddress: strings.TrimSpace(post.ShippingAddress),
+		Note:            strings.TrimSpace(post.Note),
+		StockReserved:   true,
+	}
+	if order.Email == "" {
+		order.Email = user.Email
+	}
+	if order.FullName == "" {
+		order.FullName = user.FullName
+	}
+	if _, err := mail.ParseAddress(order.Email); err != nil || order.FullName == "" {
+		return nil, ErrInvalidCustomer
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var cart models.Cart
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", user.ID).First(&cart).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEmptyCart
+		}
+		if err != nil {
+			return err
+		}
+
+		var lines []models.CartLine
+		if err = tx.Where("cart_id = ?", cart.ID).Order("created_at ASC").Find(&lines).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return ErrEmptyCart
+		}
+		itemIds := make([]uuid.UUID, len(lines))
+		for i, line := range lines {
+			itemIds[i] = line.ItemID
+		}
+		less, err := stockLockOrder(tx, itemIds)
+		if err != nil {
+			return err
+		}
+		sort.Slice(lines, func(i, j int) bool { return less(lines[i].ItemID, lines[j].ItemID) })
+
+		order.Currency = cart.Currency
+		if err = tx.Create(order).Error; err != nil {
+			return err
+		}
+
+		reason := "order #" + strconv.FormatInt(order.Number, 10)
+		for _, cartLine := range lines {
+			item, err := itemRepo.GetItemById(tx, cartLine.ItemID)
+			if err != nil {
+				return err
+			}
+			if !item.IsPublished() {
+				return fmt.Errorf("%w: %q", ErrNotPurchasable, item.Title)
+			}
+
+			price, err := itemRepo.ResolveItemPrice(item, order.Currency, money.DefaultRates())
+			if err != nil {
+				return err
+			}
+
+			_, err = itemRepo.RecordStockMovement(tx, item.ID, user.ID, &itemModels.StockMovementPost{
+				Type:     itemModels.MovementReservation,
+				Quantity: cartLine.Quantity,
+				Reason:   reason,
+			})
+			if errors.Is(err, itemRepo.ErrInsufficientStock) {
+				return fmt.Errorf("%w: %q", itemRepo.ErrInsufficientStock, item.Title)
+			}
+			if err != nil {
+				return err
+			}
+
+			line := models.OrderLine{
+				OrderID:  order.ID,
+				ItemID:   item.ID,
+				Title:    item.Title,
+				Sku:      item.Sku,
+				Price:    price.Amount,
+				Quantity: cartLine.Quantity,
+				Total:    price.Amount * int64(cartLine.Quantity),
+				VatRate:  item.VatRate,
+			}
+			if err = tx.Create(&line).Error; err != nil {
+				return err
+			}
+			order.Lines = append(order.Lines, line)
+			order.Total += line.Total
+		}
+
+		if err = tx.Model(order).Update("total", order.Total).Error; err != nil {
+			return err
+		}
+		if err = tx.Where("cart_id = ?", cart.ID).Delete(&models.CartLine{}).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, order.ID, "", models.StatusNew, &user.ID, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetOrder(db, order.ID)
+}
+
+func GetOrder(db *gorm.DB, id uuid.UUID) (*models.Order, error) {
+	var order models.Order
+	err := db.Preload("Lines").
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Where("id = ?", id).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrders повертає замовлення за фільтрами, найновіші першими
+func GetOrders(db *gorm.DB, filter *models.OrderFilter) (*models.OrderGetAll, error) {
+	if filter.Skip < 0 {
+		filter.Skip = 0
+	}
+	if filter.Limit <= 0 || filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+
+	query := db.Model(&models.Order{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	// Пошук за номером замовлення, email або ім'ям покупця
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		pattern := "%" + strings.ToLower(search) + "%"
+		if number, err := strconv.ParseInt(strings.TrimPrefix(search, "#"), 10, 64); err == nil {
+			query = query.Where("number = ? OR LOWER(email) LIKE ? OR LOWER(full_name) LIKE ?", number, pattern, pattern)
+		} else {
+			query = query.Where("LOWER(email) LIKE ? OR LOWER(full_name) LIKE ?", pattern, pattern)
+		}
+	}
+
+	response := &models.OrderGetAll{}
+	if err := query.Session(&gorm.Session{}).Count(&response.Total).Error; err != nil {
+		return nil, err
+	}
+
+	err := query.Preload("Lines").
+		Order("created_at DESC").
+		Offset(filter.Skip).Limit(filter.Limit).
+		Find(&response.Data).Error
+	if err != nil {
+		return nil, err
+	}
+	response.Count = len(response.Data)
+	return response, nil
+}
+
+// UpdateOrderStatus переводить замовлення в новий статус.
+// Скасування та повернення до відправлення знімають резерв залишків.
+func UpdateOrderStatus(db *gorm.DB, id uuid.UUID, to models.Status, userId *uuid.UUID, comment string) (*models.Order, error) {
+	if !to.Valid() {
+		return nil, fmt.Errorf("unknown order status %q", to)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines").Where("id = ?", id).First(&order).Error
+		if err != nil {
+			return err
+		}
+		if !order.Status.CanTransition(to) {
+			return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, order.Status, to)
+		}
+
+		updates := map[string]interface{}{"status": to}
+		now := time.Now()
+		switch to {
+		case models.StatusPaid:
+			updates["paid_at"] = now
+		case models.StatusShipped:
+			updates["shipped_at"] = now
+		case models.StatusDelivered:
+			updates["delivered_at"] = now
+		case models.StatusCancelled:
+			updates["cancelled_at"] = now
+		case models.StatusRefunded:
+			updates["refunded_at"] = now
+		}
+
+		release := to == models.StatusCancelled || (to == models.StatusRefunded && order.ShippedAt == nil)
+		if release && order.StockReserved {
+			if err = releaseStock(tx, &order, userId, to); err != nil {
+				return err
+			}
+			updates["stock_reserved"] = false
+		}
+
+		if err = tx.Model(&order).Updates(updates).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, order.ID, order.Status, to, userId, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetOrder(db, id)
+}
+
+// releaseStock повертає зарезервовані одиниці на склад; видалені товари пропускаються
+func releaseStock(tx *gorm.DB, order *models.Order, userId *uuid.UUID, to models.Status) error {
+	actor := uuid.Nil
+	if userId != nil {
+		actor = *userId
+	}
+	reason := fmt.Sprintf("order #%d %s", order.Number, to)
+
+	// Той самий порядок блокувань, що й під час оформлення
+	lines := append([]models.OrderLine(nil), order.Lines...)
+	itemIds := make([]uuid.UUID, len(lines))
+	for i, line := range lines {
+		itemIds[i] = line.ItemID
+	}
+	less, err := stockLockOrder(tx, itemIds)
+	if err != nil {
+		return err
+	}
+	sort.Slice(lines, func(i, j int) bool { return less(lines[i].ItemID, lines[j].ItemID) })
+	for _, line := range lines {
+		_, err := itemRepo.RecordStockMovement(tx, line.ItemID, actor, &itemModels.StockMovementPost{
+			Type:     itemModels.MovementRelease,
+			Quantity: line.Quantity,
+			Reason:   reason,
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stockLockOrder повертає порядок, у якому треба проводити рухи по складу кількох товарів.
+// RecordStockMovement блокує всю групу перекладів товару, тож товари впорядковуються за групою,
+// а в межах групи — за ID; однаковий порядок для всіх транзакцій виключає взаємні блокування
+func stockLockOrder(tx *gorm.DB, itemIds []uuid.UUID) (func(a, b uuid.UUID) bool, error) {
+	groups, err := itemRepo.StockGroups(tx, itemIds)
+	if err != nil {
+		return nil, err
+	}
+	return func(a, b uuid.UUID) bool {
+		groupA, groupB := groups[a], groups[b]
+		if order := bytes.Compare(groupA[:], groupB[:]); order != 0 {
+			return order < 0
+		}
+		return bytes.Compare(a[:], b[:]) < 0
+	}, nil
+}
+
+// ExpireOrders скасовує неоплачені замовлення, створені до before, і знімає їхні резерви
+func ExpireOrders(db *gorm.DB, before time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := db.Model(&models.Order{}).
+		Where("status = ? AND created_at < ?", models.StatusNew, before).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []uuid.UUID
+	for _, id := range ids {
+		_, err = UpdateOrderStatus(db, id, models.StatusCancelled, nil, "reservation expired")
+		// Замовлення могли оплатити між вибіркою та блокуванням
+		if errors.Is(err, ErrInvalidTransition) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired = append(expired, id)
+	}
+	return expired, nil
+}
+
+func recordEvent(tx *gorm.DB, orderId uuid.UUID, from, to models.Status, userId *uuid.UUID, comment string) error {
+	return tx.Create(&models.OrderEvent{
+		OrderID: orderId,
+		From:    from,
+		To:      to,
+		UserID:  userId,
+		Comment: comment,
+	}).Error
+}
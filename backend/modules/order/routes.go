@@ -0,0 +1,26 @@
+package order
+
+import (
+	"backend/modules/order/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	cartGroup := r.Group("/cart")
+	{
+		cartGroup.GET("/", handlers.GetCartHandler)
+		cartGroup.PATCH("/", handlers.UpdateCartHandler)
+		cartGroup.DELETE("/", handlers.ClearCartHandler)
+		cartGroup.PUT("/items/:itemId", handlers.SetCartLineHandler)
+		cartGroup.DELETE("/items/:itemId", handlers.DeleteCartLineHandler)
+		cartGroup.POST("/checkout", handlers.CheckoutHandler)
+	}
+
+	orderGroup := r.Group("/orders")
+	{
+		orderGroup.GET("/", handlers.GetOrdersHandler)
+		orderGroup.GET("/:id", handlers.GetOrderHandler)
+		orderGroup.PATCH("/:id", handlers.UpdateOrderStatusHandler)
+		orderGroup.POST("/:id/cancel", handlers.CancelOrderHandler)
+	}
+}
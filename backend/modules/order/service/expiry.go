@@ -0,0 +1,42 @@
+package service
+
+import (
+	"backend/modules/order/repository"
+	"gorm.io/gorm"
+	"log"
+	"os"
+	"time"
+)
+
+const defaultReservationTTL = 24 * time.Hour
+
+// ReservationTTL читає з ORDER_RESERVATION_TTL (наприклад, 2h), скільки неоплачене замовлення тримає резерв
+func ReservationTTL() time.Duration {
+	value := os.Getenv("ORDER_RESERVATION_TTL")
+	if value == "" {
+		return defaultReservationTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Printf("⚠️ Invalid ORDER_RESERVATION_TTL %q, using %s", value, defaultReservationTTL)
+		return defaultReservationTTL
+	}
+	return ttl
+}
+
+// StartExpiryWorker періодично скасовує неоплачені замовлення, у яких сплив резерв
+func StartExpiryWorker(db *gorm.DB, interval time.Duration, ttl time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := repository.ExpireOrders(db, time.Now().Add(-ttl))
+		if err != nil {
+			log.Printf("❌ Order expiry failed: %v", err)
+		}
+		for _, id := range expired {
+			log.Printf("🕒 order %s cancelled: reservation expired", id)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/services/payment"
+	utils2 "backend/internal/services/utils"
+	documentModels "backend/modules/document/models"
+	documentService "backend/modules/document/service"
+	orderModels "backend/modules/order/models"
+	orderRepo "backend/modules/order/repository"
+	"backend/modules/payment/models"
+	"backend/modules/payment/repository"
+	"backend/modules/payment/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"io"
+	"log"
+	"net/http"
+)
+
+const maxWebhookSize = 1 << 20
+
+// CreatePaymentHandler починає оплату замовлення й повертає redirect_url провайдера
+func CreatePaymentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	order, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return
+	}
+
+	var post models.PaymentPost
+	if err := ctx.ShouldBindJSON(&post); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	provider, ok := getProvider(ctx)
+	if !ok {
+		return
+	}
+
+	record, err := repository.CreatePayment(db, provider, order, post.ReturnURL)
+	if errors.Is(err, repository.ErrOrderNotPayable) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, record)
+}
+
+func GetOrderPaymentsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	order, ok := getAccessibleOrder(ctx)
+	if !ok {
+		return
+	}
+
+	payments, err := repository.GetOrderPayments(db, order.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": payments, "count": len(payments)})
+}
+
+// RefundPaymentHandler повертає кошти за платежем; доступно лише адміністраторам
+func RefundPaymentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.RequireAdmin(ctx, db)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		return
+	}
+
+	var post models.RefundPost
+	if err := ctx.ShouldBindJSON(&post); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	provider, ok := getProvider(ctx)
+	if !ok {
+		return
+	}
+
+	record, err := repository.RefundPayment(db, provider, id, &post, user.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		return
+	}
+	if errors.Is(err, repository.ErrNotRefundable) || errors.Is(err, repository.ErrRefundTooLarge) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, record)
+}
+
+// WebhookHandler приймає підписані події провайдера. Повторна доставка тієї самої події
+// отримує 200, щоб провайдер припинив спроби.
+func WebhookHandler(ctx *gin.Context) {
+	provider, ok := getProvider(ctx)
+	if !ok {
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := provider.VerifyWebhook(payload, ctx.Request.Header)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	handleEvent(ctx, provider.Name(), event)
+}
+
+// SimulatePaymentHandler надсилає підписану подію fake-провайдера через звичайний шлях
+// обробки вебхука. Доступно лише адміністраторам і лише з PAYMENT_PROVIDER=fake.
+func SimulatePaymentHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	provider, ok := getProvider(ctx)
+	if !ok {
+		return
+	}
+	fake, isFake := provider.(*payment.Fake)
+	if !isFake {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Simulation is only available with the fake provider"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		return
+	}
+
+	var post models.SimulatePost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	record, err := repository.GetPayment(db, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	event := payment.Event{
+		Type:      post.Type,
+		PaymentID: record.ProviderPaymentID,
+		Amount:    record.Amount,
+		Currency:  record.Currency,
+	}
+	if post.Type == payment.EventRefundSucceeded {
+		event.RefundID = "fake_ref_" + uuid.NewString()
+		event.Amount = record.Amount - record.RefundedAmount
+	}
+
+	payload, header, err := fake.Simulate(event)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	verified, err := fake.VerifyWebhook(payload, header)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	handleEvent(ctx, fake.Name(), verified)
+}
+
+func handleEvent(ctx *gin.Context, providerName string, event *payment.Event) {
+	db := postgres.DB
+	applied, err := repository.HandleEvent(db, providerName, event)
+	if errors.Is(err, payment.ErrUnknownPayment) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repository.ErrUnsupportedEvent) || errors.Is(err, repository.ErrAmountMismatch) ||
+		errors.Is(err, repository.ErrInvalidEvent) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Failed to handle payment event %s: %v", event.ID, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Оплачене замовлення отримує фактуру
+	if applied && event.Type == payment.EventPaymentSucceeded {
+		if record, err := repository.GetPaymentByProviderID(db, providerName, event.PaymentID); err == nil {
+			documentService.IssueInBackground(record.OrderID, documentModels.TypeInvoice)
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"id": event.ID, "duplicate": !applied})
+}
+
+func getProvider(ctx *gin.Context) (payment.Provider, bool) {
+	provider, err := service.Provider()
+	if err != nil {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return provider, true
+}
+
+// getAccessibleOrder завантажує замовлення :id, доступне власнику або адміністратору
+func getAccessibleOrder(ctx *gin.Context) (*orderModels.Order, bool) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, false
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return nil, false
+	}
+
+	order, err := orderRepo.GetOrder(db, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && order.UserID != user.ID && !user.IsAdmin && !user.IsSuperUser) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return order, true
+}
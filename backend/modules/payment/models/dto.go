@@ -0,0 +1,15 @@
+package models
+
+type PaymentPost struct {
+	ReturnURL string `json:"return_url"`
+}
+
+// RefundPost — без суми повертається весь залишок платежу
+type RefundPost struct {
+	Amount int64  `json:"amount" binding:"min=0"`
+	Reason string `json:"reason"`
+}
+
+type SimulatePost struct {
+	Type string `json:"type" binding:"required"`
+}
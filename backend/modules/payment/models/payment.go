@@ -0,0 +1,75 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusRefunded  Status = "refunded"
+)
+
+// Payment — спроба оплати замовлення через провайдера
+type Payment struct {
+	ID                uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID           uuid.UUID       `gorm:"type:uuid;not null;index" json:"order_id"`
+	Provider          string          `gorm:"type:varchar(32);not null;uniqueIndex:idx_payments_provider_payment" json:"provider"`
+	ProviderPaymentID string          `gorm:"type:varchar(255);not null;uniqueIndex:idx_payments_provider_payment" json:"provider_payment_id"`
+	Amount            int64           `gorm:"not null" json:"amount"`
+	Currency          string          `gorm:"type:varchar(3);not null" json:"currency"`
+	Status            Status          `gorm:"type:varchar(16);not null;default:'pending';index" json:"status"`
+	RefundedAmount    int64           `gorm:"not null;default:0" json:"refunded_amount"`
+	RedirectURL       string          `gorm:"type:text" json:"redirect_url"`
+	Refunds           []PaymentRefund `gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE" json:"refunds,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+type PaymentRefund struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	PaymentID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"payment_id"`
+	ProviderRefundID *string    `gorm:"type:varchar(255);index" json:"provider_refund_id"`
+	Amount           int64      `gorm:"not null" json:"amount"`
+	Reason           string     `gorm:"type:text" json:"reason"`
+	Status           Status     `gorm:"type:varchar(16);not null;default:'pending'" json:"status"`
+	UserID           *uuid.UUID `gorm:"type:uuid" json:"user_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// PaymentEvent — оброблена подія вебхука; унікальний ключ не дає обробити ту саму подію двічі
+type PaymentEvent struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Provider  string     `gorm:"type:varchar(32);not null;uniqueIndex:idx_payment_events_provider_event" json:"provider"`
+	EventID   string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_payment_events_provider_event" json:"event_id"`
+	Type      string     `gorm:"type:varchar(64);not null" json:"type"`
+	PaymentID *uuid.UUID `gorm:"type:uuid;index" json:"payment_id"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (payment *Payment) BeforeCreate(*gorm.DB) error {
+	if payment.ID == uuid.Nil {
+		payment.ID = uuid.New()
+	}
+	return nil
+}
+
+func (refund *PaymentRefund) BeforeCreate(*gorm.DB) error {
+	if refund.ID == uuid.Nil {
+		refund.ID = uuid.New()
+	}
+	return nil
+}
+
+func (event *PaymentEvent) BeforeCreate(*gorm.DB) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"backend/internal/services/payment"
+	orderModels "backend/modules/order/models"
+	orderRepo "backend/modules/order/repository"
+	"backend/modules/payment/models"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"strings"
+)
+
+var (
+	ErrOrderNotPayable  = errors.New("only new orders with a positive total can be paid")
+	ErrNotRefundable    = errors.New("only succeeded payments can be refunded")
+	ErrRefundTooLarge   = errors.New("the refund exceeds the remaining paid amount")
+	ErrAmountMismatch   = errors.New("the event amount does not match the payment")
+	ErrUnsupportedEvent = errors.New("unsupported webhook event type")
+	ErrInvalidEvent     = errors.New("the webhook event is incomplete")
+)
+
+// CreatePayment починає оплату замовлення. Незавершений платіж того самого провайдера
+// використовується повторно, щоб подвійне натискання не створювало двох сесій.
+func CreatePayment(db *gorm.DB, provider payment.Provider, order *orderModels.Order, returnURL string) (*models.Payment, error) {
+	if order.Status != orderModels.StatusNew || order.Total <= 0 {
+		return nil, ErrOrderNotPayable
+	}
+
+	var existing models.Payment
+	err := db.Where("order_id = ? AND provider = ? AND status = ? AND amount = ?",
+		order.ID, provider.Name(), models.StatusPending, order.Total).
+		Order("created_at DESC").
+		First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	session, err := provider.CreatePayment(context.Background(), payment.PaymentRequest{
+		Reference:   order.ID.String(),
+		Amount:      order.Total,
+		Currency:    order.Currency,
+		Email:       order.Email,
+		Description: fmt.Sprintf("Order #%d", order.Number),
+		ReturnURL:   returnURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.Payment{
+		OrderID:           order.ID,
+		Provider:          provider.Name(),
+		ProviderPaymentID: session.PaymentID,
+		Amount:            order.Total,
+		Currency:          order.Currency,
+		Status:            models.StatusPending,
+		RedirectURL:       session.RedirectURL,
+	}
+	// Провайдер повертає ту саму сесію для того самого Reference
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider"}, {Name: "provider_payment_id"}},
+		DoNothing: true,
+	}).Create(record).Error
+	if err != nil {
+		return nil, err
+	}
+	return GetPaymentByProviderID(db, provider.Name(), session.PaymentID)
+}
+
+func GetPayment(db *gorm.DB, id uuid.UUID) (*models.Payment, error) {
+	var record models.Payment
+	err := db.Preload("Refunds", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("id = ?", id).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func GetPaymentByProviderID(db *gorm.DB, provider string, providerPaymentId string) (*models.Payment, error) {
+	var record models.Payment
+	err := db.Where("provider = ? AND provider_payment_id = ?", provider, providerPaymentId).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func GetOrderPayments(db *gorm.DB, orderId uuid.UUID) ([]models.Payment, error) {
+	payments := []models.Payment{}
+	err := db.Preload("Refunds", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("order_id = ?", orderId).Order("created_at DESC").Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// HandleEvent застосовує перевірену подію вебхука. Подія записується з унікальним ключем
+// (провайдер, ID події) у тій самій транзакції, тож повторна доставка нічого не змінює.
+// Повертає false, якщо подію вже було оброблено.
+func HandleEvent(db *gorm.DB, providerName string, event *payment.Event) (bool, error) {
+	// Без ID подію не можна дедуплікувати
+	if event.ID == "" {
+		return false, fmt.Errorf("%w: missing event ID", ErrInvalidEvent)
+	}
+
+	applied := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var record models.Payment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("provider = ? AND provider_payment_id = ?", providerName, event.PaymentID).
+			First(&record).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return payment.ErrUnknownPayment
+		}
+		if err != nil {
+			return err
+		}
+
+		processed := &models.PaymentEvent{Provider: providerName, EventID: event.ID, Type: event.Type, PaymentID: &record.ID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(processed)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+
+		switch event.Type {
+		case payment.EventPaymentSucceeded:
+			return applySucceeded(tx, &record, event)
+		case payment.EventPaymentFailed:
+			if record.Status != models.StatusPending {
+				return nil
+			}
+			return tx.Model(&record).Update("status", models.StatusFailed).Error
+		case payment.EventRefundSucceeded:
+			return applyRefundEvent(tx, &record, event)
+		default:
+			return fmt.Errorf("%w: %q", ErrUnsupportedEvent, event.Type)
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+// applySucceeded позначає платіж успішним і переводить замовлення в paid.
+// Якщо замовлення тим часом скасовано, платіж лишається успішним — кошти слід повернути вручну.
+func applySucceeded(tx *gorm.DB, record *models.Payment, event *payment.Event) error {
+	if event.Amount == 0 || event.Currency == "" {
+		return fmt.Errorf("%w: amount and currency are required", ErrInvalidEvent)
+	}
+	if event.Amount != record.Amount || !strings.EqualFold(event.Currency, record.Currency) {
+		return fmt.Errorf("%w: %d %s ≠ %d %s", ErrAmountMismatch, event.Amount, event.Currency, record.Amount, record.Currency)
+	}
+	if record.Status != models.StatusPending && record.Status != models.StatusFailed {
+		return nil
+	}
+	if err := tx.Model(record).Update("status", models.StatusSucceeded).Error; err != nil {
+		return err
+	}
+
+	comment := fmt.Sprintf("payment %s via %s", record.ProviderPaymentID, record.Provider)
+	_, err := orderRepo.UpdateOrderStatus(tx, record.OrderID, orderModels.StatusPaid, nil, comment)
+	if errors.Is(err, orderRepo.ErrInvalidTransition) {
+		log.Printf("⚠️ Payment %s succeeded for order %s that can no longer be paid", record.ID, record.OrderID)
+		return nil
+	}
+	return err
+}
+
+// applyRefundEvent підтверджує повернення з вебхука. Вебхук може випередити відповідь
+// провайдера на RefundPayment, тому повернення шукається й за Reference. Невідоме
+// повернення (створене в кабінеті провайдера) додається до платежу.
+func applyRefundEvent(tx *gorm.DB, record *models.Payment, event *payment.Event) error {
+	if event.RefundID == "" {
+		return fmt.Errorf("%w: refund event without refund ID", ErrUnsupportedEvent)
+	}
+
+	var refund models.PaymentRefund
+	query := tx.Where("payment_id = ?", record.ID)
+	if reference, err := uuid.Parse(event.Reference); err == nil {
+		query = query.Where("provider_refund_id = ? OR id = ?", event.RefundID, reference)
+	} else {
+		query = query.Where("provider_refund_id = ?", event.RefundID)
+	}
+	err := query.First(&refund).Error
+	if err == nil && refund.ProviderRefundID == nil {
+		err = tx.Model(&refund).Update("provider_refund_id", event.RefundID).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		refundId := event.RefundID
+		refund = models.PaymentRefund{PaymentID: record.ID, ProviderRefundID: &refundId, Amount: event.Amount, Status: models.StatusPending}
+		if refund.Amount <= 0 {
+			refund.Amount = record.Amount - record.RefundedAmount
+		}
+		err = tx.Create(&refund).Error
+	}
+	if err != nil {
+		return err
+	}
+	return applyRefund(tx, record, &refund)
+}
+
+// applyRefund зараховує підтверджене повернення; повне повернення переводить замовлення в refunded
+func applyRefund(tx *gorm.DB, record *models.Payment, refund *models.PaymentRefund) error {
+	if refund.Status != models.StatusPending {
+		return nil
+	}
+	if err := tx.Model(refund).Update("status", models.StatusSucceeded).Error; err != nil {
+		return err
+	}
+
+	refunded := min(record.RefundedAmount+refund.Amount, record.Amount)
+	updates := map[string]interface{}{"refunded_amount": refunded}
+	if refunded == record.Amount {
+		updates["status"] = models.StatusRefunded
+	}
+	if err := tx.Model(record).Updates(updates).Error; err != nil {
+		return err
+	}
+	if refunded < record.Amount {
+		return nil
+	}
+
+	comment := fmt.Sprintf("refund of payment %s", record.ProviderPaymentID)
+	_, err := orderRepo.UpdateOrderStatus(tx, record.OrderID, orderModels.StatusRefunded, refund.UserID, comment)
+	if errors.Is(err, orderRepo.ErrInvalidTransition) {
+		return nil
+	}
+	return err
+}
+
+// RefundPayment повертає amount (0 — увесь залишок) через провайдера. Повернення спершу
+// записується як pending, а його ID передається провайдеру як ключ ідемпотентності.
+func RefundPayment(db *gorm.DB, provider payment.Provider, id uuid.UUID, post *models.RefundPost, userId uuid.UUID) (*models.Payment, error) {
+	var refund models.PaymentRefund
+	var record models.Payment
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&record).Error
+		if err != nil {
+			return err
+		}
+		if record.Status != models.StatusSucceeded || record.Provider != provider.Name() {
+			return ErrNotRefundable
+		}
+
+		var pending int64
+		err = tx.Model(&models.PaymentRefund{}).
+			Where("payment_id = ? AND status = ?", record.ID, models.StatusPending).
+			Select("COALESCE(SUM(amount), 0)").Scan(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		remaining := record.Amount - record.RefundedAmount - pending
+		amount := post.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount <= 0 || amount > remaining {
+			return ErrRefundTooLarge
+		}
+
+		refund = models.PaymentRefund{PaymentID: record.ID, Amount: amount, Reason: post.Reason, Status: models.StatusPending, UserID: &userId}
+		return tx.Create(&refund).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := provider.Refund(context.Background(), payment.RefundRequest{
+		Reference: refund.ID.String(),
+		PaymentID: record.ProviderPaymentID,
+		Amount:    refund.Amount,
+		Reason:    refund.Reason,
+	})
+	if err != nil {
+		if dbErr := db.Model(&refund).Update("status", models.StatusFailed).Error; dbErr != nil {
+			log.Printf("❌ Failed to mark refund %s as failed: %v", refund.ID, dbErr)
+		}
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&refund).Update("provider_refund_id", result.RefundID).Error; err != nil {
+			return err
+		}
+		if result.Status != payment.StatusSucceeded {
+			return nil
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", record.ID).First(&record).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", refund.ID).First(&refund).Error; err != nil {
+			return err
+		}
+		return applyRefund(tx, &record, &refund)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPayment(db, id)
+}
@@ -0,0 +1,22 @@
+package payment
+
+import (
+	"backend/modules/payment/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterWebhookRoutes — вебхук провайдера автентифікується підписом, а не токеном
+func RegisterWebhookRoutes(r *gin.RouterGroup) {
+	r.POST("/payments/webhook", handlers.WebhookHandler)
+}
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/orders/:id/payments", handlers.GetOrderPaymentsHandler)
+	r.POST("/orders/:id/payments", handlers.CreatePaymentHandler)
+
+	paymentGroup := r.Group("/payments")
+	{
+		paymentGroup.POST("/:id/refund", handlers.RefundPaymentHandler)
+		paymentGroup.POST("/:id/simulate", handlers.SimulatePaymentHandler)
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"backend/internal/services/payment"
+	"sync"
+)
+
+var (
+	provider     payment.Provider
+	providerErr  error
+	providerOnce sync.Once
+)
+
+// Provider повертає платіжного провайдера, налаштованого через змінні середовища
+func Provider() (payment.Provider, error) {
+	providerOnce.Do(func() {
+		provider, providerErr = payment.FromEnv()
+	})
+	return provider, providerErr
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
 	"backend/modules/property/models"
 	"backend/modules/property/repository"
 	"errors"
@@ -11,78 +12,114 @@ import (
 	"net/http"
 )
 
-func CreatePropertiesHandler(ctx *gin.Context) {
+func CreateAttributeHandler(ctx *gin.Context) {
 	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	var post models.AttributePost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	var property models.Property
-	if err := ctx.ShouldBindJSON(&property); err != nil {
+	attribute, err := repository.CreateAttribute(db, &post)
+	if errors.Is(err, repository.ErrAttributeCodeTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	newProps, err := repository.CreateProperty(db, &property)
+	ctx.JSON(http.StatusCreated, attribute)
+}
+
+func GetAttributesHandler(ctx *gin.Context) {
+	db := postgres.DB
+
+	var categoryId *uuid.UUID
+	if param := ctx.Query("category_id"); param != "" {
+		id, err := uuid.Parse(param)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return
+		}
+		categoryId = &id
+	}
+
+	attributes, err := repository.GetAttributes(db, categoryId)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, newProps)
+	ctx.JSON(http.StatusOK, attributes)
 }
 
-func GetPropertyByIDHandler(ctx *gin.Context) {
+func GetAttributeByIDHandler(ctx *gin.Context) {
 	db := postgres.DB
 	id, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid property ID"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attribute ID"})
 		return
 	}
 
-	property, err := repository.GetPropertyById(db, id)
+	attribute, err := repository.GetAttributeById(db, id)
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, property)
+	ctx.JSON(http.StatusOK, attribute)
 }
 
-func UpdatePropertyHandler(ctx *gin.Context) {
+func UpdateAttributeHandler(ctx *gin.Context) {
 	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
 	id, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid property ID"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attribute ID"})
 		return
 	}
 
-	var update models.PropertyUpdate
+	var update models.AttributeUpdate
 	if err := ctx.ShouldBindJSON(&update); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	updatedProperty, err := repository.UpdateProperty(db, id, &update)
+	attribute, err := repository.UpdateAttribute(db, id, &update)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Property not found"})
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Attribute not found"})
 		return
 	}
-
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, updatedProperty)
+	ctx.JSON(http.StatusOK, attribute)
 }
 
-func DeletePropertyHandler(ctx *gin.Context) {
+func DeleteAttributeHandler(ctx *gin.Context) {
 	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
 	id, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid property ID"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attribute ID"})
 		return
 	}
 
-	err = repository.DeleteProperty(db, id)
+	err = repository.DeleteAttribute(db, id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
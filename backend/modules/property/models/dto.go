@@ -2,28 +2,54 @@ package models
 
 import "github.com/google/uuid"
 
+type AttributePost struct {
+	CategoryID *uuid.UUID    `json:"category_id"`
+	Code       string        `json:"code" binding:"required"`
+	Name       string        `json:"name" binding:"required"`
+	Type       AttributeType `json:"type" binding:"required"`
+	Unit       string        `json:"unit"`
+	Options    []string      `json:"options"`
+	Required   bool          `json:"required"`
+	Min        *float64      `json:"min"`
+	Max        *float64      `json:"max"`
+	MaxLength  int           `json:"max_length"`
+	Pattern    string        `json:"pattern"`
+	Filterable *bool         `json:"filterable"`
+	Position   int           `json:"position"`
+}
+
+type AttributeUpdate struct {
+	Name       *string  `json:"name"`
+	Unit       *string  `json:"unit"`
+	Options    []string `json:"options"`
+	Required   *bool    `json:"required"`
+	Min        *float64 `json:"min"`
+	Max        *float64 `json:"max"`
+	MaxLength  *int     `json:"max_length"`
+	Pattern    *string  `json:"pattern"`
+	Filterable *bool    `json:"filterable"`
+	Position   *int     `json:"position"`
+}
+
+type AttributeGetAll struct {
+	Data  []*Attribute
+	Count int
+}
+
 type PropertyGet struct {
-	ID        uuid.UUID `json:"ID"`
-	Height    string    `json:"height"`
-	Width     string    `json:"width"`
-	Weight    string    `json:"weight"`
-	Color     string    `json:"color"`
-	Material  string    `json:"material"`
-	Brand     string    `json:"brand"`
-	Size      string    `json:"size"`
-	Motif     string    `json:"motif"`
-	Style     string    `json:"style"`
-	ContentID uuid.UUID `json:"content_id"`
+	AttributeID uuid.UUID     `json:"attribute_id"`
+	Code        string        `json:"code"`
+	Name        string        `json:"name"`
+	Type        AttributeType `json:"type"`
+	Unit        string        `json:"unit,omitempty"`
+	Value       interface{}   `json:"value"`
 }
 
-type PropertyUpdate struct {
-	Height   string `json:"height"`
-	Width    string `json:"width"`
-	Weight   string `json:"weight"`
-	Color    string `json:"color"`
-	Material string `json:"material"`
-	Brand    string `json:"brand"`
-	Size     string `json:"size"`
-	Motif    string `json:"motif"`
-	Style    string `json:"style"`
+// PropertyValuesPut — значення за кодом атрибута; null видаляє значення
+type PropertyValuesPut map[string]interface{}
+
+// FacetValue — кількість записів з певним значенням атрибута
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
 }
@@ -1,27 +1,75 @@
 package models
 
 import (
+	"backend/internal/entities"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"time"
 )
 
-type Property struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	Height    string    `gorm:"default:null" json:"height"`
-	Width     string    `gorm:"default:null" json:"width"`
-	Weight    string    `gorm:"default:null" json:"weight"`
-	Color     string    `gorm:"default:null" json:"color"`
-	Material  string    `gorm:"default:null" json:"material"`
-	Brand     string    `gorm:"default:null" json:"brand"`
-	Size      string    `gorm:"default:null" json:"size"`
-	Motif     string    `gorm:"default:null" json:"motif"`
-	Style     string    `gorm:"default:null" json:"style"`
-	ContentId uuid.UUID `gorm:"type:uuid;" json:"content_id"`
+type AttributeType string
+
+const (
+	AttributeNumber  AttributeType = "number"
+	AttributeEnum    AttributeType = "enum"
+	AttributeText    AttributeType = "text"
+	AttributeBoolean AttributeType = "boolean"
+)
+
+// Attribute — опис типізованої властивості; без CategoryID діє для всіх категорій.
+// Code унікальний у межах категорії (індекс idx_attributes_category_code створює міграція)
+type Attribute struct {
+	ID         uuid.UUID           `gorm:"type:uuid;primaryKey" json:"id"`
+	CategoryID *uuid.UUID          `gorm:"type:uuid;index" json:"category_id"`
+	Code       string              `gorm:"not null;index" json:"code"`
+	Name       string              `gorm:"not null" json:"name"`
+	Type       AttributeType       `gorm:"type:varchar(20);not null" json:"type"`
+	Unit       string              `gorm:"default:null" json:"unit"`
+	Options    entities.StringList `json:"options"`
+	Required   bool                `gorm:"default:false" json:"required"`
+	Min        *float64            `json:"min"`
+	Max        *float64            `json:"max"`
+	MaxLength  int                 `gorm:"default:0" json:"max_length"`
+	Pattern    string              `gorm:"default:null" json:"pattern"`
+	Filterable bool                `gorm:"default:true" json:"filterable"`
+	Position   int                 `gorm:"default:0" json:"position"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// PropertyValue — значення атрибута для контенту (група перекладів товару), одна колонка на тип
+type PropertyValue struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ContentID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_property_value_content_attribute" json:"content_id"`
+	AttributeID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_property_value_content_attribute;index" json:"attribute_id"`
+	NumberValue *float64  `json:"number_value"`
+	TextValue   *string   `gorm:"index" json:"text_value"`
+	BoolValue   *bool     `json:"bool_value"`
+}
+
+func (attribute *Attribute) BeforeCreate(*gorm.DB) error {
+	if attribute.ID == uuid.Nil {
+		attribute.ID = uuid.New()
+	}
+	return nil
+}
+
+func (value *PropertyValue) BeforeCreate(*gorm.DB) error {
+	if value.ID == uuid.Nil {
+		value.ID = uuid.New()
+	}
+	return nil
 }
 
-func (property *Property) BeforeCreate(*gorm.DB) error {
-	if property.ID != uuid.Nil {
-		property.ID = uuid.New()
+// Interface повертає значення у вигляді, придатному для JSON-відповіді
+func (value *PropertyValue) Interface() interface{} {
+	switch {
+	case value.NumberValue != nil:
+		return *value.NumberValue
+	case value.BoolValue != nil:
+		return *value.BoolValue
+	case value.TextValue != nil:
+		return *value.TextValue
 	}
 	return nil
 }
@@ -2,138 +2,417 @@ package repository
 
 import (
 	"backend/internal/repository"
+	categoryRepo "backend/modules/category/repository"
 	"backend/modules/property/models"
+	"backend/modules/property/service"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strconv"
+	"strings"
 )
 
-func CreateProperty(db *gorm.DB, c *models.Property) (*models.PropertyGet, error) {
-	err := repository.CreateEssence(db, c)
-	if err != nil {
+var (
+	ErrAttributeCodeTaken = errors.New("an attribute with this code already exists in the category")
+	// ErrInvalidFilter — помилка в параметрах фільтра від клієнта (невідомий атрибут чи значення)
+	ErrInvalidFilter = errors.New("invalid filter")
+)
+
+func CreateAttribute(db *gorm.DB, post *models.AttributePost) (*models.Attribute, error) {
+	attribute := &models.Attribute{
+		CategoryID: post.CategoryID,
+		Code:       strings.ToLower(strings.TrimSpace(post.Code)),
+		Name:       post.Name,
+		Type:       post.Type,
+		Unit:       post.Unit,
+		Options:    post.Options,
+		Required:   post.Required,
+		Min:        post.Min,
+		Max:        post.Max,
+		MaxLength:  post.MaxLength,
+		Pattern:    post.Pattern,
+		Filterable: true,
+		Position:   post.Position,
+	}
+	if post.Filterable != nil {
+		attribute.Filterable = *post.Filterable
+	}
+
+	if err := service.ValidateAttribute(attribute); err != nil {
 		return nil, err
 	}
-	return &models.PropertyGet{
-		ID:        c.ID,
-		Height:    c.Height,
-		Width:     c.Width,
-		Weight:    c.Weight,
-		Color:     c.Color,
-		Material:  c.Material,
-		Brand:     c.Brand,
-		Size:      c.Size,
-		Motif:     c.Motif,
-		Style:     c.Style,
-		ContentID: c.ContentId,
-	}, nil
+
+	query := db.Model(&models.Attribute{}).Where("code = ?", attribute.Code)
+	if attribute.CategoryID == nil {
+		query = query.Where("category_id IS NULL")
+	} else {
+		query = query.Where("category_id = ?", *attribute.CategoryID)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrAttributeCodeTaken
+	}
+
+	if err := repository.CreateEssence(db, attribute); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, ErrAttributeCodeTaken
+		}
+		return nil, err
+	}
+	return attribute, nil
 }
 
-func GetPropertyById(db *gorm.DB, id uuid.UUID) (*models.PropertyGet, error) {
-	var property models.Property
-	err := repository.GetByID(db, id, &property)
+func GetAttributeById(db *gorm.DB, id uuid.UUID) (*models.Attribute, error) {
+	var attribute models.Attribute
+	err := repository.GetByID(db, id, &attribute)
 	if err != nil {
 		return nil, err
 	}
-	return &models.PropertyGet{
-		ID:        property.ID,
-		Height:    property.Height,
-		Width:     property.Width,
-		Weight:    property.Weight,
-		Color:     property.Color,
-		Material:  property.Material,
-		Brand:     property.Brand,
-		Size:      property.Size,
-		Motif:     property.Motif,
-		Style:     property.Style,
-		ContentID: property.ContentId,
-	}, nil
+	return &attribute, nil
 }
 
-func GetPropertyByItemId(db *gorm.DB, itemid uuid.UUID) (*models.PropertyGet, error) {
-	var property models.Property
-	err := repository.GetAllContentByID(db, itemid, &property)
-	if err != nil {
-		// Якщо запис не знайдено — повертаємо пусту структуру без помилки
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return &models.PropertyGet{}, nil
+// GetAttributes повертає схему атрибутів: для категорії — глобальні, власні та успадковані від предків
+func GetAttributes(db *gorm.DB, categoryId *uuid.UUID) (*models.AttributeGetAll, error) {
+	var attributes []*models.Attribute
+
+	query := db.Order("position ASC, code ASC")
+	if categoryId != nil {
+		categoryIDs, err := categoryRepo.GetAncestorIDs(db, *categoryId)
+		if err != nil {
+			return nil, err
 		}
-		// Якщо якась інша помилка — повертаємо її
+		query = query.Where("category_id IS NULL OR category_id IN (?)", categoryIDs)
+	}
+
+	if err := query.Find(&attributes).Error; err != nil {
 		return nil, err
 	}
-	return &models.PropertyGet{
-		ID:        property.ID,
-		Height:    property.Height,
-		Width:     property.Width,
-		Weight:    property.Weight,
-		Color:     property.Color,
-		Material:  property.Material,
-		Brand:     property.Brand,
-		Size:      property.Size,
-		Motif:     property.Motif,
-		Style:     property.Style,
-		ContentID: property.ContentId,
-	}, nil
+	return &models.AttributeGetAll{Data: attributes, Count: len(attributes)}, nil
 }
 
-func UpdateProperty(db *gorm.DB, id uuid.UUID, update *models.PropertyUpdate) (*models.PropertyGet, error) {
-	var property models.Property
-	err := repository.GetByID(db, id, &property)
+func UpdateAttribute(db *gorm.DB, id uuid.UUID, update *models.AttributeUpdate) (*models.Attribute, error) {
+	attribute, err := GetAttributeById(db, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if update.Height != "" {
-		property.Height = update.Height
+	if update.Name != nil {
+		attribute.Name = *update.Name
 	}
-	if update.Width != "" {
-		property.Width = update.Width
+	if update.Unit != nil {
+		attribute.Unit = *update.Unit
 	}
-	if update.Weight != "" {
-		property.Weight = update.Weight
+	if update.Options != nil {
+		attribute.Options = update.Options
 	}
-	if update.Color != "" {
-		property.Color = update.Color
+	if update.Required != nil {
+		attribute.Required = *update.Required
 	}
-	if update.Material != "" {
-		property.Material = update.Material
+	if update.Min != nil {
+		attribute.Min = update.Min
 	}
-	if update.Brand != "" {
-		property.Brand = update.Brand
+	if update.Max != nil {
+		attribute.Max = update.Max
 	}
-	if update.Size != "" {
-		property.Size = update.Size
+	if update.MaxLength != nil {
+		attribute.MaxLength = *update.MaxLength
 	}
-	if update.Motif != "" {
-		property.Motif = update.Motif
+	if update.Pattern != nil {
+		attribute.Pattern = *update.Pattern
 	}
-	if update.Style != "" {
-		property.Style = update.Style
+	if update.Filterable != nil {
+		attribute.Filterable = *update.Filterable
+	}
+	if update.Position != nil {
+		attribute.Position = *update.Position
 	}
 
-	err = db.Save(&property).Error
-	if err != nil {
+	if err = service.ValidateAttribute(attribute); err != nil {
+		return nil, err
+	}
+
+	if err = db.Save(attribute).Error; err != nil {
 		return nil, err
 	}
+	return attribute, nil
+}
 
-	return &models.PropertyGet{
-		ID:        property.ID,
-		Height:    property.Height,
-		Width:     property.Width,
-		Weight:    property.Weight,
-		Color:     property.Color,
-		Material:  property.Material,
-		Brand:     property.Brand,
-		Size:      property.Size,
-		Motif:     property.Motif,
-		Style:     property.Style,
-		ContentID: property.ContentId,
-	}, nil
+func DeleteAttribute(db *gorm.DB, id uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attribute_id = ?", id).Delete(&models.PropertyValue{}).Error; err != nil {
+			return err
+		}
+		return repository.DeleteByID(tx, id, &models.Attribute{})
+	})
 }
 
-func DeleteProperty(db *gorm.DB, id uuid.UUID) error {
-	err := repository.DeleteByID(db, id, &models.Property{})
-	if errors.Is(err, gorm.ErrRecordNotFound) {
+// SetPropertyValues перевіряє та зберігає значення властивостей контенту за схемою його категорії
+func SetPropertyValues(db *gorm.DB, contentId uuid.UUID, categoryId *uuid.UUID, values models.PropertyValuesPut) ([]models.PropertyGet, error) {
+	schema, err := GetAttributes(db, categoryId)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := attributesByCode(db, categoryId, schema.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var toSave []*models.PropertyValue
+	var toDelete []uuid.UUID
+	var problems []string
+	for code, raw := range values {
+		attribute, ok := attributes[code]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: attribute is not defined for this category", code))
+			continue
+		}
+		if raw == nil {
+			toDelete = append(toDelete, attribute.ID)
+			continue
+		}
+		value, err := service.BuildValue(attribute, raw)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		value.ContentID = contentId
+		toSave = append(toSave, value)
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if len(toDelete) > 0 {
+			err := tx.Where("content_id = ? AND attribute_id IN (?)", contentId, toDelete).
+				Delete(&models.PropertyValue{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, value := range toSave {
+			err := tx.Where("content_id = ? AND attribute_id = ?", contentId, value.AttributeID).
+				Delete(&models.PropertyValue{}).Error
+			if err != nil {
+				return err
+			}
+			if err = tx.Create(value).Error; err != nil {
+				return err
+			}
+		}
+
+		// Обов'язкові атрибути перевіряємо після застосування змін
+		for _, attribute := range schema.Data {
+			if !attribute.Required {
+				continue
+			}
+			var count int64
+			err := tx.Model(&models.PropertyValue{}).
+				Where("content_id = ? AND attribute_id = ?", contentId, attribute.ID).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return fmt.Errorf("%s: value is required", attribute.Code)
+			}
+		}
 		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	valuesMap, err := GetPropertyValues(db, []uuid.UUID{contentId})
+	if err != nil {
+		return nil, err
+	}
+	return valuesMap[contentId], nil
+}
+
+// GetPropertyValues повертає властивості кількох записів одним запитом з join на атрибути
+func GetPropertyValues(db *gorm.DB, contentIds []uuid.UUID) (map[uuid.UUID][]models.PropertyGet, error) {
+	valuesMap := make(map[uuid.UUID][]models.PropertyGet)
+	if len(contentIds) == 0 {
+		return valuesMap, nil
+	}
+
+	var rows []struct {
+		models.PropertyValue
+		Code string
+		Name string
+		Type models.AttributeType
+		Unit string
+	}
+	err := db.Table("property_values pv").
+		Select("pv.*, a.code, a.name, a.type, a.unit").
+		Joins("JOIN attributes a ON a.id = pv.attribute_id").
+		Where("pv.content_id IN (?)", contentIds).
+		Order("a.position ASC, a.code ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		valuesMap[row.ContentID] = append(valuesMap[row.ContentID], models.PropertyGet{
+			AttributeID: row.AttributeID,
+			Code:        row.Code,
+			Name:        row.Name,
+			Type:        row.Type,
+			Unit:        row.Unit,
+			Value:       row.PropertyValue.Interface(),
+		})
+	}
+	return valuesMap, nil
+}
+
+func DeletePropertyValues(db *gorm.DB, contentId uuid.UUID) error {
+	return db.Where("content_id = ?", contentId).Delete(&models.PropertyValue{}).Error
+}
+
+// attributesByCode індексує схему за кодом. Якщо код є на кількох рівнях, перемагає атрибут
+// найближчої категорії: власної, потім батьківської і так далі, глобальний — останнім
+func attributesByCode(db *gorm.DB, categoryId *uuid.UUID, schema []*models.Attribute) (map[string]*models.Attribute, error) {
+	depth := map[uuid.UUID]int{}
+	if categoryId != nil {
+		categoryIDs, err := categoryRepo.GetAncestorIDs(db, *categoryId)
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range categoryIDs {
+			depth[id] = i
+		}
+	}
+	rank := func(attribute *models.Attribute) int {
+		if attribute.CategoryID == nil {
+			return len(depth)
+		}
+		return depth[*attribute.CategoryID]
+	}
+
+	attributes := make(map[string]*models.Attribute, len(schema))
+	for _, attribute := range schema {
+		if current, ok := attributes[attribute.Code]; !ok || rank(attribute) < rank(current) {
+			attributes[attribute.Code] = attribute
+		}
+	}
+	return attributes, nil
+}
+
+// ApplyAttributeFilters додає умови за значеннями атрибутів: OR в межах одного атрибута, AND між атрибутами.
+// Для числових атрибутів значення може бути діапазоном "min..max" (будь-яку межу можна пропустити).
+// Код може належати атрибутам кількох категорій — умова перевіряє кожен з них.
+// Фільтрувати можна лише за атрибутами з Filterable; помилки у фільтрах від клієнта обгорнуті в ErrInvalidFilter
+func ApplyAttributeFilters(db *gorm.DB, query *gorm.DB, contentColumn string, filters map[string][]string) (*gorm.DB, error) {
+	for code, values := range filters {
+		if len(values) == 0 {
+			continue
+		}
+
+		var attributes []models.Attribute
+		if err := db.Where("code = ? AND filterable = ?", code, true).Find(&attributes).Error; err != nil {
+			return nil, err
+		}
+		if len(attributes) == 0 {
+			return nil, fmt.Errorf("%w: unknown or non-filterable attribute %q", ErrInvalidFilter, code)
+		}
+
+		var conditions []string
+		var args []interface{}
+		for i := range attributes {
+			var valueConditions []string
+			args = append(args, attributes[i].ID)
+			for _, value := range values {
+				condition, conditionArgs, err := valueCondition(&attributes[i], value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+				}
+				valueConditions = append(valueConditions, condition)
+				args = append(args, conditionArgs...)
+			}
+			conditions = append(conditions, "(pv.attribute_id = ? AND ("+strings.Join(valueConditions, " OR ")+"))")
+		}
+
+		sql := fmt.Sprintf(`EXISTS (SELECT 1 FROM property_values pv
+			WHERE pv.content_id = %s AND (%s))`,
+			contentColumn, strings.Join(conditions, " OR "))
+		query = query.Where(sql, args...)
+	}
+	return query, nil
+}
+
+func valueCondition(attribute *models.Attribute, value string) (string, []interface{}, error) {
+	switch attribute.Type {
+	case models.AttributeNumber:
+		if from, to, found := strings.Cut(value, ".."); found {
+			var conditions []string
+			var args []interface{}
+			if from != "" {
+				number, err := strconv.ParseFloat(from, 64)
+				if err != nil {
+					return "", nil, fmt.Errorf("%s: invalid range %q", attribute.Code, value)
+				}
+				conditions = append(conditions, "pv.number_value >= ?")
+				args = append(args, number)
+			}
+			if to != "" {
+				number, err := strconv.ParseFloat(to, 64)
+				if err != nil {
+					return "", nil, fmt.Errorf("%s: invalid range %q", attribute.Code, value)
+				}
+				conditions = append(conditions, "pv.number_value <= ?")
+				args = append(args, number)
+			}
+			if len(conditions) == 0 {
+				return "pv.number_value IS NOT NULL", nil, nil
+			}
+			return "(" + strings.Join(conditions, " AND ") + ")", args, nil
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: invalid number %q", attribute.Code, value)
+		}
+		return "pv.number_value = ?", []interface{}{number}, nil
+
+	case models.AttributeBoolean:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: invalid boolean %q", attribute.Code, value)
+		}
+		return "pv.bool_value = ?", []interface{}{flag}, nil
+	}
+
+	return "pv.text_value = ?", []interface{}{value}, nil
+}
+
+// GetFacetCounts рахує кількість записів вибірки для кожного значення атрибута.
+// Невідомі та нефільтровані атрибути пропускаються — повертається nil. Запис рахується один раз,
+// навіть якщо код є в атрибутах кількох рівнів категорій.
+func GetFacetCounts(db *gorm.DB, query *gorm.DB, contentColumn string, code string) ([]models.FacetValue, error) {
+	var attributeIDs []uuid.UUID
+	err := db.Model(&models.Attribute{}).Where("code = ? AND filterable = ?", code, true).Pluck("id", &attributeIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(attributeIDs) == 0 {
+		return nil, nil
+	}
+
+	var facets []models.FacetValue
+	err = query.
+		Joins(fmt.Sprintf("JOIN property_values fpv ON fpv.content_id = %s AND fpv.attribute_id IN (?)", contentColumn), attributeIDs).
+		Select(fmt.Sprintf("COALESCE(fpv.text_value, fpv.number_value::text, fpv.bool_value::text) AS value, COUNT(DISTINCT %s) AS count", contentColumn)).
+		Group("value").
+		Order("count DESC, value ASC").
+		Scan(&facets).Error
+	if err != nil {
+		return nil, err
 	}
-	return err
+	return facets, nil
 }
@@ -8,10 +8,10 @@ import (
 func RegisterRoutes(r *gin.RouterGroup) {
 	propertyGroup := r.Group("/properties")
 	{
-		propertyGroup.POST("/", handlers.CreatePropertiesHandler)
-		propertyGroup.GET("/:id", handlers.GetPropertyByIDHandler)
-		propertyGroup.PATCH("/:id", handlers.UpdatePropertyHandler)
-		//propertyGroup.GET("/", handlers.GetAllPropertiesHandler)
-		propertyGroup.DELETE("/:id", handlers.DeletePropertyHandler)
+		propertyGroup.POST("/attributes", handlers.CreateAttributeHandler)
+		propertyGroup.GET("/attributes", handlers.GetAttributesHandler)
+		propertyGroup.GET("/attributes/:id", handlers.GetAttributeByIDHandler)
+		propertyGroup.PATCH("/attributes/:id", handlers.UpdateAttributeHandler)
+		propertyGroup.DELETE("/attributes/:id", handlers.DeleteAttributeHandler)
 	}
 }
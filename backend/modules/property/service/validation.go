@@ -0,0 +1,121 @@
+package service
+
+import (
+	"backend/modules/property/models"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateAttribute перевіряє коректність опису атрибута
+func ValidateAttribute(attribute *models.Attribute) error {
+	switch attribute.Type {
+	case models.AttributeNumber, models.AttributeText, models.AttributeBoolean:
+	case models.AttributeEnum:
+		if len(attribute.Options) == 0 {
+			return fmt.Errorf("enum attribute %q must have options", attribute.Code)
+		}
+	default:
+		return fmt.Errorf("unknown attribute type %q", attribute.Type)
+	}
+
+	if attribute.Min != nil && attribute.Max != nil && *attribute.Min > *attribute.Max {
+		return fmt.Errorf("attribute %q: min cannot be greater than max", attribute.Code)
+	}
+	if attribute.Pattern != "" {
+		if _, err := regexp.Compile(attribute.Pattern); err != nil {
+			return fmt.Errorf("attribute %q: invalid pattern: %v", attribute.Code, err)
+		}
+	}
+	return nil
+}
+
+// BuildValue перевіряє сире значення з JSON за правилами атрибута і повертає типізоване значення
+func BuildValue(attribute *models.Attribute, raw interface{}) (*models.PropertyValue, error) {
+	value := &models.PropertyValue{AttributeID: attribute.ID}
+
+	switch attribute.Type {
+	case models.AttributeNumber:
+		number, err := toNumber(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", attribute.Code, err)
+		}
+		if attribute.Min != nil && number < *attribute.Min {
+			return nil, fmt.Errorf("%s: value must be at least %v", attribute.Code, *attribute.Min)
+		}
+		if attribute.Max != nil && number > *attribute.Max {
+			return nil, fmt.Errorf("%s: value must be at most %v", attribute.Code, *attribute.Max)
+		}
+		value.NumberValue = &number
+
+	case models.AttributeEnum:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: value must be a string", attribute.Code)
+		}
+		if !contains(attribute.Options, text) {
+			return nil, fmt.Errorf("%s: value must be one of %s", attribute.Code, strings.Join(attribute.Options, ", "))
+		}
+		value.TextValue = &text
+
+	case models.AttributeText:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: value must be a string", attribute.Code)
+		}
+		if attribute.MaxLength > 0 && utf8.RuneCountInString(text) > attribute.MaxLength {
+			return nil, fmt.Errorf("%s: value is longer than %d characters", attribute.Code, attribute.MaxLength)
+		}
+		if attribute.Pattern != "" {
+			matched, err := regexp.MatchString(attribute.Pattern, text)
+			if err != nil || !matched {
+				return nil, fmt.Errorf("%s: value does not match the required format", attribute.Code)
+			}
+		}
+		value.TextValue = &text
+
+	case models.AttributeBoolean:
+		flag, ok := raw.(bool)
+		// Значення з CSV/XLSX приходять рядками
+		if text, isText := raw.(string); isText {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(text))
+			flag, ok = parsed, err == nil
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: value must be a boolean", attribute.Code)
+		}
+		value.BoolValue = &flag
+
+	default:
+		return nil, fmt.Errorf("%s: unknown attribute type %q", attribute.Code, attribute.Type)
+	}
+
+	return value, nil
+}
+
+func toNumber(raw interface{}) (float64, error) {
+	switch v := raw.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case string:
+		number, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", "."), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value must be a number")
+		}
+		return number, nil
+	}
+	return 0, fmt.Errorf("value must be a number")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
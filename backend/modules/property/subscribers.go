@@ -0,0 +1,17 @@
+package property
+
+import (
+	"backend/internal/services/events"
+	"backend/modules/property/repository"
+	"gorm.io/gorm"
+)
+
+func RegisterSubscribers(bus *events.Bus) {
+	// Значення властивостей товару спільні для групи перекладів і зникають разом з останньою версією
+	events.Subscribe(bus, func(tx *gorm.DB, event events.ContentDeleted) error {
+		if event.Entity != events.EntityItems || !event.LastTranslation {
+			return nil
+		}
+		return repository.DeletePropertyValues(tx, event.TranslationGroupID)
+	})
+}
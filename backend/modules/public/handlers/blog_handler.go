@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	"backend/internal/services/httpcache"
+	blogHandlers "backend/modules/blog/handlers"
+	blogRepo "backend/modules/blog/repository"
+	"backend/modules/public/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// GetBlogsHandler — опубліковані блоги з фільтрами ?language=, ?tags=, ?skip=, ?limit=
+func GetBlogsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	params, ok := blogHandlers.ParseBlogParameters(ctx)
+	if !ok {
+		return
+	}
+	params.State = entities.StatePublished
+
+	blogs, err := blogRepo.GetAllBlogs(db, uuid.Nil, true, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := &models.BlogPublicList{
+		Data:  make([]*models.BlogPublic, 0, len(blogs.Data)),
+		Count: blogs.Count,
+		Total: blogs.Total,
+		Tags:  blogs.Tags,
+	}
+	var lastModified time.Time
+	for _, blog := range blogs.Data {
+		response.Data = append(response.Data, models.NewBlogPublic(blog))
+		if blog.UpdatedAt.After(lastModified) {
+			lastModified = blog.UpdatedAt
+		}
+	}
+
+	httpcache.JSON(ctx, lastModified, response)
+}
+
+func GetBlogHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blog ID"})
+		return
+	}
+
+	blog, err := blogRepo.GetBlogById(db, id)
+	if err != nil || !blog.IsPublished() {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
+	httpcache.JSON(ctx, blog.UpdatedAt, models.NewBlogPublic(blog))
+}
+
+// GetBlogBySlugHandler — блог за slug; застарілий slug перенаправляє на актуальний
+func GetBlogBySlugHandler(ctx *gin.Context) {
+	db := postgres.DB
+	language := ctx.Param("language")
+
+	blog, redirected, err := blogRepo.GetBlogBySlug(db, language, ctx.Param("slug"))
+	if err != nil || !blog.IsPublished() {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
+	if redirected && blog.Slug != nil {
+		ctx.Redirect(http.StatusMovedPermanently, "/public/v1/blog/slug/"+language+"/"+*blog.Slug)
+		return
+	}
+
+	httpcache.JSON(ctx, blog.UpdatedAt, models.NewBlogPublic(blog))
+}
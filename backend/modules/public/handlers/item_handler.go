@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	"backend/internal/entities"
+	"backend/internal/services/httpcache"
+	itemHandlers "backend/modules/item/handlers"
+	itemRepo "backend/modules/item/repository"
+	propRepo "backend/modules/property/repository"
+	"backend/modules/public/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// GetItemsHandler — опубліковані товари з тими самими фільтрами, що й в адмінському списку
+func GetItemsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	params, ok := itemHandlers.ParseItemParameters(ctx)
+	if !ok {
+		return
+	}
+	params.State = entities.StatePublished
+
+	items, err := itemRepo.GetAllItems(db, uuid.Nil, true, params)
+	if errors.Is(err, propRepo.ErrInvalidFilter) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := &models.ItemPublicList{
+		Data:   make([]*models.ItemPublic, 0, len(items.Data)),
+		Count:  items.Count,
+		Total:  items.Total,
+		Tags:   items.Tags,
+		Facets: items.Facets,
+	}
+	var lastModified time.Time
+	for _, item := range items.Data {
+		response.Data = append(response.Data, models.NewItemPublic(item))
+		if item.UpdatedAt.After(lastModified) {
+			lastModified = item.UpdatedAt
+		}
+	}
+
+	httpcache.JSON(ctx, lastModified, response)
+}
+
+func GetItemHandler(ctx *gin.Context) {
+	db := postgres.DB
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	item, err := itemRepo.GetItemById(db, id)
+	if err != nil || !item.IsPublished() {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+
+	httpcache.JSON(ctx, item.UpdatedAt, models.NewItemPublic(item))
+}
+
+// GetItemBySlugHandler — товар за slug; застарілий slug перенаправляє на актуальний
+func GetItemBySlugHandler(ctx *gin.Context) {
+	db := postgres.DB
+	language := ctx.Param("language")
+
+	item, redirected, err := itemRepo.GetItemBySlug(db, language, ctx.Param("slug"))
+	if err != nil || !item.IsPublished() {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+
+	if redirected && item.Slug != nil {
+		ctx.Redirect(http.StatusMovedPermanently, "/public/v1/items/slug/"+language+"/"+*item.Slug)
+		return
+	}
+
+	httpcache.JSON(ctx, item.UpdatedAt, models.NewItemPublic(item))
+}
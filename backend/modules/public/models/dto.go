@@ -0,0 +1,113 @@
+package models
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/money"
+	blogModels "backend/modules/blog/models"
+	itemModels "backend/modules/item/models"
+	propertyModels "backend/modules/property/models"
+	tagModels "backend/modules/tag/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ItemPublic — опублікований товар без службових полів (власник, поріг залишку, стан публікації)
+type ItemPublic struct {
+	ID                 uuid.UUID                    `json:"id"`
+	Title              string                       `json:"title"`
+	Content            string                       `json:"content"`
+	Price              int64                        `json:"price"`
+	Currency           string                       `json:"currency"`
+	Prices             []money.Money                `json:"prices"`
+	Quantity           int                          `json:"quantity"`
+	Language           string                       `json:"language"`
+	Sku                *string                      `json:"sku"`
+	Slug               *string                      `json:"slug"`
+	ItemUrl            string                       `json:"item_url"`
+	CategoryID         *uuid.UUID                   `json:"category_id"`
+	Properties         []propertyModels.PropertyGet `json:"properties"`
+	TranslationGroupID uuid.UUID                    `json:"translation_group_id"`
+	Images             []string                     `json:"images"`
+	Tags               []tagModels.TagGet           `json:"tags"`
+	PublishedAt        *time.Time                   `json:"published_at"`
+	UpdatedAt          time.Time                    `json:"updated_at"`
+	entities.SEO
+}
+
+type ItemPublicList struct {
+	Data   []*ItemPublic          `json:"data"`
+	Count  int                    `json:"count"`
+	Total  int64                  `json:"total"`
+	Tags   []tagModels.TagGet     `json:"tags"`
+	Facets *itemModels.ItemFacets `json:"facets,omitempty"`
+}
+
+// BlogPublic — опублікований блог; content містить відрендерений і санітизований HTML
+type BlogPublic struct {
+	ID                 uuid.UUID          `json:"id"`
+	Title              string             `json:"title"`
+	Content            string             `json:"content"`
+	Excerpt            string             `json:"excerpt"`
+	ReadingTime        int                `json:"reading_time"`
+	TOC                entities.Headings  `json:"toc"`
+	Language           string             `json:"language"`
+	Slug               *string            `json:"slug"`
+	TranslationGroupID uuid.UUID          `json:"translation_group_id"`
+	CommentsEnabled    bool               `json:"comments_enabled"`
+	Images             []string           `json:"images"`
+	Tags               []tagModels.TagGet `json:"tags"`
+	PublishedAt        *time.Time         `json:"published_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+	entities.SEO
+}
+
+type BlogPublicList struct {
+	Data  []*BlogPublic      `json:"data"`
+	Count int                `json:"count"`
+	Total int64              `json:"total"`
+	Tags  []tagModels.TagGet `json:"tags"`
+}
+
+func NewItemPublic(item *itemModels.ItemGet) *ItemPublic {
+	return &ItemPublic{
+		ID:                 item.ID,
+		Title:              item.Title,
+		Content:            item.Content,
+		Price:              item.Price,
+		Currency:           item.Currency,
+		Prices:             item.Prices,
+		Quantity:           item.Quantity,
+		Language:           item.Language,
+		Sku:                item.Sku,
+		Slug:               item.Slug,
+		ItemUrl:            item.ItemUrl,
+		CategoryID:         item.CategoryID,
+		Properties:         item.Properties,
+		TranslationGroupID: item.TranslationGroupID,
+		Images:             item.Images,
+		Tags:               item.Tags,
+		PublishedAt:        item.PublishAt,
+		UpdatedAt:          item.UpdatedAt,
+		SEO:                item.SEO,
+	}
+}
+
+func NewBlogPublic(blog *blogModels.BlogGet) *BlogPublic {
+	return &BlogPublic{
+		ID:                 blog.ID,
+		Title:              blog.Title,
+		Content:            blog.ContentHTML,
+		Excerpt:            blog.Excerpt,
+		ReadingTime:        blog.ReadingTime,
+		TOC:                blog.TOC,
+		Language:           blog.Language,
+		Slug:               blog.Slug,
+		TranslationGroupID: blog.TranslationGroupID,
+		CommentsEnabled:    blog.CommentsEnabled,
+		Images:             blog.Images,
+		Tags:               blog.Tags,
+		PublishedAt:        blog.PublishAt,
+		UpdatedAt:          blog.UpdatedAt,
+		SEO:                blog.SEO,
+	}
+}
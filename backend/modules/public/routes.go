@@ -0,0 +1,40 @@
+package public
+
+import (
+	"backend/internal/middleware"
+	"backend/modules/public/handlers"
+	"github.com/gin-gonic/gin"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultRateLimit = 120
+
+// RateLimit — кількість запитів за хвилину з однієї IP-адреси з PUBLIC_RATE_LIMIT
+func RateLimit() int {
+	value := os.Getenv("PUBLIC_RATE_LIMIT")
+	if value == "" {
+		return defaultRateLimit
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		log.Printf("⚠️ Invalid PUBLIC_RATE_LIMIT %q, using %d", value, defaultRateLimit)
+		return defaultRateLimit
+	}
+	return limit
+}
+
+// RegisterRoutes — API лише для читання опублікованого контенту, доступне без токена
+func RegisterRoutes(r *gin.RouterGroup) {
+	publicGroup := r.Group("/public/v1", middleware.RateLimiter(RateLimit(), time.Minute))
+	{
+		publicGroup.GET("/items", handlers.GetItemsHandler)
+		publicGroup.GET("/items/slug/:language/:slug", handlers.GetItemBySlugHandler)
+		publicGroup.GET("/items/:id", handlers.GetItemHandler)
+		publicGroup.GET("/blog", handlers.GetBlogsHandler)
+		publicGroup.GET("/blog/slug/:language/:slug", handlers.GetBlogBySlugHandler)
+		publicGroup.GET("/blog/:id", handlers.GetBlogHandler)
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "github.com/google/uuid"
+
+type TagPost struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug"`
+}
+
+// TagGet — тег із кількістю використань; у записах контенту кількість не заповнюється
+type TagGet struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Slug  string    `json:"slug"`
+	Count int64     `json:"count,omitempty"`
+}
+
+type TagUpdate struct {
+	Name *string `json:"name"`
+	Slug *string `json:"slug"`
+}
+
+// TagMerge — теги, що зливаються в цільовий і видаляються
+type TagMerge struct {
+	SourceIDs []uuid.UUID `json:"source_ids" binding:"required"`
+}
+
+// TagsPut — повний список тегів запису за назвами; відсутні теги створюються
+type TagsPut struct {
+	Tags []string `json:"tags"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Таблиці контенту, до якого прив'язуються теги
+const (
+	EntityBlogs    = "blogs"
+	EntityItems    = "items"
+	EntityContacts = "contacts"
+)
+
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(64);not null" json:"name"`
+	Slug      string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"slug"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ContentTag — зв'язок тегу з блогом, товаром або контактом
+type ContentTag struct {
+	TagID       uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"tag_id"`
+	EntityTable string    `gorm:"type:varchar(32);primaryKey" json:"entity_table"`
+	EntityID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"entity_id"`
+	Tag         Tag       `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time
+}
+
+func (tag *Tag) BeforeCreate(*gorm.DB) error {
+	if tag.ID == uuid.Nil {
+		tag.ID = uuid.New()
+	}
+	return nil
+}
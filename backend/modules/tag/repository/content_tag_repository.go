@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"backend/internal/services/utils"
+	"backend/modules/tag/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+const maxTagsPerEntity = 30
+
+// SetEntityTags замінює теги запису; теги задаються назвами, відсутні створюються
+func SetEntityTags(db *gorm.DB, table string, entityId uuid.UUID, names []string) ([]models.TagGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		tagIDs, err := resolveTags(tx, names)
+		if err != nil {
+			return err
+		}
+
+		query := tx.Where("entity_table = ? AND entity_id = ?", table, entityId)
+		if len(tagIDs) > 0 {
+			query = query.Where("tag_id NOT IN ?", tagIDs)
+		}
+		if err = query.Delete(&models.ContentTag{}).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		links := make([]models.ContentTag, 0, len(tagIDs))
+		for _, tagId := range tagIDs {
+			links = append(links, models.ContentTag{TagID: tagId, EntityTable: table, EntityID: entityId})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := GetEntityTags(db, table, []uuid.UUID{entityId})
+	if err != nil {
+		return nil, err
+	}
+	return tags[entityId], nil
+}
+
+// GetEntityTags повертає теги для кожного з записів таблиці
+func GetEntityTags(db *gorm.DB, table string, entityIds []uuid.UUID) (map[uuid.UUID][]models.TagGet, error) {
+	result := make(map[uuid.UUID][]models.TagGet, len(entityIds))
+	if len(entityIds) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		EntityID uuid.UUID
+		models.TagGet
+	}
+	err := db.Table("content_tags ct").
+		Select("ct.entity_id, t.id, t.name, t.slug").
+		Joins("JOIN tags t ON t.id = ct.tag_id").
+		Where("ct.entity_table = ? AND ct.entity_id IN ?", table, entityIds).
+		Order("t.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.EntityID] = append(result[row.EntityID], row.TagGet)
+	}
+	return result, nil
+}
+
+// CountTags рахує використання тегів серед записів, які вибирає idsQuery (запит з однією колонкою ID)
+func CountTags(db *gorm.DB, table string, idsQuery *gorm.DB) ([]models.TagGet, error) {
+	tags := []models.TagGet{}
+	err := db.Table("content_tags ct").
+		Select("t.id, t.name, t.slug, COUNT(*) AS count").
+		Joins("JOIN tags t ON t.id = ct.tag_id").
+		Where("ct.entity_table = ? AND ct.entity_id IN (?)", table, idsQuery).
+		Group("t.id").
+		Order("count DESC, t.name ASC").
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FilterByTags обмежує запит записами з тегами slugs: з усіма або, якщо matchAny, хоча б з одним
+func FilterByTags(query *gorm.DB, table string, idColumn string, slugs []string, matchAny bool) *gorm.DB {
+	if len(slugs) == 0 {
+		return query
+	}
+
+	if matchAny {
+		return query.Where(idColumn+` IN (SELECT ct.entity_id FROM content_tags ct
+			JOIN tags t ON t.id = ct.tag_id
+			WHERE ct.entity_table = ? AND t.slug IN ?)`, table, slugs)
+	}
+	unique := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		unique[slug] = true
+	}
+	return query.Where(idColumn+` IN (SELECT ct.entity_id FROM content_tags ct
+		JOIN tags t ON t.id = ct.tag_id
+		WHERE ct.entity_table = ? AND t.slug IN ?
+		GROUP BY ct.entity_id
+		HAVING COUNT(DISTINCT t.id) = ?)`, table, slugs, len(unique))
+}
+
+func DeleteEntityTags(db *gorm.DB, table string, entityId uuid.UUID) error {
+	return db.Where("entity_table = ? AND entity_id = ?", table, entityId).Delete(&models.ContentTag{}).Error
+}
+
+// resolveTags знаходить теги за slug назв і створює відсутні
+func resolveTags(tx *gorm.DB, names []string) ([]uuid.UUID, error) {
+	bySlug := make(map[string]string)
+	var slugs []string
+	for _, raw := range names {
+		name, slug, err := normalizeTag(raw, "")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := bySlug[slug]; !ok {
+			bySlug[slug] = name
+			slugs = append(slugs, slug)
+		}
+	}
+	if len(slugs) > maxTagsPerEntity {
+		return nil, errors.New("too many tags")
+	}
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	var existing []models.Tag
+	if err := tx.Where("slug IN ?", slugs).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uuid.UUID, len(existing))
+	for _, tag := range existing {
+		ids[tag.Slug] = tag.ID
+	}
+
+	result := make([]uuid.UUID, 0, len(slugs))
+	for _, slug := range slugs {
+		if id, ok := ids[slug]; ok {
+			result = append(result, id)
+			continue
+		}
+		// Паралельне створення того самого тегу не падає: беремо вже вставлений рядок
+		tag := &models.Tag{Name: bySlug[slug], Slug: slug}
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).Create(tag).Error
+		if err != nil {
+			return nil, err
+		}
+		var created models.Tag
+		if err = tx.Where("slug = ?", slug).First(&created).Error; err != nil {
+			return nil, err
+		}
+		result = append(result, created.ID)
+	}
+	return result, nil
+}
+
+// ParseTagSlugs розбирає параметр ?tags=a,b: назви або slug тегів через кому
+func ParseTagSlugs(param string) []string {
+	var slugs []string
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(param, ",") {
+		// Повтори прибираємо, інакше фільтр "усі теги" не знайде жодного запису
+		if slug := utils.Slugify(value); slug != "" && !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
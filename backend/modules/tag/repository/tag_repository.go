@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"backend/internal/services/utils"
+	"backend/modules/tag/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxTagLength      = 64
+	defaultSearchSize = 20
+	maxSearchSize     = 100
+)
+
+var (
+	ErrTagExists   = errors.New("a tag with this slug already exists")
+	ErrInvalidTag  = errors.New("the tag name must contain letters or digits")
+	ErrMergeTarget = errors.New("a tag cannot be merged into itself")
+)
+
+func CreateTag(db *gorm.DB, post *models.TagPost) (*models.TagGet, error) {
+	name, slug, err := normalizeTag(post.Name, post.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSlugAvailable(db, slug, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	tag := &models.Tag{Name: name, Slug: slug}
+	if err = db.Create(tag).Error; err != nil {
+		return nil, err
+	}
+	return &models.TagGet{ID: tag.ID, Name: tag.Name, Slug: tag.Slug}, nil
+}
+
+// SearchTags повертає теги для автодоповнення: збіг з початку назви або slug, найуживаніші першими
+func SearchTags(db *gorm.DB, search string, limit int) ([]models.TagGet, error) {
+	if limit <= 0 {
+		limit = defaultSearchSize
+	}
+	if limit > maxSearchSize {
+		limit = maxSearchSize
+	}
+
+	query := db.Table("tags t").
+		Select("t.id, t.name, t.slug, COUNT(ct.tag_id) AS count").
+		Joins("LEFT JOIN content_tags ct ON ct.tag_id = t.id").
+		Group("t.id")
+
+	if search = strings.TrimSpace(search); search != "" {
+		prefix := escapeLike(strings.ToLower(search)) + "%"
+		slugPrefix := escapeLike(utils.Slugify(search)) + "%"
+		query = query.Where("LOWER(t.name) LIKE ? OR t.slug LIKE ?", prefix, slugPrefix)
+	}
+
+	tags := []models.TagGet{}
+	err := query.Order("count DESC, t.name ASC").Limit(limit).Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func GetTagById(db *gorm.DB, id uuid.UUID) (*models.TagGet, error) {
+	var tag models.TagGet
+	err := db.Table("tags t").
+		Select("t.id, t.name, t.slug, COUNT(ct.tag_id) AS count").
+		Joins("LEFT JOIN content_tags ct ON ct.tag_id = t.id").
+		Where("t.id = ?", id).
+		Group("t.id").
+		Take(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// UpdateTag перейменовує тег; без явного slug він генерується з нової назви
+func UpdateTag(db *gorm.DB, id uuid.UUID, update *models.TagUpdate) (*models.TagGet, error) {
+	var tag models.Tag
+	if err := db.Where("id = ?", id).First(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	name := tag.Name
+	if update.Name != nil {
+		name = *update.Name
+	}
+	slug := ""
+	if update.Slug != nil {
+		slug = *update.Slug
+	} else if update.Name == nil {
+		slug = tag.Slug
+	}
+
+	name, slug, err := normalizeTag(name, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSlugAvailable(db, slug, tag.ID); err != nil {
+		return nil, err
+	}
+
+	err = db.Model(&tag).Updates(map[string]interface{}{"name": name, "slug": slug}).Error
+	if err != nil {
+		return nil, err
+	}
+	return GetTagById(db, id)
+}
+
+// MergeTags переносить усі зв'язки тегів sourceIds на цільовий тег і видаляє вихідні теги
+func MergeTags(db *gorm.DB, targetId uuid.UUID, sourceIds []uuid.UUID) (*models.TagGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", targetId).First(&models.Tag{}).Error; err != nil {
+			return err
+		}
+		for _, sourceId := range sourceIds {
+			if sourceId == targetId {
+				return ErrMergeTarget
+			}
+		}
+
+		var count int64
+		if err := tx.Model(&models.Tag{}).Where("id IN ?", sourceIds).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) != len(uniqueIDs(sourceIds)) {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Запис, що вже має цільовий тег, не отримує дубль зв'язку
+		err := tx.Exec(`INSERT INTO content_tags (tag_id, entity_table, entity_id, created_at)
+			SELECT ?, entity_table, entity_id, MIN(created_at) FROM content_tags
+			WHERE tag_id IN ?
+			GROUP BY entity_table, entity_id
+			ON CONFLICT DO NOTHING`, targetId, sourceIds).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Where("tag_id IN ?", sourceIds).Delete(&models.ContentTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", sourceIds).Delete(&models.Tag{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTagById(db, targetId)
+}
+
+func DeleteTag(db *gorm.DB, id uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&models.Tag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", id).Delete(&models.ContentTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Tag{}).Error
+	})
+}
+
+// normalizeTag прибирає зайві пробіли з назви та будує slug; порожній slug береться з назви
+func normalizeTag(name string, slug string) (string, string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if utf8.RuneCountInString(name) > maxTagLength {
+		return "", "", errors.New("the tag name is too long")
+	}
+	if strings.TrimSpace(slug) == "" {
+		slug = name
+	}
+	slug = utils.Slugify(slug)
+	if name == "" || slug == "" {
+		return "", "", ErrInvalidTag
+	}
+	if len(slug) > maxTagLength {
+		slug = strings.TrimRight(slug[:maxTagLength], "-")
+	}
+	return name, slug, nil
+}
+
+func checkSlugAvailable(db *gorm.DB, slug string, exceptId uuid.UUID) error {
+	var count int64
+	err := db.Model(&models.Tag{}).Where("slug = ? AND id <> ?", slug, exceptId).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTagExists
+	}
+	return nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func uniqueIDs(ids []uuid.UUID) map[uuid.UUID]bool {
+	set := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
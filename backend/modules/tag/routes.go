@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"backend/modules/tag/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	tagGroup := r.Group("/tags")
+	{
+		tagGroup.POST("/", handlers.CreateTagHandler)
+		tagGroup.GET("/", handlers.GetTagsHandler)
+		tagGroup.GET("/:id", handlers.GetTagByIdHandler)
+		tagGroup.PATCH("/:id", handlers.UpdateTagHandler)
+		tagGroup.POST("/:id/merge", handlers.MergeTagsHandler)
+		tagGroup.DELETE("/:id", handlers.DeleteTagHandler)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/task/models"
+	"backend/modules/task/repository"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+// getBoard завантажує дошку з :id і перевіряє доступ; manage вимагає власника або суперкористувача.
+// При помилці відповідь уже надіслана
+func getBoard(ctx *gin.Context, boardId uuid.UUID, manage bool) (*models.TaskBoard, *userModels.User, bool) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return nil, nil, false
+	}
+
+	board, err := repository.GetBoardRecord(db, boardId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
+		return nil, nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+
+	allowed, err := repository.CanAccess(db, board, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+	// Чужа дошка для стороннього користувача не існує
+	if !allowed {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
+		return nil, nil, false
+	}
+	if manage && board.OwnerID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Only the owner can manage this board"})
+		return nil, nil, false
+	}
+	return board, user, true
+}
+
+func getBoardParam(ctx *gin.Context, manage bool) (*models.TaskBoard, *userModels.User, bool) {
+	boardId, ok := parseID(ctx, "id", "board")
+	if !ok {
+		return nil, nil, false
+	}
+	return getBoard(ctx, boardId, manage)
+}
+
+func getColumnParam(ctx *gin.Context) (*models.TaskColumn, bool) {
+	columnId, ok := parseID(ctx, "id", "column")
+	if !ok {
+		return nil, false
+	}
+	column, err := repository.GetColumn(postgres.DB, columnId)
+	if !found(ctx, err, "Column not found") {
+		return nil, false
+	}
+	if _, _, ok = getBoard(ctx, column.BoardID, false); !ok {
+		return nil, false
+	}
+	return column, true
+}
+
+func getCardParam(ctx *gin.Context) (*models.TaskCard, *userModels.User, bool) {
+	cardId, ok := parseID(ctx, "id", "card")
+	if !ok {
+		return nil, nil, false
+	}
+	card, err := repository.GetCardRecord(postgres.DB, cardId)
+	if !found(ctx, err, "Card not found") {
+		return nil, nil, false
+	}
+	_, user, ok := getBoard(ctx, card.BoardID, false)
+	if !ok {
+		return nil, nil, false
+	}
+	return card, user, true
+}
+
+func getLabelParam(ctx *gin.Context) (*models.TaskLabel, bool) {
+	labelId, ok := parseID(ctx, "id", "label")
+	if !ok {
+		return nil, false
+	}
+	label, err := repository.GetLabel(postgres.DB, labelId)
+	if !found(ctx, err, "Label not found") {
+		return nil, false
+	}
+	if _, _, ok = getBoard(ctx, label.BoardID, false); !ok {
+		return nil, false
+	}
+	return label, true
+}
+
+func parseID(ctx *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(param))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + " ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// found відповідає 404 чи 500 на помилку завантаження запису
+func found(ctx *gin.Context, err error, message string) bool {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": message})
+		return false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// respondTaskError відповідає 400 для помилок перевірки, 404 для відсутніх записів, інакше 500
+func respondTaskError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, repository.ErrColumnNotEmpty):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrTitleRequired),
+		errors.Is(err, repository.ErrInvalidPosition),
+		errors.Is(err, repository.ErrNotMember),
+		errors.Is(err, repository.ErrUnknownUser),
+		errors.Is(err, repository.ErrForeignLabel),
+		errors.Is(err, repository.ErrForeignColumn),
+		errors.Is(err, repository.ErrOwnerMember):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/task/models"
+	"backend/modules/task/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// CreateBoardHandler — дошки створює персонал магазину; учасниками можуть бути будь-які користувачі
+func CreateBoardHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+	if !user.IsAdmin && !user.IsSuperUser {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var post models.BoardPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	board, err := repository.CreateBoard(db, &post, user.ID)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, board)
+}
+
+func GetBoardsHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.GetCurrentUserFromContext(ctx, db)
+	if !ok {
+		return
+	}
+
+	boards, err := repository.GetBoards(db, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"boards": boards})
+}
+
+func GetBoardHandler(ctx *gin.Context) {
+	board, _, ok := getBoardParam(ctx, false)
+	if !ok {
+		return
+	}
+
+	result, err := repository.GetBoard(postgres.DB, board.ID)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func UpdateBoardHandler(ctx *gin.Context) {
+	board, _, ok := getBoardParam(ctx, true)
+	if !ok {
+		return
+	}
+
+	var update models.BoardUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := repository.UpdateBoard(postgres.DB, board, &update)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func DeleteBoardHandler(ctx *gin.Context) {
+	board, _, ok := getBoardParam(ctx, true)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteBoard(postgres.DB, board); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Board deleted"})
+}
+
+func AddBoardMemberHandler(ctx *gin.Context) {
+	board, _, ok := getBoardParam(ctx, true)
+	if !ok {
+		return
+	}
+	userId, ok := parseID(ctx, "userId", "user")
+	if !ok {
+		return
+	}
+
+	if err := repository.AddMember(postgres.DB, board, userId); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Member added"})
+}
+
+// RemoveBoardMemberHandler — учасник може й сам покинути дошку
+func RemoveBoardMemberHandler(ctx *gin.Context) {
+	userId, ok := parseID(ctx, "userId", "user")
+	if !ok {
+		return
+	}
+	board, user, ok := getBoardParam(ctx, false)
+	if !ok {
+		return
+	}
+	if user.ID != userId && board.OwnerID != user.ID && !user.IsSuperUser {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Only the owner can manage this board"})
+		return
+	}
+
+	if err := repository.RemoveMember(postgres.DB, board, userId); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Member removed"})
+}
+
+func CreateColumnHandler(ctx *gin.Context) {
+	board, _, ok := getBoardParam(ctx, false)
+	if !ok {
+		return
+	}
+
+	var post models.ColumnPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	column, err := repository.CreateColumn(postgres.DB, board.ID, &post)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, column)
+}
+
+func UpdateColumnHandler(ctx *gin.Context) {
+	column, ok := getColumnParam(ctx)
+	if !ok {
+		return
+	}
+
+	var update models.ColumnUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := repository.UpdateColumn(postgres.DB, column, &update); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, column)
+}
+
+func DeleteColumnHandler(ctx *gin.Context) {
+	column, ok := getColumnParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteColumn(postgres.DB, column); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Column deleted"})
+}
+
+func CreateLabelHandler(ctx *gin.Context) {
+	board, _, ok := getBoardParam(ctx, false)
+	if !ok {
+		return
+	}
+
+	var post models.LabelPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := repository.CreateLabel(postgres.DB, board.ID, &post)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, label)
+}
+
+func UpdateLabelHandler(ctx *gin.Context) {
+	label, ok := getLabelParam(ctx)
+	if !ok {
+		return
+	}
+
+	var update models.LabelUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := repository.UpdateLabel(postgres.DB, label, &update); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, label)
+}
+
+func DeleteLabelHandler(ctx *gin.Context) {
+	label, ok := getLabelParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteLabel(postgres.DB, label); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Label deleted"})
+}
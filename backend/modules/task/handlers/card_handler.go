@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/task/models"
+	"backend/modules/task/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+func CreateCardHandler(ctx *gin.Context) {
+	column, ok := getColumnParam(ctx)
+	if !ok {
+		return
+	}
+	userId, _ := utils2.GetUserIDFromContext(ctx)
+
+	var post models.CardPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card, err := repository.CreateCard(postgres.DB, column, &post, userId)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, card)
+}
+
+func GetCardHandler(ctx *gin.Context) {
+	card, _, ok := getCardParam(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := repository.GetCard(postgres.DB, card.ID)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func UpdateCardHandler(ctx *gin.Context) {
+	card, _, ok := getCardParam(ctx)
+	if !ok {
+		return
+	}
+	userId, _ := utils2.GetUserIDFromContext(ctx)
+
+	var update models.CardUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := repository.UpdateCard(postgres.DB, card, &update, userId)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func MoveCardHandler(ctx *gin.Context) {
+	card, _, ok := getCardParam(ctx)
+	if !ok {
+		return
+	}
+
+	var move models.CardMove
+	if err := ctx.ShouldBindJSON(&move); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := repository.MoveCard(postgres.DB, card, &move)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func DeleteCardHandler(ctx *gin.Context) {
+	card, _, ok := getCardParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteCard(postgres.DB, card); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Card deleted"})
+}
+
+func AddChecklistItemHandler(ctx *gin.Context) {
+	card, _, ok := getCardParam(ctx)
+	if !ok {
+		return
+	}
+
+	var post models.ChecklistItemPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := repository.AddChecklistItem(postgres.DB, card.ID, &post)
+	if err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, item)
+}
+
+func UpdateChecklistItemHandler(ctx *gin.Context) {
+	item, ok := getChecklistItem(ctx)
+	if !ok {
+		return
+	}
+
+	var update models.ChecklistItemUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := repository.UpdateChecklistItem(postgres.DB, item, &update); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+func DeleteChecklistItemHandler(ctx *gin.Context) {
+	item, ok := getChecklistItem(ctx)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteChecklistItem(postgres.DB, item); err != nil {
+		respondTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Checklist item deleted"})
+}
+
+// GetMyTasksHandler — картки, де поточний користувач виконавець, з усіх доступних дошок
+func GetMyTasksHandler(ctx *gin.Context) {
+	db := postgres.DB
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	params := &models.MyTasksParameters{IncludeCompleted: ctx.Query("include_completed") == "true"}
+	if value := ctx.Query("due_before"); value != "" {
+		dueBefore, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_before, expected RFC 3339"})
+			return
+		}
+		params.DueBefore = &dueBefore
+	}
+	if value := ctx.Query("board_id"); value != "" {
+		boardId, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid board ID"})
+			return
+		}
+		params.BoardID = &boardId
+	}
+
+	tasks, err := repository.GetMyTasks(db, userId, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks})
+}
+
+func getChecklistItem(ctx *gin.Context) (*models.TaskChecklistItem, bool) {
+	itemId, ok := parseID(ctx, "itemId", "checklist item")
+	if !ok {
+		return nil, false
+	}
+	card, _, ok := getCardParam(ctx)
+	if !ok {
+		return nil, false
+	}
+	item, err := repository.GetChecklistItem(postgres.DB, card.ID, itemId)
+	if !found(ctx, err, "Checklist item not found") {
+		return nil, false
+	}
+	return item, true
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type BoardPost struct {
+	Title       string      `json:"title" binding:"required"`
+	Description string      `json:"description"`
+	MemberIDs   []uuid.UUID `json:"member_ids"`
+	// Columns — назви початкових колонок; без них створюються "To do", "In progress", "Done"
+	Columns []string `json:"columns"`
+}
+
+type BoardUpdate struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+}
+
+// UserRef — короткі відомості про учасника чи виконавця
+type UserRef struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+	Avatar   string    `json:"avatar"`
+}
+
+type BoardSummary struct {
+	TaskBoard
+	Owner UserRef `json:"owner"`
+}
+
+// BoardGet — дошка з колонками, картками, мітками та учасниками
+type BoardGet struct {
+	TaskBoard
+	Owner   UserRef     `json:"owner"`
+	Members []UserRef   `json:"members"`
+	Labels  []TaskLabel `json:"labels"`
+	Columns []ColumnGet `json:"columns"`
+}
+
+type ColumnGet struct {
+	TaskColumn
+	Cards []*CardGet `json:"cards"`
+}
+
+type ColumnPost struct {
+	Title    string `json:"title" binding:"required"`
+	Position *int   `json:"position"`
+}
+
+type ColumnUpdate struct {
+	Title    *string `json:"title"`
+	Position *int    `json:"position"`
+}
+
+type CardGet struct {
+	TaskCard
+	Assignees []UserRef   `json:"assignees"`
+	Labels    []TaskLabel `json:"labels"`
+}
+
+// CardPost — нова картка; без position вона додається в кінець колонки
+type CardPost struct {
+	Title          string      `json:"title" binding:"required"`
+	Description    string      `json:"description"`
+	Position       *int        `json:"position"`
+	DueDate        *time.Time  `json:"due_date"`
+	ReminderOffset int         `json:"reminder_offset"`
+	CalendarSync   bool        `json:"calendar_sync"`
+	AssigneeIDs    []uuid.UUID `json:"assignee_ids"`
+	LabelIDs       []uuid.UUID `json:"label_ids"`
+	Checklist      []string    `json:"checklist"`
+}
+
+// CardUpdate — часткове оновлення; передані assignee_ids чи label_ids повністю замінюють наявні
+type CardUpdate struct {
+	Title          *string      `json:"title"`
+	Description    *string      `json:"description"`
+	DueDate        *time.Time   `json:"due_date"`
+	ClearDueDate   bool         `json:"clear_due_date"`
+	ReminderOffset *int         `json:"reminder_offset"`
+	CalendarSync   *bool        `json:"calendar_sync"`
+	Completed      *bool        `json:"completed"`
+	AssigneeIDs    *[]uuid.UUID `json:"assignee_ids"`
+	LabelIDs       *[]uuid.UUID `json:"label_ids"`
+}
+
+// CardMove — переміщення картки; без column_id картка лишається в своїй колонці, без position стає останньою
+type CardMove struct {
+	ColumnID *uuid.UUID `json:"column_id"`
+	Position *int       `json:"position"`
+}
+
+type LabelPost struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color" binding:"required"`
+}
+
+type LabelUpdate struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+type ChecklistItemPost struct {
+	Title    string `json:"title" binding:"required"`
+	Position *int   `json:"position"`
+}
+
+type ChecklistItemUpdate struct {
+	Title    *string `json:"title"`
+	Done     *bool   `json:"done"`
+	Position *int    `json:"position"`
+}
+
+// MyTasksParameters — фільтри списку "мої завдання"
+type MyTasksParameters struct {
+	IncludeCompleted bool
+	DueBefore        *time.Time
+	BoardID          *uuid.UUID
+}
+
+type MyTask struct {
+	*CardGet
+	BoardTitle  string `json:"board_title"`
+	ColumnTitle string `json:"column_title"`
+}
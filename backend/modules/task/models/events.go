@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// TaskAssigned — на картку призначено нових виконавців; той, хто призначав, до UserIDs не входить
+type TaskAssigned struct {
+	CardID      uuid.UUID   `json:"card_id"`
+	BoardID     uuid.UUID   `json:"board_id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	UserIDs     []uuid.UUID `json:"user_ids"`
+	ActorID     uuid.UUID   `json:"actor_id"`
+}
+
+func (TaskAssigned) EventName() string { return "task.assigned" }
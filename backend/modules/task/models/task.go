@@ -0,0 +1,137 @@
+package models
+
+import (
+	calendarModels "backend/modules/calendar/models"
+	userModels "backend/modules/user/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// TaskBoard — дошка завдань; бачать її власник і учасники
+type TaskBoard struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
+	Title       string            `gorm:"type:varchar(255);not null" json:"title"`
+	Description string            `gorm:"type:text" json:"description"`
+	OwnerID     uuid.UUID         `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Owner       userModels.User   `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+	Members     []TaskBoardMember `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type TaskBoardMember struct {
+	BoardID   uuid.UUID       `gorm:"type:uuid;primaryKey" json:"board_id"`
+	UserID    uuid.UUID       `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	User      userModels.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// TaskColumn — колонка дошки; порядок задає Position у межах дошки
+type TaskColumn struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	BoardID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"board_id"`
+	Title     string     `gorm:"type:varchar(100);not null" json:"title"`
+	Position  int        `gorm:"not null" json:"position"`
+	Board     TaskBoard  `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+	Cards     []TaskCard `gorm:"foreignKey:ColumnID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TaskCard — картка завдання; порядок задає Position у межах колонки.
+// Якщо CalendarSync увімкнено, кожен виконавець отримує подію календаря на DueDate з нагадуванням
+type TaskCard struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primaryKey" json:"id"`
+	BoardID        uuid.UUID           `gorm:"type:uuid;not null;index" json:"board_id"`
+	ColumnID       uuid.UUID           `gorm:"type:uuid;not null;index" json:"column_id"`
+	Title          string              `gorm:"type:varchar(255);not null" json:"title"`
+	Description    string              `gorm:"type:text" json:"description"`
+	Position       int                 `gorm:"not null" json:"position"`
+	DueDate        *time.Time          `gorm:"index" json:"due_date"`
+	ReminderOffset int                 `gorm:"not null;default:0" json:"reminder_offset"`
+	CalendarSync   bool                `gorm:"not null;default:false" json:"calendar_sync"`
+	CompletedAt    *time.Time          `json:"completed_at"`
+	CreatedByID    *uuid.UUID          `gorm:"type:uuid" json:"created_by_id"`
+	Board          TaskBoard           `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedBy      *userModels.User    `gorm:"foreignKey:CreatedByID;constraint:OnDelete:SET NULL" json:"-"`
+	Assignees      []TaskCardAssignee  `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"-"`
+	Labels         []TaskCardLabel     `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"-"`
+	Checklist      []TaskChecklistItem `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"checklist"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+type TaskCardAssignee struct {
+	CardID uuid.UUID       `gorm:"type:uuid;primaryKey" json:"card_id"`
+	UserID uuid.UUID       `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	User   userModels.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TaskLabel — мітка дошки; картки тієї ж дошки позначаються нею через TaskCardLabel
+type TaskLabel struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	BoardID uuid.UUID `gorm:"type:uuid;not null;index" json:"board_id"`
+	Name    string    `gorm:"type:varchar(64);not null" json:"name"`
+	Color   string    `gorm:"type:varchar(32);not null" json:"color"`
+	Board   TaskBoard `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type TaskCardLabel struct {
+	CardID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"card_id"`
+	LabelID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"label_id"`
+	Label   TaskLabel `gorm:"foreignKey:LabelID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TaskChecklistItem — пункт чек-листа; порядок задає Position у межах картки
+type TaskChecklistItem struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	CardID   uuid.UUID `gorm:"type:uuid;not null;index" json:"card_id"`
+	Title    string    `gorm:"type:varchar(255);not null" json:"title"`
+	Done     bool      `gorm:"not null;default:false" json:"done"`
+	Position int       `gorm:"not null" json:"position"`
+}
+
+// TaskCardEvent — подія календаря виконавця, створена для терміну картки
+type TaskCardEvent struct {
+	CardID  uuid.UUID               `gorm:"type:uuid;primaryKey" json:"card_id"`
+	UserID  uuid.UUID               `gorm:"type:uuid;primaryKey" json:"user_id"`
+	EventID uuid.UUID               `gorm:"type:uuid;not null;uniqueIndex" json:"event_id"`
+	Card    TaskCard                `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"-"`
+	Event   calendarModels.Calendar `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (board *TaskBoard) BeforeCreate(*gorm.DB) error {
+	if board.ID == uuid.Nil {
+		board.ID = uuid.New()
+	}
+	return nil
+}
+
+func (column *TaskColumn) BeforeCreate(*gorm.DB) error {
+	if column.ID == uuid.Nil {
+		column.ID = uuid.New()
+	}
+	return nil
+}
+
+func (card *TaskCard) BeforeCreate(*gorm.DB) error {
+	if card.ID == uuid.Nil {
+		card.ID = uuid.New()
+	}
+	return nil
+}
+
+func (label *TaskLabel) BeforeCreate(*gorm.DB) error {
+	if label.ID == uuid.Nil {
+		label.ID = uuid.New()
+	}
+	return nil
+}
+
+func (item *TaskChecklistItem) BeforeCreate(*gorm.DB) error {
+	if item.ID == uuid.Nil {
+		item.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"backend/internal/repository"
+	calendarModels "backend/modules/calendar/models"
+	"backend/modules/task/models"
+	"backend/modules/task/service"
+	userModels "backend/modules/user/models"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+var (
+	ErrTitleRequired   = errors.New("title cannot be empty")
+	ErrInvalidPosition = repository.ErrInvalidPosition
+	ErrNotMember       = errors.New("assignees must be members of the board")
+	ErrUnknownUser     = errors.New("user not found")
+	ErrForeignLabel    = errors.New("labels must belong to the board of the card")
+	ErrForeignColumn   = errors.New("the column belongs to another board")
+	ErrColumnNotEmpty  = errors.New("move or delete the cards of the column first")
+	ErrOwnerMember     = errors.New("the owner is always a member of the board")
+)
+
+// Колонки нової дошки, якщо інші не задано
+var defaultColumns = []string{"To do", "In progress", "Done"}
+
+// CanAccess — дошку бачать власник, учасники та суперкористувач
+func CanAccess(db *gorm.DB, board *models.TaskBoard, user *userModels.User) (bool, error) {
+	if board.OwnerID == user.ID || user.IsSuperUser {
+		return true, nil
+	}
+	var count int64
+	err := db.Model(&models.TaskBoardMember{}).Where("board_id = ? AND user_id = ?", board.ID, user.ID).Count(&count).Error
+	return count > 0, err
+}
+
+func GetBoardRecord(db *gorm.DB, id uuid.UUID) (*models.TaskBoard, error) {
+	var board models.TaskBoard
+	if err := db.First(&board, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+func CreateBoard(db *gorm.DB, post *models.BoardPost, ownerId uuid.UUID) (*models.BoardGet, error) {
+	board := models.TaskBoard{
+		Title:       strings.TrimSpace(post.Title),
+		Description: post.Description,
+		OwnerID:     ownerId,
+	}
+	if board.Title == "" {
+		return nil, ErrTitleRequired
+	}
+	columns := post.Columns
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&board).Error; err != nil {
+			return err
+		}
+		for _, userId := range post.MemberIDs {
+			if err := addMember(tx, &board, userId); err != nil {
+				return err
+			}
+		}
+		for position, title := range columns {
+			column := models.TaskColumn{BoardID: board.ID, Title: strings.TrimSpace(title), Position: position}
+			if column.Title == "" {
+				return ErrTitleRequired
+			}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetBoard(db, board.ID)
+}
+
+// GetBoards повертає дошки, доступні користувачу; суперкористувач бачить усі
+func GetBoards(db *gorm.DB, user *userModels.User) ([]models.BoardSummary, error) {
+	query := db.Model(&models.TaskBoard{})
+	if !user.IsSuperUser {
+		query = query.Where("owner_id = ? OR id IN (?)", user.ID,
+			db.Model(&models.TaskBoardMember{}).Select("board_id").Where("user_id = ?", user.ID))
+	}
+	var boards []models.TaskBoard
+	if err := query.Order("title").Find(&boards).Error; err != nil {
+		return nil, err
+	}
+
+	ownerIds := make([]uuid.UUID, 0, len(boards))
+	for _, board := range boards {
+		ownerIds = append(ownerIds, board.OwnerID)
+	}
+	users, err := loadUsers(db, ownerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.BoardSummary, 0, len(boards))
+	for _, board := range boards {
+		result = append(result, models.BoardSummary{TaskBoard: board, Owner: users[board.OwnerID]})
+	}
+	return result, nil
+}
+
+// GetBoard повертає дошку з колонками й картками, упорядкованими за позицією
+func GetBoard(db *gorm.DB, id uuid.UUID) (*models.BoardGet, error) {
+	board, err := GetBoardRecord(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberIds []uuid.UUID
+	if err = db.Model(&models.TaskBoardMember{}).Where("board_id = ?", id).Order("created_at").Pluck("user_id", &memberIds).Error; err != nil {
+		return nil, err
+	}
+	users, err := loadUsers(db, append(memberIds, board.OwnerID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BoardGet{
+		TaskBoard: *board,
+		Owner:     users[board.OwnerID],
+		Members:   make([]models.UserRef, 0, len(memberIds)),
+		Labels:    []models.TaskLabel{},
+		Columns:   []models.ColumnGet{},
+	}
+	for _, memberId := range memberIds {
+		result.Members = append(result.Members, users[memberId])
+	}
+	if err = db.Where("board_id = ?", id).Order("name").Find(&result.Labels).Error; err != nil {
+		return nil, err
+	}
+
+	var columns []models.TaskColumn
+	if err = db.Where("board_id = ?", id).Order("position, created_at").Find(&columns).Error; err != nil {
+		return nil, err
+	}
+	var cards []models.TaskCard
+	if err = preloadCard(db).Where("board_id = ?", id).Order("position, created_at").Find(&cards).Error; err != nil {
+		return nil, err
+	}
+	cardGets, err := toCardGets(db, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	byColumn := make(map[uuid.UUID][]*models.CardGet)
+	for _, card := range cardGets {
+		byColumn[card.ColumnID] = append(byColumn[card.ColumnID], card)
+	}
+	for _, column := range columns {
+		columnCards := byColumn[column.ID]
+		if columnCards == nil {
+			columnCards = []*models.CardGet{}
+		}
+		result.Columns = append(result.Columns, models.ColumnGet{TaskColumn: column, Cards: columnCards})
+	}
+	return result, nil
+}
+
+func UpdateBoard(db *gorm.DB, board *models.TaskBoard, update *models.BoardUpdate) (*models.BoardGet, error) {
+	if update.Title != nil {
+		board.Title = strings.TrimSpace(*update.Title)
+		if board.Title == "" {
+			return nil, ErrTitleRequired
+		}
+	}
+	if update.Description != nil {
+		board.Description = *update.Description
+	}
+	if err := db.Model(board).Select("title", "description").Updates(board).Error; err != nil {
+		return nil, err
+	}
+	return GetBoard(db, board.ID)
+}
+
+// DeleteBoard видаляє дошку разом з картками та їхніми подіями в календарях виконавців
+func DeleteBoard(db *gorm.DB, board *models.TaskBoard) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		cards := tx.Model(&models.TaskCard{}).Select("id").Where("board_id = ?", board.ID)
+		if err := service.DeleteCardEvents(tx, cards); err != nil {
+			return err
+		}
+		return tx.Delete(board).Error
+	})
+}
+
+func AddMember(db *gorm.DB, board *models.TaskBoard, userId uuid.UUID) error {
+	return addMember(db, board, userId)
+}
+
+// RemoveMember прибирає учасника з дошки, з виконавців її карток і видаляє його події для цих карток
+func RemoveMember(db *gorm.DB, board *models.TaskBoard, userId uuid.UUID) error {
+	if userId == board.OwnerID {
+		return ErrOwnerMember
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("board_id = ? AND user_id = ?", board.ID, userId).Delete(&models.TaskBoardMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		cards := tx.Model(&models.TaskCard{}).Select("id").Where("board_id = ?", board.ID)
+		events := tx.Model(&models.TaskCardEvent{}).Select("event_id").Where("user_id = ? AND card_id IN (?)", userId, cards)
+		if err := tx.Where("id IN (?)", events).Delete(&calendarModels.Calendar{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND card_id IN (?)", userId, cards).Delete(&models.TaskCardAssignee{}).Error
+	})
+}
+
+func addMember(tx *gorm.DB, board *models.TaskBoard, userId uuid.UUID) error {
+	if userId == board.OwnerID {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(&userModels.User{}).Where("id = ?", userId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrUnknownUser
+	}
+	member := models.TaskBoardMember{BoardID: board.ID, UserID: userId}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&member).Error
+}
+
+func CreateColumn(db *gorm.DB, boardId uuid.UUID, post *models.ColumnPost) (*models.TaskColumn, error) {
+	column := models.TaskColumn{BoardID: boardId, Title: strings.TrimSpace(post.Title)}
+	if column.Title == "" {
+		return nil, ErrTitleRequired
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if column.Position, err = repository.PlaceBy[models.TaskColumn](tx, post.Position, "board_id", boardId); err != nil {
+			return err
+		}
+		return tx.Create(&column).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+func GetColumn(db *gorm.DB, id uuid.UUID) (*models.TaskColumn, error) {
+	var column models.TaskColumn
+	if err := db.First(&column, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+func UpdateColumn(db *gorm.DB, column *models.TaskColumn, update *models.ColumnUpdate) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if update.Title != nil {
+			column.Title = strings.TrimSpace(*update.Title)
+			if column.Title == "" {
+				return ErrTitleRequired
+			}
+		}
+		if update.Position != nil && *update.Position != column.Position {
+			position, err := repository.PlaceBy[models.TaskColumn](tx, update.Position, "board_id", column.BoardID)
+			if err != nil {
+				return err
+			}
+			column.Position = position
+		}
+		return tx.Model(column).Select("title", "position").Updates(column).Error
+	})
+}
+
+func DeleteColumn(db *gorm.DB, column *models.TaskColumn) error {
+	var count int64
+	if err := db.Model(&models.TaskCard{}).Where("column_id = ?", column.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrColumnNotEmpty
+	}
+	return db.Delete(column).Error
+}
+
+func loadUsers(db *gorm.DB, ids []uuid.UUID) (map[uuid.UUID]models.UserRef, error) {
+	result := make(map[uuid.UUID]models.UserRef, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	var users []userModels.User
+	if err := db.Select("id", "full_name", "avatar").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		result[user.ID] = models.UserRef{ID: user.ID, FullName: user.FullName, Avatar: user.Avatar}
+	}
+	return result, nil
+}
@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"backend/internal/repository"
+	"backend/internal/services/events"
+	"backend/modules/task/models"
+	"backend/modules/task/service"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"strings"
+	"time"
+)
+
+func CreateCard(db *gorm.DB, column *models.TaskColumn, post *models.CardPost, userId uuid.UUID) (*models.CardGet, error) {
+	card := models.TaskCard{
+		BoardID:        column.BoardID,
+		ColumnID:       column.ID,
+		Title:          strings.TrimSpace(post.Title),
+		Description:    post.Description,
+		DueDate:        post.DueDate,
+		ReminderOffset: max(post.ReminderOffset, 0),
+		CalendarSync:   post.CalendarSync,
+		CreatedByID:    &userId,
+	}
+	if card.Title == "" {
+		return nil, ErrTitleRequired
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		var assigned []uuid.UUID
+		if card.Position, err = repository.PlaceBy[models.TaskCard](tx, post.Position, "column_id", column.ID); err != nil {
+			return err
+		}
+		if err = tx.Omit(clause.Associations).Create(&card).Error; err != nil {
+			return err
+		}
+		for position, title := range post.Checklist {
+			item := models.TaskChecklistItem{CardID: card.ID, Title: strings.TrimSpace(title), Position: position}
+			if item.Title == "" {
+				return ErrTitleRequired
+			}
+			if err = tx.Create(&item).Error; err != nil {
+				return err
+			}
+		}
+		if err = setLabels(tx, &card, post.LabelIDs); err != nil {
+			return err
+		}
+		if assigned, err = setAssignees(tx, &card, post.AssigneeIDs); err != nil {
+			return err
+		}
+		if err = service.SyncCardEvents(tx, &card, post.AssigneeIDs); err != nil {
+			return err
+		}
+		return publishAssigned(tx, &card, assigned, userId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetCard(db, card.ID)
+}
+
+func GetCardRecord(db *gorm.DB, id uuid.UUID) (*models.TaskCard, error) {
+	var card models.TaskCard
+	if err := db.First(&card, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func GetCard(db *gorm.DB, id uuid.UUID) (*models.CardGet, error) {
+	var card models.TaskCard
+	if err := preloadCard(db).First(&card, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	cards, err := toCardGets(db, []models.TaskCard{card})
+	if err != nil {
+		return nil, err
+	}
+	return cards[0], nil
+}
+
+// UpdateCard змінює картку; нові виконавці, крім userId, отримують сповіщення
+func UpdateCard(db *gorm.DB, card *models.TaskCard, update *models.CardUpdate, userId uuid.UUID) (*models.CardGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var assigned []uuid.UUID
+		if update.Title != nil {
+			card.Title = strings.TrimSpace(*update.Title)
+			if card.Title == "" {
+				return ErrTitleRequired
+			}
+		}
+		if update.Description != nil {
+			card.Description = *update.Description
+		}
+		if update.ClearDueDate {
+			card.DueDate = nil
+		} else if update.DueDate != nil {
+			card.DueDate = update.DueDate
+		}
+		if update.ReminderOffset != nil {
+			card.ReminderOffset = max(*update.ReminderOffset, 0)
+		}
+		if update.CalendarSync != nil {
+			card.CalendarSync = *update.CalendarSync
+		}
+		if update.Completed != nil {
+			switch {
+			case *update.Completed && card.CompletedAt == nil:
+				now := time.Now()
+				card.CompletedAt = &now
+			case !*update.Completed:
+				card.CompletedAt = nil
+			}
+		}
+		err := tx.Model(card).
+			Select("title", "description", "due_date", "reminder_offset", "calendar_sync", "completed_at").
+			Updates(card).Error
+		if err != nil {
+			return err
+		}
+
+		if update.LabelIDs != nil {
+			if err = setLabels(tx, card, *update.LabelIDs); err != nil {
+				return err
+			}
+		}
+		var assignees []uuid.UUID
+		if update.AssigneeIDs != nil {
+			assignees = *update.AssigneeIDs
+			if assigned, err = setAssignees(tx, card, assignees); err != nil {
+				return err
+			}
+		} else if err = tx.Model(&models.TaskCardAssignee{}).Where("card_id = ?", card.ID).Pluck("user_id", &assignees).Error; err != nil {
+			return err
+		}
+		if err = service.SyncCardEvents(tx, card, assignees); err != nil {
+			return err
+		}
+		return publishAssigned(tx, card, assigned, userId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetCard(db, card.ID)
+}
+
+// MoveCard переносить картку в іншу колонку тієї ж дошки або на іншу позицію
+func MoveCard(db *gorm.DB, card *models.TaskCard, move *models.CardMove) (*models.CardGet, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		columnId := card.ColumnID
+		if move.ColumnID != nil {
+			column, err := GetColumn(tx, *move.ColumnID)
+			if err != nil {
+				return err
+			}
+			if column.BoardID != card.BoardID {
+				return ErrForeignColumn
+			}
+			columnId = column.ID
+		}
+
+		position, err := repository.PlaceBy[models.TaskCard](tx, move.Position, "column_id", columnId)
+		if err != nil {
+			return err
+		}
+		card.ColumnID, card.Position = columnId, position
+		return tx.Model(card).Select("column_id", "position").Updates(card).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetCard(db, card.ID)
+}
+
+func DeleteCard(db *gorm.DB, card *models.TaskCard) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		cards := tx.Model(&models.TaskCard{}).Select("id").Where("id = ?", card.ID)
+		if err := service.DeleteCardEvents(tx, cards); err != nil {
+			return err
+		}
+		return tx.Delete(card).Error
+	})
+}
+
+// GetMyTasks повертає картки, де користувач виконавець: спершу з найближчим терміном, без терміну — в кінці
+func GetMyTasks(db *gorm.DB, userId uuid.UUID, params *models.MyTasksParameters) ([]models.MyTask, error) {
+	query := preloadCard(db).
+		Joins("JOIN task_card_assignees a ON a.card_id = task_cards.id AND a.user_id = ?", userId)
+	if !params.IncludeCompleted {
+		query = query.Where("task_cards.completed_at IS NULL")
+	}
+	if params.DueBefore != nil {
+		query = query.Where("task_cards.due_date < ?", *params.DueBefore)
+	}
+	if params.BoardID != nil {
+		query = query.Where("task_cards.board_id = ?", *params.BoardID)
+	}
+
+	var cards []models.TaskCard
+	if err := query.Order("task_cards.due_date ASC NULLS LAST, task_cards.created_at").Find(&cards).Error; err != nil {
+		return nil, err
+	}
+	cardGets, err := toCardGets(db, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	var boardIds, columnIds []uuid.UUID
+	for _, card := range cards {
+		boardIds = append(boardIds, card.BoardID)
+		columnIds = append(columnIds, card.ColumnID)
+	}
+	titles := func(model interface{}, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+		var rows []struct {
+			ID    uuid.UUID
+			Title string
+		}
+		result := make(map[uuid.UUID]string)
+		if len(ids) == 0 {
+			return result, nil
+		}
+		if err := db.Model(model).Select("id", "title").Where("id IN ?", ids).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			result[row.ID] = row.Title
+		}
+		return result, nil
+	}
+	boards, err := titles(&models.TaskBoard{}, boardIds)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := titles(&models.TaskColumn{}, columnIds)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]models.MyTask, 0, len(cardGets))
+	for _, card := range cardGets {
+		tasks = append(tasks, models.MyTask{CardGet: card, BoardTitle: boards[card.BoardID], ColumnTitle: columns[card.ColumnID]})
+	}
+	return tasks, nil
+}
+
+// setAssignees замінює виконавців картки і повертає тих, кого призначено вперше;
+// виконавцем може бути лише власник чи учасник дошки
+func setAssignees(tx *gorm.DB, card *models.TaskCard, userIds []uuid.UUID) ([]uuid.UUID, error) {
+	unique := uniqueIds(userIds)
+	if len(unique) > 0 {
+		var count int64
+		err := tx.Model(&models.TaskBoard{}).
+			Select("COUNT(DISTINCT u.id)").
+			Joins("JOIN users u ON u.id = task_boards.owner_id OR u.id IN (SELECT user_id FROM task_board_members WHERE board_id = task_boards.id)").
+			Where("task_boards.id = ? AND u.id IN ?", card.BoardID, unique).
+			Scan(&count).Error
+		if err != nil {
+			return nil, err
+		}
+		if int(count) != len(unique) {
+			return nil, ErrNotMember
+		}
+	}
+
+	var previous []uuid.UUID
+	if err := tx.Model(&models.TaskCardAssignee{}).Where("card_id = ?", card.ID).Pluck("user_id", &previous).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("card_id = ?", card.ID).Delete(&models.TaskCardAssignee{}).Error; err != nil {
+		return nil, err
+	}
+	var added []uuid.UUID
+	for _, userId := range unique {
+		if err := tx.Create(&models.TaskCardAssignee{CardID: card.ID, UserID: userId}).Error; err != nil {
+			return nil, err
+		}
+		if !slices.Contains(previous, userId) {
+			added = append(added, userId)
+		}
+	}
+	return added, nil
+}
+
+// setLabels замінює мітки картки; мітки мають належати тій самій дошці
+func setLabels(tx *gorm.DB, card *models.TaskCard, labelIds []uuid.UUID) error {
+	unique := uniqueIds(labelIds)
+	if len(unique) > 0 {
+		var count int64
+		if err := tx.Model(&models.TaskLabel{}).Where("board_id = ? AND id IN ?", card.BoardID, unique).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) != len(unique) {
+			return ErrForeignLabel
+		}
+	}
+
+	if err := tx.Where("card_id = ?", card.ID).Delete(&models.TaskCardLabel{}).Error; err != nil {
+		return err
+	}
+	for _, labelId := range unique {
+		if err := tx.Create(&models.TaskCardLabel{CardID: card.ID, LabelID: labelId}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func preloadCard(db *gorm.DB) *gorm.DB {
+	return db.Preload("Checklist", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position, id")
+	}).Preload("Assignees").Preload("Labels.Label")
+}
+
+func toCardGets(db *gorm.DB, cards []models.TaskCard) ([]*models.CardGet, error) {
+	var userIds []uuid.UUID
+	for _, card := range cards {
+		for _, assignee := range card.Assignees {
+			userIds = append(userIds, assignee.UserID)
+		}
+	}
+	users, err := loadUsers(db, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.CardGet, 0, len(cards))
+	for _, card := range cards {
+		get := &models.CardGet{TaskCard: card, Assignees: []models.UserRef{}, Labels: []models.TaskLabel{}}
+		if get.Checklist == nil {
+			get.Checklist = []models.TaskChecklistItem{}
+		}
+		for _, assignee := range card.Assignees {
+			get.Assignees = append(get.Assignees, users[assignee.UserID])
+		}
+		for _, label := range card.Labels {
+			get.Labels = append(get.Labels, label.Label)
+		}
+		result = append(result, get)
+	}
+	return result, nil
+}
+
+func uniqueIds(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// publishAssigned публікує task.assigned для нових виконавців; той, хто призначив, сповіщення не отримує
+func publishAssigned(tx *gorm.DB, card *models.TaskCard, assigned []uuid.UUID, actorId uuid.UUID) error {
+	var userIds []uuid.UUID
+	for _, userId := range assigned {
+		if userId != actorId {
+			userIds = append(userIds, userId)
+		}
+	}
+	if len(userIds) == 0 {
+		return nil
+	}
+	return events.Publish(tx, models.TaskAssigned{
+		CardID:      card.ID,
+		BoardID:     card.BoardID,
+		Title:       card.Title,
+		Description: card.Description,
+		UserIDs:     userIds,
+		ActorID:     actorId,
+	})
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"backend/internal/repository"
+	"backend/modules/task/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+func CreateLabel(db *gorm.DB, boardId uuid.UUID, post *models.LabelPost) (*models.TaskLabel, error) {
+	label := models.TaskLabel{BoardID: boardId, Name: strings.TrimSpace(post.Name), Color: strings.TrimSpace(post.Color)}
+	if label.Name == "" {
+		return nil, ErrTitleRequired
+	}
+	if err := db.Create(&label).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func GetLabel(db *gorm.DB, id uuid.UUID) (*models.TaskLabel, error) {
+	var label models.TaskLabel
+	if err := db.First(&label, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func UpdateLabel(db *gorm.DB, label *models.TaskLabel, update *models.LabelUpdate) error {
+	if update.Name != nil {
+		label.Name = strings.TrimSpace(*update.Name)
+		if label.Name == "" {
+			return ErrTitleRequired
+		}
+	}
+	if update.Color != nil {
+		label.Color = strings.TrimSpace(*update.Color)
+	}
+	return db.Model(label).Select("name", "color").Updates(label).Error
+}
+
+// DeleteLabel видаляє мітку; з карток вона знімається каскадом
+func DeleteLabel(db *gorm.DB, label *models.TaskLabel) error {
+	return db.Delete(label).Error
+}
+
+func AddChecklistItem(db *gorm.DB, cardId uuid.UUID, post *models.ChecklistItemPost) (*models.TaskChecklistItem, error) {
+	item := models.TaskChecklistItem{CardID: cardId, Title: strings.TrimSpace(post.Title)}
+	if item.Title == "" {
+		return nil, ErrTitleRequired
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if item.Position, err = repository.PlaceBy[models.TaskChecklistItem](tx, post.Position, "card_id", cardId); err != nil {
+			return err
+		}
+		return tx.Create(&item).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func GetChecklistItem(db *gorm.DB, cardId uuid.UUID, id uuid.UUID) (*models.TaskChecklistItem, error) {
+	var item models.TaskChecklistItem
+	if err := db.First(&item, "id = ? AND card_id = ?", id, cardId).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func UpdateChecklistItem(db *gorm.DB, item *models.TaskChecklistItem, update *models.ChecklistItemUpdate) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if update.Title != nil {
+			item.Title = strings.TrimSpace(*update.Title)
+			if item.Title == "" {
+				return ErrTitleRequired
+			}
+		}
+		if update.Done != nil {
+			item.Done = *update.Done
+		}
+		if update.Position != nil && *update.Position != item.Position {
+			position, err := repository.PlaceBy[models.TaskChecklistItem](tx, update.Position, "card_id", item.CardID)
+			if err != nil {
+				return err
+			}
+			item.Position = position
+		}
+		return tx.Model(item).Select("title", "done", "position").Updates(item).Error
+	})
+}
+
+func DeleteChecklistItem(db *gorm.DB, item *models.TaskChecklistItem) error {
+	return db.Delete(item).Error
+}
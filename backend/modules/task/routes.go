@@ -0,0 +1,38 @@
+package task
+
+import (
+	"backend/modules/task/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	taskGroup := r.Group("/tasks")
+	{
+		taskGroup.GET("/my", handlers.GetMyTasksHandler)
+
+		taskGroup.GET("/boards", handlers.GetBoardsHandler)
+		taskGroup.POST("/boards", handlers.CreateBoardHandler)
+		taskGroup.GET("/boards/:id", handlers.GetBoardHandler)
+		taskGroup.PATCH("/boards/:id", handlers.UpdateBoardHandler)
+		taskGroup.DELETE("/boards/:id", handlers.DeleteBoardHandler)
+		taskGroup.PUT("/boards/:id/members/:userId", handlers.AddBoardMemberHandler)
+		taskGroup.DELETE("/boards/:id/members/:userId", handlers.RemoveBoardMemberHandler)
+		taskGroup.POST("/boards/:id/columns", handlers.CreateColumnHandler)
+		taskGroup.POST("/boards/:id/labels", handlers.CreateLabelHandler)
+
+		taskGroup.PATCH("/columns/:id", handlers.UpdateColumnHandler)
+		taskGroup.DELETE("/columns/:id", handlers.DeleteColumnHandler)
+		taskGroup.POST("/columns/:id/cards", handlers.CreateCardHandler)
+
+		taskGroup.PATCH("/labels/:id", handlers.UpdateLabelHandler)
+		taskGroup.DELETE("/labels/:id", handlers.DeleteLabelHandler)
+
+		taskGroup.GET("/cards/:id", handlers.GetCardHandler)
+		taskGroup.PATCH("/cards/:id", handlers.UpdateCardHandler)
+		taskGroup.DELETE("/cards/:id", handlers.DeleteCardHandler)
+		taskGroup.POST("/cards/:id/move", handlers.MoveCardHandler)
+		taskGroup.POST("/cards/:id/checklist", handlers.AddChecklistItemHandler)
+		taskGroup.PATCH("/cards/:id/checklist/:itemId", handlers.UpdateChecklistItemHandler)
+		taskGroup.DELETE("/cards/:id/checklist/:itemId", handlers.DeleteChecklistItemHandler)
+	}
+}
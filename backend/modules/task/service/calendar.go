@@ -0,0 +1,112 @@
+package service
+
+import (
+	calendarModels "backend/modules/calendar/models"
+	"backend/modules/task/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"slices"
+)
+
+// Колір подій календаря, створених для завдань
+const eventColor = "orange"
+
+// EventAssignees повертає виконавців, яким потрібна подія календаря: лише для незавершеної картки
+// з терміном і ввімкненою синхронізацією
+func EventAssignees(card *models.TaskCard, assignees []uuid.UUID) []uuid.UUID {
+	if !card.CalendarSync || card.DueDate == nil || card.CompletedAt != nil {
+		return nil
+	}
+	return assignees
+}
+
+// EventFor будує подію календаря виконавця на термін картки; нагадування надсилається листом
+func EventFor(card *models.TaskCard, userId uuid.UUID) calendarModels.Calendar {
+	return calendarModels.Calendar{
+		Title:          "Task: " + card.Title,
+		Description:    card.Description,
+		StartDate:      *card.DueDate,
+		EndDate:        *card.DueDate,
+		ReminderOffset: card.ReminderOffset,
+		Color:          eventColor,
+		WorkingDay:     true,
+		SendEmail:      true,
+		UserID:         userId,
+	}
+}
+
+// PlanCardEvents зіставляє наявні події картки з виконавцями: linked — події, які треба оновити,
+// missing — виконавці без події в порядку списку, stale — події, які треба видалити
+func PlanCardEvents(card *models.TaskCard, assignees []uuid.UUID, links []models.TaskCardEvent) (map[uuid.UUID]uuid.UUID, []uuid.UUID, []uuid.UUID) {
+	wanted := make(map[uuid.UUID]bool)
+	for _, userId := range EventAssignees(card, assignees) {
+		wanted[userId] = true
+	}
+	linked := make(map[uuid.UUID]uuid.UUID)
+	var stale []uuid.UUID
+	for _, link := range links {
+		if wanted[link.UserID] {
+			linked[link.UserID] = link.EventID
+		} else {
+			stale = append(stale, link.EventID)
+		}
+	}
+
+	var missing []uuid.UUID
+	for _, userId := range EventAssignees(card, assignees) {
+		if _, ok := linked[userId]; !ok && !slices.Contains(missing, userId) {
+			missing = append(missing, userId)
+		}
+	}
+	return linked, missing, stale
+}
+
+// SyncCardEvents приводить події календаря у відповідність до картки: створює відсутні, оновлює наявні
+// й видаляє зайві. Зміна терміну чи зсуву нагадування знову вмикає нагадування
+func SyncCardEvents(tx *gorm.DB, card *models.TaskCard, assignees []uuid.UUID) error {
+	var links []models.TaskCardEvent
+	if err := tx.Where("card_id = ?", card.ID).Find(&links).Error; err != nil {
+		return err
+	}
+	linked, missing, stale := PlanCardEvents(card, assignees, links)
+
+	// Зв'язки видаляються каскадом разом з подіями
+	if len(stale) > 0 {
+		if err := tx.Where("id IN ?", stale).Delete(&calendarModels.Calendar{}).Error; err != nil {
+			return err
+		}
+	}
+
+	for userId, eventId := range linked {
+		event := EventFor(card, userId)
+		err := tx.Model(&calendarModels.Calendar{}).Where("id = ?", eventId).Updates(map[string]interface{}{
+			"reminder_sent":   gorm.Expr("reminder_sent AND start_date = ? AND reminder_offset = ?", event.StartDate, event.ReminderOffset),
+			"title":           event.Title,
+			"description":     event.Description,
+			"start_date":      event.StartDate,
+			"end_date":        event.EndDate,
+			"reminder_offset": event.ReminderOffset,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, userId := range missing {
+		event := EventFor(card, userId)
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		link := models.TaskCardEvent{CardID: card.ID, UserID: userId, EventID: event.ID}
+		if err := tx.Create(&link).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteCardEvents видаляє події календаря карток, які вибирає cardIds (запит з однією колонкою ID)
+func DeleteCardEvents(tx *gorm.DB, cardIds *gorm.DB) error {
+	events := tx.Model(&models.TaskCardEvent{}).Select("event_id").Where("card_id IN (?)", cardIds)
+	return tx.Where("id IN (?)", events).Delete(&calendarModels.Calendar{}).Error
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"backend/internal/db/postgres"
+	"backend/internal/services/presence"
 	utils2 "backend/internal/services/utils"
 	"backend/modules/user/models"
 	"backend/modules/user/repository"
@@ -11,7 +12,6 @@ import (
 	"github.com/google/uuid"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 func CreateUser(ctx *gin.Context) {
@@ -42,10 +42,6 @@ func ReadUserMe(ctx *gin.Context) {
 		return
 	}
 
-	now := time.Now()
-	user.LastSeenAt = &now
-	db.Model(&user).Update("last_seen_at", time.Now())
-
 	response := &models.UserResponse{
 		ID:          user.ID,
 		FullName:    user.FullName,
@@ -57,9 +53,21 @@ func ReadUserMe(ctx *gin.Context) {
 		Acronym:     user.Acronym,
 		LastSeenAt:  user.LastSeenAt,
 	}
+	service.WithPresence(response)
 	ctx.JSON(http.StatusOK, response)
 }
 
+// Heartbeat підтримує статус "online", поки користувач діє у вкладці без інших запитів до API.
+// Саму активність фіксує AuthMiddleware
+func Heartbeat(ctx *gin.Context) {
+	userId, ok := utils2.GetUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"status": presence.Default.Status(userId, nil)})
+}
+
 func ReadUserById(ctx *gin.Context) {
 	db := postgres.DB
 	userIDRaw := ctx.Param("id")
@@ -78,6 +86,7 @@ func ReadUserById(ctx *gin.Context) {
 		}
 		return
 	}
+	service.WithPresence(user)
 
 	ctx.JSON(http.StatusOK, user)
 }
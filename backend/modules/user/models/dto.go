@@ -2,6 +2,7 @@ package models
 
 import (
 	//blog "backend/modules/blog/models"
+	"backend/internal/services/presence"
 	"github.com/google/uuid"
 	"time"
 )
@@ -16,6 +17,8 @@ type UserResponse struct {
 	IsAdmin     bool       `json:"isAdmin"`
 	Acronym     string     `json:"acronym"`
 	LastSeenAt  *time.Time `json:"lastSeenAt,omitempty"`
+	// Presence заповнюється лише там, де статус потрібен клієнту: у списку користувачів і профілі
+	Presence presence.Status `json:"presence,omitempty"`
 }
 
 type AllUsers struct {
@@ -0,0 +1,8 @@
+package models
+
+// UserCreated — зареєстровано або створено користувача
+type UserCreated struct {
+	User *UserResponse `json:"user"`
+}
+
+func (UserCreated) EventName() string { return "user.created" }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"backend/internal/repository"
+	"backend/internal/services/events"
 	"backend/modules/user/models"
 	"backend/modules/user/utils"
 	"errors"
@@ -23,11 +24,20 @@ func CreateUser(db *gorm.DB, user *models.User) (*models.UserResponse, error) {
 		user.Avatar = "https://f003.backblazeb2.com/file/admin-go-panel/user.png"
 	}
 
-	// Створення користувача в БД
-	if err := db.Create(user).Error; err != nil {
+	// Створення користувача в БД разом із подією user.created
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return events.Publish(tx, models.UserCreated{User: toUserResponse(user)})
+	})
+	if err != nil {
 		return nil, err
 	}
+	return toUserResponse(user), nil
+}
 
+func toUserResponse(user *models.User) *models.UserResponse {
 	return &models.UserResponse{
 		ID:          user.ID,
 		FullName:    user.FullName,
@@ -36,7 +46,7 @@ func CreateUser(db *gorm.DB, user *models.User) (*models.UserResponse, error) {
 		IsSuperUser: user.IsSuperUser,
 		IsAdmin:     user.IsAdmin,
 		Acronym:     user.Acronym,
-	}, err
+	}
 }
 
 func GetAllUsers(db *gorm.DB, limit int, skip int) ([]*models.User, error) {
@@ -47,6 +57,16 @@ func GetAllUsers(db *gorm.DB, limit int, skip int) ([]*models.User, error) {
 	return users, nil
 }
 
+// GetAdmins повертає активних адміністраторів і суперкористувачів
+func GetAdmins(db *gorm.DB) ([]*models.User, error) {
+	var users []*models.User
+	err := db.Where("is_active = ? AND (is_admin = ? OR is_super_user = ?)", true, true, true).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func GetUserById(db *gorm.DB, id uuid.UUID) (*models.UserResponse, error) {
 	var user models.User
 
@@ -12,6 +12,7 @@ func RegisterRoutes(r *gin.RouterGroup) {
 		userGroup.GET("/me", handlers.ReadUserMe)
 		userGroup.PATCH("/me", handlers.UpdateCurrentUser)
 		userGroup.PATCH("/me/password/", handlers.UpdatePasswordCurrentUser)
+		userGroup.POST("/me/heartbeat", handlers.Heartbeat)
 		userGroup.GET("/", handlers.ReadAllUsers)
 		userGroup.GET("/:id", handlers.ReadUserById)
 		userGroup.POST("/", handlers.CreateUser)
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"backend/internal/services/presence"
 	"backend/modules/user/models"
 	"backend/modules/user/repository"
 	"backend/modules/user/utils"
@@ -76,7 +77,14 @@ func TransformUsers(users []*models.User) []*models.UserResponse {
 			IsAdmin:     user.IsAdmin,
 			LastSeenAt:  user.LastSeenAt,
 		}
+		WithPresence(userResponse)
 		userResponses = append(userResponses, userResponse)
 	}
 	return userResponses
 }
+
+// WithPresence доповнює відповідь поточним статусом присутності та найсвіжішим LastSeenAt
+func WithPresence(user *models.UserResponse) {
+	user.Presence = presence.Default.Status(user.ID, user.LastSeenAt)
+	user.LastSeenAt = presence.Default.LastSeen(user.ID, user.LastSeenAt)
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"backend/internal/db/postgres"
+	utils2 "backend/internal/services/utils"
+	"backend/modules/webhook/models"
+	"backend/modules/webhook/repository"
+	"backend/modules/webhook/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func GetWebhookEventsHandler(ctx *gin.Context) {
+	if _, ok := utils2.RequireAdmin(ctx, postgres.DB); !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"events": models.Events()})
+}
+
+func CreateWebhookHandler(ctx *gin.Context) {
+	db := postgres.DB
+	user, ok := utils2.RequireAdmin(ctx, db)
+	if !ok {
+		return
+	}
+
+	var post models.EndpointPost
+	if err := ctx.ShouldBindJSON(&post); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := repository.CreateEndpoint(db, &post, user.ID)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, endpoint)
+}
+
+func GetWebhooksHandler(ctx *gin.Context) {
+	db := postgres.DB
+	if _, ok := utils2.RequireAdmin(ctx, db); !ok {
+		return
+	}
+
+	endpoints, err := repository.GetEndpoints(db)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, endpoints)
+}
+
+func GetWebhookHandler(ctx *gin.Context) {
+	endpoint, ok := getEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, endpoint)
+}
+
+func UpdateWebhookHandler(ctx *gin.Context) {
+	endpoint, ok := getEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	var update models.EndpointUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := repository.UpdateEndpoint(postgres.DB, endpoint, &update)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, endpoint)
+}
+
+func RotateWebhookSecretHandler(ctx *gin.Context) {
+	endpoint, ok := getEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := repository.RotateSecret(postgres.DB, endpoint)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func DeleteWebhookHandler(ctx *gin.Context) {
+	if _, ok := utils2.RequireAdmin(ctx, postgres.DB); !ok {
+		return
+	}
+	endpointId, ok := parseID(ctx, "id", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteEndpoint(postgres.DB, endpointId); err != nil {
+		respondWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"success": "Webhook deleted"})
+}
+
+// PingWebhookHandler надсилає перевірочну подію одразу, не чекаючи воркера, і повертає результат спроби
+func PingWebhookHandler(ctx *gin.Context) {
+	db := postgres.DB
+	endpoint, ok := getEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	delivery, err := service.Ping(db, endpoint)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err = service.Attempt(db, delivery); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
+
+func GetWebhookDeliveriesHandler(ctx *gin.Context) {
+	endpoint, ok := getEndpoint(ctx)
+	if !ok {
+		return
+	}
+
+	skip, _ := strconv.Atoi(ctx.DefaultQuery("skip", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	params := &models.DeliveryParameters{
+		Status: models.DeliveryStatus(ctx.Query("status")),
+		Event:  ctx.Query("event"),
+		Skip:   max(skip, 0),
+		Limit:  limit,
+	}
+	if params.Status != "" && !params.Status.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	deliveries, err := repository.GetDeliveries(postgres.DB, endpoint.ID, params)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func GetWebhookDeliveryHandler(ctx *gin.Context) {
+	delivery, ok := getDelivery(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delivery)
+}
+
+// ReplayWebhookDeliveryHandler ставить копію доставки в чергу; надішле її воркер
+func ReplayWebhookDeliveryHandler(ctx *gin.Context) {
+	delivery, ok := getDelivery(ctx)
+	if !ok {
+		return
+	}
+
+	replay, err := repository.ReplayDelivery(postgres.DB, delivery, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, replay)
+}
+
+func respondWebhookError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+	case errors.Is(err, repository.ErrInvalidURL),
+		errors.Is(err, repository.ErrInvalidEvent),
+		errors.Is(err, repository.ErrNoEvents):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getEndpoint перевіряє права й завантажує вебхук з :id; при помилці відповідь уже надіслана
+func getEndpoint(ctx *gin.Context) (*models.WebhookEndpoint, bool) {
+	if _, ok := utils2.RequireAdmin(ctx, postgres.DB); !ok {
+		return nil, false
+	}
+	endpointId, ok := parseID(ctx, "id", "Invalid webhook ID")
+	if !ok {
+		return nil, false
+	}
+
+	endpoint, err := repository.GetEndpoint(postgres.DB, endpointId)
+	if err != nil {
+		respondWebhookError(ctx, err)
+		return nil, false
+	}
+	return endpoint, true
+}
+
+func getDelivery(ctx *gin.Context) (*models.WebhookDelivery, bool) {
+	if _, ok := utils2.RequireAdmin(ctx, postgres.DB); !ok {
+		return nil, false
+	}
+	deliveryId, ok := parseID(ctx, "id", "Invalid delivery ID")
+	if !ok {
+		return nil, false
+	}
+
+	delivery, err := repository.GetDelivery(postgres.DB, deliveryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return nil, false
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return delivery, true
+}
+
+func parseID(ctx *gin.Context, name string, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return uuid.Nil, false
+	}
+	return id, true
+}
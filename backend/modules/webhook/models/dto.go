@@ -0,0 +1,39 @@
+package models
+
+type EndpointPost struct {
+	URL         string   `json:"url" binding:"required"`
+	Description string   `json:"description"`
+	Events      []string `json:"events" binding:"required"`
+	Active      *bool    `json:"active"`
+}
+
+type EndpointUpdate struct {
+	URL         *string   `json:"url"`
+	Description *string   `json:"description"`
+	Events      *[]string `json:"events"`
+	Active      *bool     `json:"active"`
+}
+
+// EndpointSecret повертається лише при створенні та заміні секрету — потім його не видно
+type EndpointSecret struct {
+	WebhookEndpoint
+	Secret string `json:"secret"`
+}
+
+type EndpointGetAll struct {
+	Data  []WebhookEndpoint `json:"data"`
+	Count int               `json:"count"`
+}
+
+type DeliveryParameters struct {
+	Status DeliveryStatus
+	Event  string
+	Skip   int
+	Limit  int
+}
+
+type DeliveryGetAll struct {
+	Data  []WebhookDelivery `json:"data"`
+	Count int               `json:"count"`
+	Total int64             `json:"total"`
+}
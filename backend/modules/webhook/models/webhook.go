@@ -0,0 +1,96 @@
+package models
+
+import (
+	"backend/internal/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+// Події, на які можна підписати вебхук
+const (
+	EventItemCreated          = "item.created"
+	EventItemUpdated          = "item.updated"
+	EventBlogPublished        = "blog.published"
+	EventUserCreated          = "user.created"
+	EventCalendarEventCreated = "calendar.event.created"
+	// EventPing надсилається лише кнопкою перевірки й не потребує підписки
+	EventPing = "ping"
+	// EventAll у списку подій підписує вебхук на все
+	EventAll = "*"
+)
+
+// Events повертає події, доступні для підписки
+func Events() []string {
+	return []string{EventItemCreated, EventItemUpdated, EventBlogPublished, EventUserCreated, EventCalendarEventCreated}
+}
+
+func ValidEvent(event string) bool {
+	return event == EventAll || slices.Contains(Events(), event)
+}
+
+type DeliveryStatus string
+
+const (
+	StatusPending   DeliveryStatus = "pending"
+	StatusDelivered DeliveryStatus = "delivered"
+	StatusFailed    DeliveryStatus = "failed"
+)
+
+func (s DeliveryStatus) Valid() bool {
+	return s == StatusPending || s == StatusDelivered || s == StatusFailed
+}
+
+// WebhookEndpoint — зовнішня адреса, куди надсилаються події; Secret підписує тіло запиту
+type WebhookEndpoint struct {
+	ID          uuid.UUID           `gorm:"type:uuid;primaryKey" json:"id"`
+	URL         string              `gorm:"type:text;not null" json:"url"`
+	Description string              `gorm:"type:varchar(255)" json:"description"`
+	Events      entities.StringList `gorm:"type:jsonb;not null" json:"events"`
+	Secret      string              `gorm:"type:varchar(100);not null" json:"-"`
+	Active      bool                `gorm:"not null" json:"active"`
+	CreatedByID *uuid.UUID          `gorm:"type:uuid" json:"created_by_id"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// Subscribed повідомляє, чи надсилати вебхуку подію
+func (endpoint *WebhookEndpoint) Subscribed(event string) bool {
+	return slices.Contains(endpoint.Events, EventAll) || slices.Contains(endpoint.Events, event)
+}
+
+// WebhookDelivery — одна спроба доставити подію на адресу; рядок живе в черзі, доки не стане delivered або failed.
+// EventID однаковий для всіх адрес і повторів однієї події — отримувач використовує його для ідемпотентності
+type WebhookDelivery struct {
+	ID             uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	EndpointID     uuid.UUID       `gorm:"type:uuid;not null;index" json:"endpoint_id"`
+	Event          string          `gorm:"type:varchar(64);not null;index" json:"event"`
+	EventID        uuid.UUID       `gorm:"type:uuid;not null;index" json:"event_id"`
+	Payload        entities.JSON   `gorm:"type:jsonb;not null" json:"payload"`
+	Status         DeliveryStatus  `gorm:"type:varchar(16);not null;default:'pending';index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time       `gorm:"not null;index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `gorm:"type:text" json:"response_body"`
+	LastError      string          `gorm:"type:text" json:"last_error"`
+	ReplayOfID     *uuid.UUID      `gorm:"type:uuid" json:"replay_of_id"`
+	Endpoint       WebhookEndpoint `gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt      time.Time       `gorm:"index" json:"created_at"`
+}
+
+func (endpoint *WebhookEndpoint) BeforeCreate(*gorm.DB) error {
+	if endpoint.ID == uuid.Nil {
+		endpoint.ID = uuid.New()
+	}
+	return nil
+}
+
+func (delivery *WebhookDelivery) BeforeCreate(*gorm.DB) error {
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/modules/webhook/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// CreateDeliveries ставить подію в чергу для кожного підписаного вебхука. У транзакції доставки
+// з'являться лише після коміту, тож отримувач не побачить подію про зміну, яку відкотили
+func CreateDeliveries(db *gorm.DB, event string, eventId uuid.UUID, payload []byte, now time.Time) ([]models.WebhookDelivery, error) {
+	endpoints, err := GetSubscribedEndpoints(db, event)
+	if err != nil || len(endpoints) == 0 {
+		return nil, err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			Event:         event,
+			EventID:       eventId,
+			Payload:       entities.JSON(payload),
+			Status:        models.StatusPending,
+			NextAttemptAt: now,
+		})
+	}
+	if err = db.Omit(clause.Associations).Create(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CreateDelivery ставить подію в чергу для одного вебхука незалежно від підписки (перевірка зв'язку)
+func CreateDelivery(db *gorm.DB, endpoint *models.WebhookEndpoint, event string, eventId uuid.UUID, payload []byte, now time.Time) (*models.WebhookDelivery, error) {
+	delivery := models.WebhookDelivery{
+		EndpointID:    endpoint.ID,
+		Event:         event,
+		EventID:       eventId,
+		Payload:       entities.JSON(payload),
+		Status:        models.StatusPending,
+		NextAttemptAt: now,
+		Endpoint:      *endpoint,
+	}
+	if err := db.Omit(clause.Associations).Create(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func GetDeliveries(db *gorm.DB, endpointId uuid.UUID, params *models.DeliveryParameters) (*models.DeliveryGetAll, error) {
+	query := db.Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", endpointId)
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.Event != "" {
+		query = query.Where("event = ?", params.Event)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	deliveries := []models.WebhookDelivery{}
+	err := query.Order("created_at DESC, id").Offset(params.Skip).Limit(params.Limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return &models.DeliveryGetAll{Data: deliveries, Count: len(deliveries), Total: total}, nil
+}
+
+func GetDelivery(db *gorm.DB, id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := db.First(&delivery, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ReplayDelivery ставить у чергу копію доставки з тим самим тілом і EventID; оригінал лишається в журналі
+func ReplayDelivery(db *gorm.DB, original *models.WebhookDelivery, now time.Time) (*models.WebhookDelivery, error) {
+	replay := models.WebhookDelivery{
+		EndpointID:    original.EndpointID,
+		Event:         original.Event,
+		EventID:       original.EventID,
+		Payload:       original.Payload,
+		Status:        models.StatusPending,
+		NextAttemptAt: now,
+		ReplayOfID:    &original.ID,
+	}
+	if err := db.Omit(clause.Associations).Create(&replay).Error; err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
+
+// ClaimDueDeliveries забирає доставки, час яких настав. FOR UPDATE SKIP LOCKED не дає кільком
+// воркерам узяти той самий рядок, а зсув next_attempt_at на lease ховає його від інших до завершення спроби.
+// Якщо воркер впаде посеред надсилання, доставку повторять після lease
+func ClaimDueDeliveries(db *gorm.DB, now time.Time, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.StatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+
+	// Вебхуки підвантажуються після коміту, щоб не тримати блокування довше потрібного
+	endpointIds := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		endpointIds = append(endpointIds, delivery.EndpointID)
+	}
+	var endpoints []models.WebhookEndpoint
+	if err = db.Where("id IN ?", endpointIds).Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	byId := make(map[uuid.UUID]models.WebhookEndpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byId[endpoint.ID] = endpoint
+	}
+	for i := range deliveries {
+		deliveries[i].Endpoint = byId[deliveries[i].EndpointID]
+	}
+	return deliveries, nil
+}
+
+// SaveAttempt зберігає результат спроби
+func SaveAttempt(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	return db.Model(delivery).Omit(clause.Associations).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "delivered_at", "response_status", "response_body", "last_error").
+		Updates(delivery).Error
+}
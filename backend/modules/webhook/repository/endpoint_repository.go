@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/safehttp"
+	"backend/modules/webhook/models"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidURL   = errors.New("webhook url must be an absolute http or https url of a public host")
+	ErrInvalidEvent = errors.New("unknown webhook event")
+	ErrNoEvents     = errors.New("webhook must subscribe to at least one event")
+)
+
+func CreateEndpoint(db *gorm.DB, post *models.EndpointPost, userId uuid.UUID) (*models.EndpointSecret, error) {
+	endpoint := models.WebhookEndpoint{
+		URL:         strings.TrimSpace(post.URL),
+		Description: strings.TrimSpace(post.Description),
+		Active:      post.Active == nil || *post.Active,
+		CreatedByID: &userId,
+	}
+	if err := validateURL(endpoint.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(post.Events)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Events = events
+	if endpoint.Secret, err = generateSecret(); err != nil {
+		return nil, err
+	}
+
+	if err = db.Create(&endpoint).Error; err != nil {
+		return nil, err
+	}
+	return &models.EndpointSecret{WebhookEndpoint: endpoint, Secret: endpoint.Secret}, nil
+}
+
+func GetEndpoints(db *gorm.DB) (*models.EndpointGetAll, error) {
+	endpoints := []models.WebhookEndpoint{}
+	if err := db.Order("created_at").Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	return &models.EndpointGetAll{Data: endpoints, Count: len(endpoints)}, nil
+}
+
+func GetEndpoint(db *gorm.DB, id uuid.UUID) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := db.First(&endpoint, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func UpdateEndpoint(db *gorm.DB, endpoint *models.WebhookEndpoint, update *models.EndpointUpdate) (*models.WebhookEndpoint, error) {
+	if update.URL != nil {
+		endpoint.URL = strings.TrimSpace(*update.URL)
+		if err := validateURL(endpoint.URL); err != nil {
+			return nil, err
+		}
+	}
+	if update.Description != nil {
+		endpoint.Description = strings.TrimSpace(*update.Description)
+	}
+	if update.Events != nil {
+		events, err := normalizeEvents(*update.Events)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.Events = events
+	}
+	if update.Active != nil {
+		endpoint.Active = *update.Active
+	}
+
+	err := db.Model(endpoint).Select("url", "description", "events", "active").Updates(endpoint).Error
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// RotateSecret видає новий секрет; старий перестає діяти одразу, включно з доставками в черзі
+func RotateSecret(db *gorm.DB, endpoint *models.WebhookEndpoint) (*models.EndpointSecret, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Model(endpoint).Update("secret", secret).Error; err != nil {
+		return nil, err
+	}
+	endpoint.Secret = secret
+	return &models.EndpointSecret{WebhookEndpoint: *endpoint, Secret: secret}, nil
+}
+
+func DeleteEndpoint(db *gorm.DB, id uuid.UUID) error {
+	result := db.Delete(&models.WebhookEndpoint{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetSubscribedEndpoints повертає активні вебхуки, підписані на подію; вебхуків небагато, тож фільтруємо в пам'яті
+func GetSubscribedEndpoints(db *gorm.DB, event string) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	if err := db.Where("active").Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	subscribed := endpoints[:0]
+	for _, endpoint := range endpoints {
+		if endpoint.Subscribed(event) {
+			subscribed = append(subscribed, endpoint)
+		}
+	}
+	return subscribed, nil
+}
+
+// validateURL відхиляє адреси, що не є http(s) або ведуть до внутрішньої мережі. Воркер перевіряє
+// адресу ще раз під час з'єднання, бо DNS може змінитися після збереження
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidURL
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = safehttp.ValidateURL(ctx, raw); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	return nil
+}
+
+func normalizeEvents(events []string) (entities.StringList, error) {
+	var result entities.StringList
+	seen := make(map[string]bool)
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !models.ValidEvent(event) {
+			return nil, ErrInvalidEvent
+		}
+		if !seen[event] {
+			seen[event] = true
+			result = append(result, event)
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrNoEvents
+	}
+	return result, nil
+}
+
+func generateSecret() (string, error) {
+	buffer := make([]byte, 24)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buffer), nil
+}
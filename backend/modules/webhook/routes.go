@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"backend/modules/webhook/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup) {
+	webhookGroup := r.Group("/webhooks")
+	{
+		webhookGroup.GET("/", handlers.GetWebhooksHandler)
+		webhookGroup.POST("/", handlers.CreateWebhookHandler)
+		webhookGroup.GET("/events", handlers.GetWebhookEventsHandler)
+		webhookGroup.GET("/deliveries/:id", handlers.GetWebhookDeliveryHandler)
+		webhookGroup.POST("/deliveries/:id/replay", handlers.ReplayWebhookDeliveryHandler)
+		webhookGroup.GET("/:id", handlers.GetWebhookHandler)
+		webhookGroup.PATCH("/:id", handlers.UpdateWebhookHandler)
+		webhookGroup.DELETE("/:id", handlers.DeleteWebhookHandler)
+		webhookGroup.POST("/:id/ping", handlers.PingWebhookHandler)
+		webhookGroup.POST("/:id/rotate-secret", handlers.RotateWebhookSecretHandler)
+		webhookGroup.GET("/:id/deliveries", handlers.GetWebhookDeliveriesHandler)
+	}
+}
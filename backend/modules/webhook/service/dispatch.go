@@ -0,0 +1,47 @@
+package service
+
+import (
+	"backend/modules/webhook/models"
+	"backend/modules/webhook/repository"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Envelope — тіло запиту вебхука
+type Envelope struct {
+	ID        uuid.UUID `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// Enqueue ставить подію в чергу для всіх підписаних вебхуків; надсилає їх воркер.
+// Викликається з синхронних підписників шини подій, тож доставки комітяться разом зі зміною
+func Enqueue(db *gorm.DB, event string, data any) error {
+	now := time.Now()
+	envelope := Envelope{ID: uuid.New(), Event: event, CreatedAt: now, Data: data}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	_, err = repository.CreateDeliveries(db, event, envelope.ID, payload, now)
+	return err
+}
+
+// Ping ставить у чергу перевірочну подію для одного вебхука
+func Ping(db *gorm.DB, endpoint *models.WebhookEndpoint) (*models.WebhookDelivery, error) {
+	now := time.Now()
+	envelope := Envelope{
+		ID:        uuid.New(),
+		Event:     models.EventPing,
+		CreatedAt: now,
+		Data:      map[string]any{"endpoint_id": endpoint.ID, "message": "Webhook is configured correctly"},
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return repository.CreateDelivery(db, endpoint, envelope.Event, envelope.ID, payload, now)
+}
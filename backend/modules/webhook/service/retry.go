@@ -0,0 +1,26 @@
+package service
+
+import (
+	"time"
+)
+
+// MaxAttempts — скільки разів намагаємося доставити подію, перш ніж позначити доставку як failed
+const MaxAttempts = 10
+
+const (
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = 6 * time.Hour
+)
+
+// RetryDelay — пауза після невдалої спроби attempt (з 1): 30s, 1m, 2m, 4m… але не більше 6 годин.
+// Усі 10 спроб розтягуються приблизно на 8,5 години
+func RetryDelay(attempt int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
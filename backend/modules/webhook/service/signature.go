@@ -0,0 +1,22 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader містить "t=<unix time>,v1=<hex HMAC-SHA256 від "<t>.<тіло запиту>">".
+// Отримувач перевіряє підпис своїм секретом і відкидає запити зі старою міткою часу
+const SignatureHeader = "Webhook-Signature"
+
+func Sign(secret string, payload []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"backend/internal/services/safehttp"
+	"backend/modules/webhook/models"
+	"backend/modules/webhook/repository"
+	"bytes"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Скільки доставок воркер забирає за один прохід і скільки надсилає одночасно
+	batchSize   = 20
+	concurrency = 5
+	// Час, на який забрана доставка ховається від інших воркерів
+	claimLease = 2 * time.Minute
+	// Скільки відповіді отримувача зберігається в журналі
+	responseLimit = 2048
+)
+
+// Client надсилає запити вебхуків; тайм-аут обмежує повільних отримувачів. Внутрішні адреси
+// відхиляються під час з'єднання, а переспрямування не виконуються: відповідь 3xx — невдала доставка
+var Client = newClient()
+
+func newClient() *http.Client {
+	client := safehttp.NewClient(10 * time.Second)
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return client
+}
+
+// StartWorker періодично надсилає доставки з черги. Кілька інстансів можуть працювати одночасно
+func StartWorker(db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Повний пакет означає, що в черзі є ще — беремо наступний без очікування
+		for {
+			processed, err := ProcessDue(db, time.Now())
+			if err != nil {
+				log.Printf("❌ Webhook worker failed: %v", err)
+			}
+			if processed < batchSize {
+				break
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// ProcessDue забирає доставки, час яких настав, і надсилає їх; повертає кількість забраних
+func ProcessDue(db *gorm.DB, now time.Time) (int, error) {
+	deliveries, err := repository.ClaimDueDeliveries(db, now, batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i := range deliveries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := Attempt(db, delivery); err != nil {
+				log.Printf("❌ Cannot save webhook delivery %s: %v", delivery.ID, err)
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// Attempt надсилає доставку один раз і зберігає результат: успіх, наступна спроба з паузою або failed.
+// Перевірочна подія не повторюється — адміністратор бачить результат одразу
+func Attempt(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	status, body, err := send(&delivery.Endpoint, delivery, now)
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	switch {
+	case err == nil:
+		delivery.Status = models.StatusDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	case delivery.Attempts >= MaxAttempts || delivery.Event == models.EventPing:
+		delivery.Status = models.StatusFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = models.StatusPending
+		delivery.NextAttemptAt = now.Add(RetryDelay(delivery.Attempts))
+		delivery.LastError = err.Error()
+	}
+	return repository.SaveAttempt(db, delivery)
+}
+
+func send(endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery, now time.Time) (int, string, error) {
+	if !endpoint.Active {
+		return 0, "", fmt.Errorf("webhook endpoint is disabled")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Client.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "backend-webhooks/1.0")
+	req.Header.Set("Webhook-Event", delivery.Event)
+	req.Header.Set("Webhook-Id", delivery.EventID.String())
+	req.Header.Set("Webhook-Delivery", delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, delivery.Payload, now))
+
+	resp, err := Client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, responseLimit))
+	body := strings.ToValidUTF8(string(data), "")
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, body, fmt.Errorf("endpoint responded with %d", resp.StatusCode)
+	}
+	return resp.StatusCode, body, nil
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"backend/internal/services/events"
+	blogModels "backend/modules/blog/models"
+	calendarModels "backend/modules/calendar/models"
+	itemModels "backend/modules/item/models"
+	userModels "backend/modules/user/models"
+	"backend/modules/webhook/models"
+	"backend/modules/webhook/service"
+	"gorm.io/gorm"
+)
+
+// RegisterSubscribers ставить доставки вебхуків у чергу в транзакції події:
+// відкочена зміна не залишає вебхука про те, чого не сталося
+func RegisterSubscribers(bus *events.Bus) {
+	events.Subscribe(bus, func(tx *gorm.DB, event itemModels.ItemCreated) error {
+		return service.Enqueue(tx, models.EventItemCreated, event.Item)
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event itemModels.ItemUpdated) error {
+		return service.Enqueue(tx, models.EventItemUpdated, event.Item)
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event blogModels.BlogPublished) error {
+		return service.Enqueue(tx, models.EventBlogPublished, event)
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event userModels.UserCreated) error {
+		return service.Enqueue(tx, models.EventUserCreated, event.User)
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event calendarModels.EventCreated) error {
+		return service.Enqueue(tx, models.EventCalendarEventCreated, event.Event)
+	})
+}
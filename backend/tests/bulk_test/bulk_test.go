@@ -0,0 +1,87 @@
+package bulk_test
+
+import (
+	"backend/internal/entities"
+	"encoding/json"
+	"github.com/google/uuid"
+	"math/big"
+	"testing"
+)
+
+func TestBulkRequestValidate(t *testing.T) {
+	published := entities.StatePublished
+	invalidState := entities.PublicationState("unknown")
+	categoryId := uuid.New()
+	ids := []uuid.UUID{uuid.New()}
+	tooMany := make([]uuid.UUID, entities.BulkMaxItems+1)
+
+	supported := []entities.BulkOperation{
+		entities.BulkSetState, entities.BulkSetCategory, entities.BulkSetLanguage,
+		entities.BulkDelete, entities.BulkShiftPrice,
+	}
+	cases := []struct {
+		name    string
+		request entities.BulkRequest
+		valid   bool
+	}{
+		{"delete by ids", entities.BulkRequest{IDs: ids, Operation: entities.BulkDelete}, true},
+		{"delete by filter", entities.BulkRequest{Filter: &entities.BulkFilter{Language: "pl"}, Operation: entities.BulkDelete}, true},
+		{"unsupported operation", entities.BulkRequest{IDs: ids, Operation: "archive"}, false},
+		{"no ids or filter", entities.BulkRequest{Operation: entities.BulkDelete}, false},
+		{"ids and filter", entities.BulkRequest{IDs: ids, Filter: &entities.BulkFilter{}, Operation: entities.BulkDelete}, false},
+		{"too many ids", entities.BulkRequest{IDs: tooMany, Operation: entities.BulkDelete}, false},
+		{"set state", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetState, State: &published}, true},
+		{"set state without state", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetState}, false},
+		{"set invalid state", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetState, State: &invalidState}, false},
+		{"set category", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetCategory, CategoryID: &categoryId}, true},
+		{"set category without id", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetCategory}, false},
+		{"set language", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetLanguage, Language: "en"}, true},
+		{"set empty language", entities.BulkRequest{IDs: ids, Operation: entities.BulkSetLanguage}, false},
+		{"shift price", entities.BulkRequest{IDs: ids, Operation: entities.BulkShiftPrice, Percent: "15"}, true},
+		{"shift price without percent", entities.BulkRequest{IDs: ids, Operation: entities.BulkShiftPrice}, false},
+		{"shift price by -100%", entities.BulkRequest{IDs: ids, Operation: entities.BulkShiftPrice, Percent: "-100"}, false},
+	}
+	for _, tc := range cases {
+		err := tc.request.Validate(supported...)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: expected valid=%v, got %v", tc.name, tc.valid, err)
+		}
+	}
+
+	// Операція має бути серед підтримуваних конкретним обробником
+	request := entities.BulkRequest{IDs: ids, Operation: entities.BulkShiftPrice, Percent: "10"}
+	if err := request.Validate(entities.BulkDelete); err == nil {
+		t.Error("expected an error for an operation the caller does not support")
+	}
+}
+
+func TestBulkRequestPriceFactor(t *testing.T) {
+	cases := []struct {
+		percent  json.Number
+		expected *big.Rat
+	}{
+		{"-10", big.NewRat(9, 10)},
+		{"25", big.NewRat(5, 4)},
+		{"0", big.NewRat(1, 1)},
+		{"12.5", big.NewRat(9, 8)},
+		{"-99.9", big.NewRat(1, 1000)},
+	}
+	for _, tc := range cases {
+		request := entities.BulkRequest{Percent: tc.percent}
+		factor, err := request.PriceFactor()
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.percent, err)
+			continue
+		}
+		if factor.Cmp(tc.expected) != 0 {
+			t.Errorf("%s: expected %s, got %s", tc.percent, tc.expected, factor)
+		}
+	}
+
+	for _, percent := range []json.Number{"", "abc", "-100", "-150"} {
+		request := entities.BulkRequest{Percent: percent}
+		if _, err := request.PriceFactor(); err == nil {
+			t.Errorf("%q: expected an error", percent)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package category_test
+
+import (
+	"backend/modules/category/repository"
+	"strings"
+	"testing"
+)
+
+func TestLegacyCategorySlug(t *testing.T) {
+	cases := map[string]string{
+		"Home & Garden": "home-garden",
+		"  Ogród ":      "ogrod",
+		"Одяг":          "odiah",
+	}
+	for name, expected := range cases {
+		slug, fallback := repository.LegacyCategorySlug(name)
+		if slug != expected || fallback {
+			t.Errorf("%q: expected %q, got %q (fallback %v)", name, expected, slug, fallback)
+		}
+	}
+}
+
+func TestLegacyCategorySlugFallback(t *testing.T) {
+	first, fallback := repository.LegacyCategorySlug("家具")
+	if !fallback || !strings.HasPrefix(first, "category-") {
+		t.Fatalf("expected a generated slug, got %q (fallback %v)", first, fallback)
+	}
+	if again, _ := repository.LegacyCategorySlug(" 家具 "); again != first {
+		t.Errorf("the generated slug must be stable, got %q and %q", first, again)
+	}
+	if other, _ := repository.LegacyCategorySlug("衣服"); other == first {
+		t.Errorf("different names must not share a generated slug: %q", other)
+	}
+}
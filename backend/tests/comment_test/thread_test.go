@@ -0,0 +1,30 @@
+package comment_test
+
+import (
+	"backend/modules/comment/models"
+	"backend/modules/comment/repository"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestBuildThread(t *testing.T) {
+	root := &models.CommentGet{ID: uuid.New()}
+	reply := &models.CommentGet{ID: uuid.New(), ParentID: &root.ID}
+	nested := &models.CommentGet{ID: uuid.New(), ParentID: &reply.ID}
+	hidden := uuid.New()
+	orphan := &models.CommentGet{ID: uuid.New(), ParentID: &hidden}
+	orphanReply := &models.CommentGet{ID: uuid.New(), ParentID: &orphan.ID}
+	second := &models.CommentGet{ID: uuid.New()}
+
+	thread := repository.BuildThread([]*models.CommentGet{root, reply, orphan, nested, orphanReply, second})
+
+	if len(thread) != 2 || thread[0] != root || thread[1] != second {
+		t.Fatalf("expected two root comments in order, got %d", len(thread))
+	}
+	if len(root.Replies) != 1 || root.Replies[0] != reply {
+		t.Fatalf("expected one reply to the root comment, got %d", len(root.Replies))
+	}
+	if len(reply.Replies) != 1 || reply.Replies[0] != nested {
+		t.Fatalf("expected the nested reply under the first reply")
+	}
+}
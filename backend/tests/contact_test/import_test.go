@@ -0,0 +1,45 @@
+package contact_test
+
+import (
+	"backend/modules/contact/models"
+	"backend/modules/contact/service"
+	"testing"
+)
+
+func TestParseImportRows(t *testing.T) {
+	rows, rowErrors, err := service.ParseImportRows([][]string{
+		{"Name", "First Name", "Last Name", "Email", "Company", "Country", "Tags"},
+		{"Acme Sp. z o.o.", "", "", "biuro@acme.pl", "", "pl", "vip; hurt"},
+		{"", "Anna", "Nowak", "Anna.Nowak@Acme.pl", "Acme Sp. z o.o.", "", ""},
+		{"", "", "", "", "", "", ""},
+		{"", "", "", "not-an-email", "", "", ""},
+		{"Beta", "", "", "", "Acme", "", ""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 valid rows, got %+v", rows)
+	}
+	company, person := rows[0], rows[1]
+	if company.Kind != models.KindCompany || company.Address.Country != "PL" || len(company.Tags) != 2 || company.Tags[1] != "hurt" {
+		t.Errorf("unexpected company row %+v", company)
+	}
+	if person.Kind != models.KindPerson || person.Name != "Anna Nowak" || person.Email != "anna.nowak@acme.pl" || person.Line != 3 {
+		t.Errorf("unexpected person row %+v", person)
+	}
+
+	if len(rowErrors) != 2 || rowErrors[0].Row != 5 || rowErrors[1].Row != 6 {
+		t.Errorf("expected errors in rows 5 and 6, got %+v", rowErrors)
+	}
+}
+
+func TestParseImportRowsRejectsUnknownColumns(t *testing.T) {
+	if _, _, err := service.ParseImportRows([][]string{{"name", "fax"}}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+	if _, _, err := service.ParseImportRows([][]string{{"phone"}, {"123"}}); err == nil {
+		t.Error("expected an error without a name or email column")
+	}
+}
@@ -0,0 +1,35 @@
+package contact_test
+
+import (
+	"backend/modules/contact/models"
+	"backend/modules/contact/service"
+	"testing"
+	"time"
+)
+
+func TestMergeTimeline(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+
+	notes := []models.TimelineEntry{{Type: models.TimelineNote, At: at(5)}, {Type: models.TimelineNote, At: at(1)}}
+	orders := []models.TimelineEntry{{Type: models.TimelineOrder, At: at(4)}, {Type: models.TimelineOrder, At: at(2)}}
+	events := []models.TimelineEntry{{Type: models.TimelineEvent, At: at(3)}}
+
+	timeline := service.MergeTimeline(3, notes, orders, events)
+	if len(timeline.Data) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(timeline.Data))
+	}
+	for i, expected := range []string{models.TimelineNote, models.TimelineOrder, models.TimelineEvent} {
+		if timeline.Data[i].Type != expected {
+			t.Errorf("entry %d: expected %s, got %s", i, expected, timeline.Data[i].Type)
+		}
+	}
+	if timeline.Next == nil || !timeline.Next.Equal(at(3)) {
+		t.Errorf("expected next cursor %v, got %v", at(3), timeline.Next)
+	}
+
+	last := service.MergeTimeline(10, notes, nil, events)
+	if len(last.Data) != 3 || last.Next != nil {
+		t.Errorf("expected the last page without a cursor, got %+v", last)
+	}
+}
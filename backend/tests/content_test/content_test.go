@@ -0,0 +1,101 @@
+package content_test
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/content"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeRemovesScripts(t *testing.T) {
+	source := `<p onclick="steal()">Hello <script>alert(1)</script><b>world</b></p>` +
+		`<a href="javascript:alert(1)">bad</a><a href="https://example.com" target="_blank">good</a>` +
+		`<img src="x" onerror="alert(1)"><iframe src="https://evil.example"></iframe>`
+
+	document, err := content.Render(source, entities.ContentHTML)
+	if err != nil {
+		t.Fatalf("Error rendering HTML: %v", err)
+	}
+
+	for _, forbidden := range []string{"script", "onclick", "onerror", "javascript:", "iframe", "target"} {
+		if strings.Contains(document.HTML, forbidden) {
+			t.Errorf("Sanitized HTML contains %q: %s", forbidden, document.HTML)
+		}
+	}
+	for _, expected := range []string{"<b>world</b>", `href="https://example.com"`, `<img src="x"/>`} {
+		if !strings.Contains(document.HTML, expected) {
+			t.Errorf("Sanitized HTML does not contain %q: %s", expected, document.HTML)
+		}
+	}
+}
+
+func TestMarkdownRendering(t *testing.T) {
+	source := "# Title\n\nSome **bold** and *italic* text with `code` and [a link](https://example.com).\n\n" +
+		"- first\n- second\n\n1. one\n2. two\n\n> quote\n\n```go\nfmt.Println(\"<hi>\")\n```\n\n<script>alert(1)</script>"
+
+	document, err := content.Render(source, entities.ContentMarkdown)
+	if err != nil {
+		t.Fatalf("Error rendering Markdown: %v", err)
+	}
+
+	expected := []string{
+		`<h1 id="title">Title</h1>`,
+		"<strong>bold</strong>",
+		"<em>italic</em>",
+		"<code>code</code>",
+		`<a href="https://example.com" rel="nofollow noopener">a link</a>`,
+		"<ul>\n<li>first</li>\n<li>second</li>\n</ul>",
+		"<ol>\n<li>one</li>\n<li>two</li>\n</ol>",
+		"<blockquote>\n<p>quote</p>\n</blockquote>",
+		`<pre><code class="language-go">fmt.Println(&#34;&lt;hi&gt;&#34;)</code></pre>`,
+		"&lt;script&gt;",
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(document.HTML, fragment) {
+			t.Errorf("Rendered HTML does not contain %q:\n%s", fragment, document.HTML)
+		}
+	}
+}
+
+func TestTableOfContents(t *testing.T) {
+	source := "## Intro\n\ntext\n\n### Details\n\n## Intro\n\n## Вступ"
+
+	document, err := content.Render(source, entities.ContentMarkdown)
+	if err != nil {
+		t.Fatalf("Error rendering Markdown: %v", err)
+	}
+
+	expected := entities.Headings{
+		{Level: 2, ID: "intro", Text: "Intro"},
+		{Level: 3, ID: "details", Text: "Details"},
+		{Level: 2, ID: "intro-2", Text: "Intro"},
+		{Level: 2, ID: "vstup", Text: "Вступ"},
+	}
+	if len(document.TOC) != len(expected) {
+		t.Fatalf("Expected %d headings, got %+v", len(expected), document.TOC)
+	}
+	for i, heading := range expected {
+		if document.TOC[i] != heading {
+			t.Errorf("Expected heading %+v, got %+v", heading, document.TOC[i])
+		}
+	}
+}
+
+func TestExcerptAndReadingTime(t *testing.T) {
+	source := "<h2>Heading</h2><p>" + strings.Repeat("word ", 450) + "</p>"
+
+	document, err := content.Render(source, entities.ContentHTML)
+	if err != nil {
+		t.Fatalf("Error rendering HTML: %v", err)
+	}
+
+	if document.ReadingTime != 3 {
+		t.Errorf("Expected reading time 3, got %d", document.ReadingTime)
+	}
+	if strings.HasPrefix(document.Excerpt, "Heading") {
+		t.Errorf("Excerpt should skip headings: %q", document.Excerpt)
+	}
+	if !strings.HasSuffix(document.Excerpt, "…") || len([]rune(document.Excerpt)) > 201 {
+		t.Errorf("Unexpected excerpt: %q", document.Excerpt)
+	}
+}
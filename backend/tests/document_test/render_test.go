@@ -0,0 +1,182 @@
+package document_test
+
+import (
+	"backend/internal/services/pdf"
+	"backend/modules/document/models"
+	"backend/modules/document/service"
+	orderModels "backend/modules/order/models"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+func TestRenderInvoiceTemplate(t *testing.T) {
+	service.TemplateDir = "../../web/templates"
+
+	sku := "MUG-1"
+	order := &orderModels.Order{
+		Number:          42,
+		Currency:        "PLN",
+		Email:           "jan@example.com",
+		FullName:        "Jan Kowalski",
+		ShippingAddress: "ul. Długa 5\n00-001 Łódź",
+		Lines: []orderModels.OrderLine{
+			{Title: "Kubek | ceramiczny", Sku: &sku, Price: 4999, Quantity: 2, Total: 9998},
+		},
+	}
+	lines := service.BuildLines(order.Lines, 23, "PLN")
+	data := &models.DocumentData{
+		Type:      models.TypeInvoice,
+		Title:     "Faktura VAT",
+		Number:    "FV/2026/00001",
+		IssuedAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		SaleDate:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Seller:    models.CompanySettings{Name: "Sklep Sp. z o.o.", Address: "ul. Krótka 1\nKraków", TaxID: "1234567890", VatRate: 23},
+		Order:     order,
+		Currency:  "PLN",
+		Lines:     lines,
+		Breakdown: service.Breakdown(lines, "PLN"),
+	}
+	for _, row := range data.Breakdown {
+		data.Net, data.Vat, data.Gross = data.Net+row.Net, data.Vat+row.Vat, data.Gross+row.Gross
+	}
+
+	content, err := service.Render(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkStructure(t, content)
+	text := pageText(t, content)
+	for _, expected := range []string{"FV/2026/00001", "Kubek / ceramiczny (MUG-1)", "1234567890", "Jan Kowalski", "00-001 Łódź", "Do zapłaty: 99,98 PLN"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q in the page content", expected)
+		}
+	}
+	if !bytes.Contains(content, []byte("/FontFile2")) {
+		t.Error("expected an embedded TrueType font")
+	}
+}
+
+func TestRenderKeepsCyrillic(t *testing.T) {
+	content, err := pdf.Render("Рахунок", "# Рахунок-фактура\n| Товар | Ціна |\n|:--|--:|\n| Чашка «Київ» | 1\u00a0234,56 |\n\\Олена Коваль (ФОП)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, content)
+
+	text := pageText(t, content)
+	for _, expected := range []string{"Рахунок-фактура", "Чашка «Київ»", "1\u00a0234,56", "Олена Коваль (ФОП)"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q in the page content", expected)
+		}
+	}
+	if strings.Contains(text, "?") {
+		t.Errorf("unexpected replacement characters in %q", text)
+	}
+}
+
+func TestRenderBreaksLongTablesAcrossPages(t *testing.T) {
+	var markup strings.Builder
+	markup.WriteString("| Name | Price |\n|:--|--:|\n")
+	for i := 0; i < 200; i++ {
+		markup.WriteString("| Item " + strconv.Itoa(i) + " | 1,00 |\n")
+	}
+
+	content, err := pdf.Render("Long", markup.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkStructure(t, content)
+
+	pages := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(content)
+	if pages == nil || string(pages[1]) == "1" {
+		t.Fatalf("expected several pages, got %s", pages)
+	}
+	// Заголовок таблиці повторюється на кожній сторінці
+	if count, _ := strconv.Atoi(string(pages[1])); strings.Count(pageText(t, content), "\nName\n") != count {
+		t.Errorf("expected the header on each of %d pages", count)
+	}
+}
+
+// checkStructure перевіряє, що таблиця xref вказує на початки відповідних об'єктів
+func checkStructure(t *testing.T, content []byte) {
+	t.Helper()
+	if !bytes.HasPrefix(content, []byte("%PDF-1.")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+
+	start := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(content)
+	if start == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(start[1]))
+	if !bytes.HasPrefix(content[xref:], []byte("xref\n")) {
+		t.Fatal("startxref does not point to the xref table")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(content[offset:], []byte(strconv.Itoa(i+1)+" 0 obj")) {
+			t.Errorf("xref entry %d points to the wrong offset", i+1)
+		}
+	}
+}
+
+// pageText повертає рядки, виведені операторами Tj на сторінках, по одному на рядок.
+// Текст шрифту Identity-H записано в UTF-16BE
+func pageText(t *testing.T, content []byte) string {
+	t.Helper()
+	text := strings.Builder{}
+	text.WriteString("\n")
+	streams := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(content, -1)
+	for _, stream := range streams {
+		reader, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		if err != nil {
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range regexp.MustCompile(`(?s)\(((?:[^\\]|\\.)*?)\) Tj`).FindAllSubmatch(data, -1) {
+			text.WriteString(decodeUTF16(unescape(match[1])))
+			text.WriteString("\n")
+		}
+	}
+	return text.String()
+}
+
+func unescape(value []byte) []byte {
+	var result []byte
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'r':
+				result = append(result, '\r')
+				continue
+			case 'n':
+				result = append(result, '\n')
+				continue
+			}
+		}
+		result = append(result, value[i])
+	}
+	return result
+}
+
+func decodeUTF16(value []byte) string {
+	units := make([]uint16, 0, len(value)/2)
+	for i := 0; i+1 < len(value); i += 2 {
+		units = append(units, uint16(value[i])<<8|uint16(value[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
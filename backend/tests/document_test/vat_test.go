@@ -0,0 +1,72 @@
+package document_test
+
+import (
+	"backend/modules/document/repository"
+	"backend/modules/document/service"
+	orderModels "backend/modules/order/models"
+	"testing"
+)
+
+func TestSplitGross(t *testing.T) {
+	cases := []struct {
+		gross    int64
+		rate     int
+		net, vat int64
+	}{
+		{12300, 23, 10000, 2300},
+		{999, 23, 812, 187},
+		{10800, 8, 10000, 800},
+		{500, 0, 500, 0},
+	}
+	for _, tc := range cases {
+		net, vat := service.SplitGross(tc.gross, tc.rate, "PLN")
+		if net != tc.net || vat != tc.vat {
+			t.Errorf("%d at %d%%: expected %d + %d, got %d + %d", tc.gross, tc.rate, tc.net, tc.vat, net, vat)
+		}
+	}
+}
+
+func TestBreakdownGroupsByRate(t *testing.T) {
+	reduced := 8
+	lines := service.BuildLines([]orderModels.OrderLine{
+		{Title: "Book", Price: 5400, Quantity: 2, Total: 10800, VatRate: &reduced},
+		{Title: "Mug", Price: 999, Quantity: 1, Total: 999},
+		{Title: "Poster", Price: 999, Quantity: 1, Total: 999},
+	}, 23, "PLN")
+
+	if lines[1].VatRate != 23 || lines[0].VatRate != 8 || lines[2].Position != 3 {
+		t.Fatalf("unexpected lines %+v", lines)
+	}
+
+	rows := service.Breakdown(lines, "PLN")
+	if len(rows) != 2 || rows[0].Rate != 23 || rows[1].Rate != 8 {
+		t.Fatalf("expected rows for 23%% and 8%%, got %+v", rows)
+	}
+	// ПДВ рахується від суми за ставкою: 1998 → 1624 + 374, а не 2 × (812 + 187)
+	if rows[0].Gross != 1998 || rows[0].Net != 1624 || rows[0].Vat != 374 {
+		t.Errorf("unexpected 23%% row %+v", rows[0])
+	}
+	if rows[1].Net != 10000 || rows[1].Vat != 800 {
+		t.Errorf("unexpected 8%% row %+v", rows[1])
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	amounts := map[int64]string{
+		123456:   "1 234,56",
+		5:        "0,05",
+		-1234500: "-12 345,00",
+	}
+	for amount, expected := range amounts {
+		if got := service.FormatAmount(amount, "PLN"); got != expected {
+			t.Errorf("FormatAmount(%d): expected %q, got %q", amount, expected, got)
+		}
+	}
+
+	if got := repository.FormatNumber("FV", 2026, 12); got != "FV/2026/00012" {
+		t.Errorf("unexpected invoice number %q", got)
+	}
+	if got := repository.FormatNumber(" ", 2026, 1); got != "2026/00001" {
+		t.Errorf("unexpected number without prefix %q", got)
+	}
+}
@@ -0,0 +1,158 @@
+package events_test
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/events"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type created struct {
+	Title string `json:"title"`
+}
+
+func (created) EventName() string { return "test.created" }
+
+type removed struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (removed) EventName() string { return "test.removed" }
+
+func TestSyncHandlers(t *testing.T) {
+	bus := events.NewBus()
+	var calls []string
+	events.Subscribe(bus, func(tx *gorm.DB, event created) error {
+		calls = append(calls, "first:"+event.Title)
+		return nil
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event created) error {
+		calls = append(calls, "second:"+event.Title)
+		return nil
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event removed) error {
+		calls = append(calls, "removed")
+		return nil
+	})
+
+	// Без асинхронних обробників Publish до бази не звертається
+	if err := bus.Publish(nil, created{Title: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"first:a", "second:a"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+}
+
+func TestSyncHandlerErrorStopsPublish(t *testing.T) {
+	bus := events.NewBus()
+	failure := errors.New("boom")
+	secondCalled := false
+	events.Subscribe(bus, func(tx *gorm.DB, event created) error {
+		return failure
+	})
+	events.Subscribe(bus, func(tx *gorm.DB, event created) error {
+		secondCalled = true
+		return nil
+	})
+
+	err := bus.Publish(nil, created{})
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "test.created") {
+		t.Errorf("expected wrapped handler error, got %v", err)
+	}
+	if secondCalled {
+		t.Error("handlers after a failed one must not run")
+	}
+}
+
+func TestHandleDecodesPayload(t *testing.T) {
+	bus := events.NewBus()
+	var received created
+	events.SubscribeAsync(bus, "test.store", func(db *gorm.DB, event created) error {
+		received = event
+		return nil
+	})
+
+	record := &entities.OutboxEvent{Name: "test.created", Handler: "test.store", Payload: entities.JSON(`{"title":"stored"}`)}
+	if err := bus.Handle(nil, record); err != nil {
+		t.Fatal(err)
+	}
+	if received.Title != "stored" {
+		t.Errorf("expected decoded title, got %q", received.Title)
+	}
+}
+
+func TestHandleObserver(t *testing.T) {
+	bus := events.NewBus()
+	var name, payload string
+	bus.Observe("test.audit", func(db *gorm.DB, eventName string, data []byte) error {
+		name, payload = eventName, string(data)
+		return nil
+	})
+
+	record := &entities.OutboxEvent{Name: "test.removed", Handler: "test.audit", Payload: entities.JSON(`{"id":"x"}`)}
+	if err := bus.Handle(nil, record); err != nil {
+		t.Fatal(err)
+	}
+	if name != "test.removed" || payload != `{"id":"x"}` {
+		t.Errorf("observer got %s %s", name, payload)
+	}
+
+	record.Handler = "test.missing"
+	if err := bus.Handle(nil, record); err == nil {
+		t.Error("expected error for an unknown handler")
+	}
+}
+
+func TestDuplicateAsyncHandlerPanics(t *testing.T) {
+	bus := events.NewBus()
+	handler := func(db *gorm.DB, event created) error { return nil }
+	events.SubscribeAsync(bus, "test.store", handler)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a duplicate handler name")
+		}
+	}()
+	events.SubscribeAsync(bus, "test.store", handler)
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  10 * time.Second,
+		2:  20 * time.Second,
+		4:  80 * time.Second,
+		9:  2560 * time.Second,
+		10: time.Hour,
+		30: time.Hour,
+	}
+	for attempt, expected := range cases {
+		if delay := events.RetryDelay(attempt); delay != expected {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, expected, delay)
+		}
+	}
+}
+
+func TestContentIDs(t *testing.T) {
+	group, translation := uuid.New(), uuid.New()
+	cases := []struct {
+		event    events.ContentDeleted
+		expected []uuid.UUID
+	}{
+		{events.ContentDeleted{ID: translation, TranslationGroupID: group}, []uuid.UUID{translation}},
+		{events.ContentDeleted{ID: translation, TranslationGroupID: group, LastTranslation: true}, []uuid.UUID{translation, group}},
+		{events.ContentDeleted{ID: group, TranslationGroupID: group, LastTranslation: true}, []uuid.UUID{group}},
+		{events.ContentDeleted{ID: group, TranslationGroupID: group}, nil},
+	}
+	for i, c := range cases {
+		if ids := c.event.ContentIDs(); !reflect.DeepEqual(ids, c.expected) {
+			t.Errorf("case %d: expected %v, got %v", i, c.expected, ids)
+		}
+	}
+}
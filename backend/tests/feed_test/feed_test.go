@@ -0,0 +1,107 @@
+package feed_test
+
+import (
+	"backend/internal/services/feed"
+	"backend/modules/feed/service"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var published = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func TestRSSIncludesContentAndEnclosures(t *testing.T) {
+	body, err := feed.RSS(feed.Channel{Title: "Blog", Link: "https://example.com/en/blog", SelfLink: "https://example.com/feeds/en/rss.xml", Language: "en"}, []feed.Entry{{
+		ID:         "urn:uuid:1",
+		Title:      "Fish & chips",
+		Link:       "https://example.com/en/blog/fish-chips",
+		Content:    "<p>Hello</p>",
+		Published:  published,
+		Enclosures: []feed.Enclosure{{URL: "https://cdn.example.com/a.jpg", Type: "image/jpeg"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xml := string(body)
+	for _, expected := range []string{
+		`<rss version="2.0"`,
+		`<atom:link href="https://example.com/feeds/en/rss.xml" rel="self" type="application/rss+xml">`,
+		`<title>Fish &amp; chips</title>`,
+		`<content:encoded><![CDATA[<p>Hello</p>]]></content:encoded>`,
+		`<pubDate>Sat, 01 Mar 2025 10:00:00 +0000</pubDate>`,
+		`<enclosure url="https://cdn.example.com/a.jpg" length="0" type="image/jpeg">`,
+	} {
+		if !strings.Contains(xml, expected) {
+			t.Errorf("RSS does not contain %s:\n%s", expected, xml)
+		}
+	}
+}
+
+func TestAtomFallsBackToFeedAuthor(t *testing.T) {
+	body, err := feed.Atom(feed.Channel{Title: "Blog", Language: "uk", Updated: published}, []feed.Entry{{ID: "urn:uuid:1", Title: "Пост", Published: published, Updated: published}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xml := string(body)
+	for _, expected := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="uk">`,
+		`<author>`,
+		`<name>Blog</name>`,
+		`<updated>2025-03-01T10:00:00Z</updated>`,
+	} {
+		if !strings.Contains(xml, expected) {
+			t.Errorf("Atom does not contain %s:\n%s", expected, xml)
+		}
+	}
+}
+
+func TestSitemapAlternates(t *testing.T) {
+	body, err := feed.Sitemap([]feed.URL{
+		{Loc: "https://example.com/en/blog/a", Alternates: []feed.Alternate{{Language: "en", Href: "https://example.com/en/blog/a"}, {Language: "uk", Href: "https://example.com/uk/blog/a-uk"}}},
+		{Loc: "https://example.com/en/blog/b", Alternates: []feed.Alternate{{Language: "en", Href: "https://example.com/en/blog/b"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xml := string(body)
+	if !strings.Contains(xml, `<xhtml:link rel="alternate" hreflang="uk" href="https://example.com/uk/blog/a-uk">`) {
+		t.Errorf("sitemap does not contain the hreflang alternate:\n%s", xml)
+	}
+	if strings.Count(xml, "<xhtml:link") != 2 {
+		t.Errorf("a page without translations must not list alternates:\n%s", xml)
+	}
+}
+
+func TestCacheRebuildsOnVersionChange(t *testing.T) {
+	cache := feed.NewCache()
+	builds := 0
+	build := func() ([]byte, error) {
+		builds++
+		return []byte("feed"), nil
+	}
+
+	_, _ = cache.Get("rss:en", "v1", build)
+	_, _ = cache.Get("rss:en", "v1", build)
+	if builds != 1 {
+		t.Fatalf("expected a cached document, built %d times", builds)
+	}
+	_, _ = cache.Get("rss:en", "v2", build)
+	if builds != 2 {
+		t.Fatalf("expected a rebuild after the version changed, built %d times", builds)
+	}
+
+	if _, err := cache.Get("rss:en", "v3", func() ([]byte, error) { return nil, errors.New("boom") }); err == nil {
+		t.Fatal("expected the build error")
+	}
+}
+
+func TestFeedURLUsesSiteURL(t *testing.T) {
+	t.Setenv("SITE_URL", "https://example.com/")
+	if got := service.FeedURL(service.FormatAtom, "en"); got != "https://example.com/feeds/en/atom.xml" {
+		t.Fatalf("Unexpected self link %q", got)
+	}
+}
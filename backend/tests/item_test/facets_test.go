@@ -0,0 +1,72 @@
+package item_test
+
+import (
+	"backend/modules/item/handlers"
+	"backend/modules/item/repository"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSortPriceBounds(t *testing.T) {
+	input := []int64{10000, 0, 5000, 10000}
+	bounds := repository.SortPriceBounds(input)
+	if !reflect.DeepEqual(bounds, []int64{0, 5000, 10000}) {
+		t.Errorf("unexpected bounds %v", bounds)
+	}
+	if input[0] != 10000 {
+		t.Errorf("the input must not be modified: %v", input)
+	}
+}
+
+func TestBuildPriceBuckets(t *testing.T) {
+	buckets := repository.BuildPriceBuckets([]int64{0, 5000, 10000}, map[int]int64{-1: 7, 0: 2, 2: 3})
+	if len(buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %d", len(buckets))
+	}
+
+	expected := []struct {
+		from  int64
+		to    int64
+		count int64
+	}{{0, 5000, 2}, {5000, 10000, 0}}
+	for i, want := range expected {
+		bucket := buckets[i]
+		if bucket.From != want.from || bucket.To == nil || *bucket.To != want.to || bucket.Count != want.count {
+			t.Errorf("bucket %d: expected [%d, %d) x%d, got %+v", i, want.from, want.to, want.count, bucket)
+		}
+	}
+	if last := buckets[2]; last.From != 10000 || last.To != nil || last.Count != 3 {
+		t.Errorf("the last bucket must be open-ended, got %+v", last)
+	}
+}
+
+func TestParsePriceBuckets(t *testing.T) {
+	bounds, err := handlers.ParsePriceBuckets(" 0, 5000,,10000 ")
+	if err != nil || !reflect.DeepEqual(bounds, []int64{0, 5000, 10000}) {
+		t.Errorf("unexpected result %v (%v)", bounds, err)
+	}
+	for _, value := range []string{"0,abc", "-100", "1.5"} {
+		if _, err := handlers.ParsePriceBuckets(value); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestParseAttributeFilters(t *testing.T) {
+	query := url.Values{
+		"attr.color": {"red", "blue, green"},
+		"attr.size":  {"S,M"},
+		"attr.":      {"ignored"},
+		"language":   {"pl"},
+	}
+	filters := handlers.ParseAttributeFilters(query)
+
+	expected := map[string][]string{
+		"color": {"red", "blue", "green"},
+		"size":  {"S", "M"},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("expected %v, got %v", expected, filters)
+	}
+}
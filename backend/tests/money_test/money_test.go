@@ -0,0 +1,82 @@
+package money_test
+
+import (
+	"backend/internal/services/money"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	m, err := money.Parse("12,99", "pln")
+	if err != nil {
+		t.Fatalf("Error parsing amount: %v", err)
+	}
+	if m.Amount != 1299 || m.Currency != "PLN" {
+		t.Fatalf("Unexpected result: %+v", m)
+	}
+	if m.Decimal() != "12.99" {
+		t.Fatalf("Unexpected decimal: %s", m.Decimal())
+	}
+
+	if _, err = money.Parse("1.999", "PLN"); err == nil {
+		t.Fatal("Expected error for too many decimal places")
+	}
+	if _, err = money.Parse("1", "XXX"); err == nil {
+		t.Fatal("Expected error for unknown currency")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rates := &money.StaticRates{
+		Base:  "PLN",
+		Rates: map[string]*big.Rat{"EUR": big.NewRat(43, 10)},
+	}
+
+	// 10.00 EUR * 4.30 = 43.00 PLN
+	pln, err := money.Convert(money.New(1000, "EUR"), "PLN", rates)
+	if err != nil {
+		t.Fatalf("Error converting: %v", err)
+	}
+	if pln.Amount != 4300 || pln.Currency != "PLN" {
+		t.Fatalf("Unexpected result: %+v", pln)
+	}
+
+	// 1.00 PLN / 4.30 = 0.2325... EUR -> 0.23 EUR
+	eur, err := money.Convert(money.New(100, "PLN"), "EUR", rates)
+	if err != nil {
+		t.Fatalf("Error converting: %v", err)
+	}
+	if eur.Amount != 23 {
+		t.Fatalf("Unexpected result: %+v", eur)
+	}
+
+	if _, err = money.Convert(money.New(100, "PLN"), "UAH", rates); err == nil {
+		t.Fatal("Expected error for missing rate")
+	}
+}
+
+func TestMulRatRounding(t *testing.T) {
+	// 0.05 * 0.5 = 0.025 -> 0.03 (half away from zero)
+	if got := money.New(5, "PLN").MulRat(big.NewRat(1, 2)).Amount; got != 3 {
+		t.Fatalf("Unexpected rounding: %d", got)
+	}
+	if got := money.New(-5, "PLN").MulRat(big.NewRat(1, 2)).Amount; got != -3 {
+		t.Fatalf("Unexpected rounding: %d", got)
+	}
+}
+
+func TestParseStaticRates(t *testing.T) {
+	rates, err := money.ParseStaticRates("pln", " EUR=4.30, uah=0.098 ,")
+	if err != nil {
+		t.Fatalf("Error parsing rates: %v", err)
+	}
+	if rate := rates.Rates["EUR"]; rate == nil || rate.Cmp(big.NewRat(43, 10)) != 0 {
+		t.Errorf("expected EUR=4.30, got %v", rate)
+	}
+
+	for _, spec := range []string{"EUR", "EUR=abc", "EUR=0", "EUR=-1"} {
+		if _, err := money.ParseStaticRates("PLN", spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package notification_test
+
+import (
+	"backend/modules/notification/models"
+	"backend/modules/notification/service"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestHubDeliversOnlyToSubscribedUser(t *testing.T) {
+	hub := service.NewHub()
+	alice, bob := uuid.New(), uuid.New()
+
+	first, cancelFirst := hub.Subscribe(alice)
+	second, cancelSecond := hub.Subscribe(alice)
+	other, cancelOther := hub.Subscribe(bob)
+	defer cancelFirst()
+	defer cancelSecond()
+	defer cancelOther()
+
+	hub.Publish(alice, service.Event{Name: "notification", ID: "1"})
+
+	for _, ch := range []<-chan service.Event{first, second} {
+		select {
+		case event := <-ch:
+			if event.ID != "1" {
+				t.Errorf("expected event 1, got %q", event.ID)
+			}
+		default:
+			t.Error("expected every connection of the user to receive the event")
+		}
+	}
+	select {
+	case <-other:
+		t.Error("event leaked to another user")
+	default:
+	}
+}
+
+func TestHubCancel(t *testing.T) {
+	hub := service.NewHub()
+	userId := uuid.New()
+
+	ch, cancel := hub.Subscribe(userId)
+	if hub.Connected(userId) != 1 {
+		t.Fatalf("expected 1 connection, got %d", hub.Connected(userId))
+	}
+	cancel()
+	cancel()
+	if hub.Connected(userId) != 0 {
+		t.Fatalf("expected no connections after cancel, got %d", hub.Connected(userId))
+	}
+
+	hub.Publish(userId, service.Event{Name: "notification"})
+	select {
+	case <-ch:
+		t.Error("cancelled subscriber received an event")
+	default:
+	}
+}
+
+func TestHubPublishDoesNotBlockOnSlowSubscriber(t *testing.T) {
+	hub := service.NewHub()
+	userId := uuid.New()
+	ch, cancel := hub.Subscribe(userId)
+	defer cancel()
+
+	// Ніхто не читає канал: зайві події відкидаються, а Publish не блокується
+	for i := 0; i < 1000; i++ {
+		hub.Publish(userId, service.Event{Name: "notification"})
+	}
+	if len(ch) != cap(ch) {
+		t.Errorf("expected a full buffer of %d events, got %d", cap(ch), len(ch))
+	}
+}
+
+func TestDefaultChannels(t *testing.T) {
+	for _, kind := range models.Types() {
+		if _, ok := models.Defaults[kind]; !ok {
+			t.Errorf("type %q has no default channels", kind)
+		}
+	}
+	if models.Defaults[models.TypeTaskAssigned].Email {
+		t.Error("task assignments should not be emailed by default")
+	}
+}
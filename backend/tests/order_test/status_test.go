@@ -0,0 +1,47 @@
+package order_test
+
+import (
+	itemModels "backend/modules/item/models"
+	"backend/modules/order/models"
+	"testing"
+)
+
+func TestOrderStatusTransitions(t *testing.T) {
+	cases := []struct {
+		from, to models.Status
+		allowed  bool
+	}{
+		{models.StatusNew, models.StatusPaid, true},
+		{models.StatusNew, models.StatusCancelled, true},
+		{models.StatusNew, models.StatusShipped, false},
+		{models.StatusPaid, models.StatusShipped, true},
+		{models.StatusPaid, models.StatusRefunded, true},
+		{models.StatusShipped, models.StatusDelivered, true},
+		{models.StatusShipped, models.StatusCancelled, false},
+		{models.StatusDelivered, models.StatusRefunded, true},
+		{models.StatusCancelled, models.StatusNew, false},
+		{models.StatusRefunded, models.StatusPaid, false},
+		{models.StatusPaid, models.StatusPaid, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.from.CanTransition(tc.to); got != tc.allowed {
+			t.Errorf("%s → %s: expected %v, got %v", tc.from, tc.to, tc.allowed, got)
+		}
+	}
+	if models.Status("lost").Valid() {
+		t.Error("unknown status must be invalid")
+	}
+}
+
+func TestReservationMovements(t *testing.T) {
+	if delta, ok := itemModels.MovementReservation.Delta(3); !ok || delta != -3 {
+		t.Errorf("reservation must decrease stock, got %d %v", delta, ok)
+	}
+	if delta, ok := itemModels.MovementRelease.Delta(3); !ok || delta != 3 {
+		t.Errorf("release must increase stock, got %d %v", delta, ok)
+	}
+	if _, ok := itemModels.MovementReservation.Delta(-1); ok {
+		t.Error("a negative reservation must be rejected")
+	}
+}
@@ -0,0 +1,121 @@
+package payment_test
+
+import (
+	"backend/internal/services/payment"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded"}`)
+	header := payment.Sign("secret", payload, now)
+
+	if err := payment.VerifySignature("secret", payload, header, now.Add(time.Minute)); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+
+	cases := map[string]error{
+		"tampered payload": payment.VerifySignature("secret", []byte(`{"id":"evt_2"}`), header, now),
+		"wrong secret":     payment.VerifySignature("other", payload, header, now),
+		"stale timestamp":  payment.VerifySignature("secret", payload, header, now.Add(10*time.Minute)),
+		"malformed header": payment.VerifySignature("secret", payload, "garbage", now),
+	}
+	for name, err := range cases {
+		if !errors.Is(err, payment.ErrInvalidSignature) {
+			t.Errorf("%s: expected ErrInvalidSignature, got %v", name, err)
+		}
+	}
+}
+
+func TestFakeSimulateRoundTrip(t *testing.T) {
+	fake := payment.NewFake("secret")
+	session, err := fake.CreatePayment(context.Background(), payment.PaymentRequest{Reference: "order-1", Amount: 1500, Currency: "PLN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, header, err := fake.Simulate(payment.Event{Type: payment.EventPaymentSucceeded, PaymentID: session.PaymentID, Amount: 1500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := fake.VerifyWebhook(payload, header)
+	if err != nil {
+		t.Fatalf("simulated event rejected: %v", err)
+	}
+	if event.ID == "" || event.PaymentID != session.PaymentID || event.Amount != 1500 {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	other := payment.NewFake("another-secret")
+	if _, err = other.VerifyWebhook(payload, header); !errors.Is(err, payment.ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a foreign secret, got %v", err)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad key"})
+			return
+		}
+
+		switch r.URL.Path {
+		case "/payments":
+			var request payment.PaymentRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			if r.Header.Get("Idempotency-Key") != request.Reference {
+				t.Errorf("idempotency key %q does not match reference %q", r.Header.Get("Idempotency-Key"), request.Reference)
+			}
+			json.NewEncoder(w).Encode(map[string]string{"id": "pay_1", "redirect_url": "https://pay.example/pay_1"})
+		case "/refunds":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "already refunded"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &payment.HTTP{BaseURL: server.URL, APIKey: "key", WebhookSecret: "secret"}
+
+	session, err := provider.CreatePayment(context.Background(), payment.PaymentRequest{Reference: "order-1", Amount: 1500, Currency: "PLN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.PaymentID != "pay_1" || session.Status != payment.StatusPending || session.RedirectURL == "" {
+		t.Errorf("unexpected session %+v", session)
+	}
+
+	_, err = provider.Refund(context.Background(), payment.RefundRequest{Reference: "refund-1", PaymentID: "pay_1", Amount: 100})
+	if err == nil || err.Error() == "" {
+		t.Fatal("expected the provider error to be returned")
+	}
+
+	unauthorized := &payment.HTTP{BaseURL: server.URL, APIKey: "wrong"}
+	if _, err = unauthorized.CreatePayment(context.Background(), payment.PaymentRequest{Reference: "order-2", Amount: 1}); err == nil {
+		t.Error("expected an error for a rejected API key")
+	}
+}
+
+func TestFromEnvRequiresWebhookSecret(t *testing.T) {
+	for _, kind := range []string{"", "fake", "http"} {
+		t.Setenv("PAYMENT_PROVIDER", kind)
+		t.Setenv("PAYMENT_API_URL", "https://payments.example.com")
+		t.Setenv("PAYMENT_WEBHOOK_SECRET", "")
+		if _, err := payment.FromEnv(); err == nil {
+			t.Errorf("%q: expected an error without PAYMENT_WEBHOOK_SECRET", kind)
+		}
+
+		t.Setenv("PAYMENT_WEBHOOK_SECRET", "secret")
+		if _, err := payment.FromEnv(); err != nil {
+			t.Errorf("%q: unexpected error %v", kind, err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package presence_test
+
+import (
+	"backend/internal/services/presence"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	cases := []struct {
+		name        string
+		connections int
+		idle        time.Duration
+		expected    presence.Status
+	}{
+		{"active", 0, time.Minute, presence.Online},
+		{"active with tab", 1, time.Minute, presence.Online},
+		{"idle tab", 2, time.Hour, presence.Away},
+		{"recently left", 0, 10 * time.Minute, presence.Away},
+		{"gone", 0, time.Hour, presence.Offline},
+	}
+	for _, c := range cases {
+		if status := presence.Resolve(c.connections, c.idle); status != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, status)
+		}
+	}
+}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time                 { return c.now }
+func (c *clock) Advance(duration time.Duration) { c.now = c.now.Add(duration) }
+
+func newTracker() (*presence.Tracker, *clock, *[]presence.Change) {
+	c := &clock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	tracker := presence.NewTracker()
+	tracker.Now = c.Now
+	var changes []presence.Change
+	tracker.Listen(func(change presence.Change) {
+		changes = append(changes, change)
+	})
+	return tracker, c, &changes
+}
+
+func TestTouchThrottlesPersistence(t *testing.T) {
+	tracker, c, _ := newTracker()
+	userId := uuid.New()
+
+	if !tracker.Touch(userId) {
+		t.Fatal("first activity should be persisted")
+	}
+	c.Advance(10 * time.Second)
+	if tracker.Touch(userId) {
+		t.Error("activity within the persist interval should not be persisted")
+	}
+	c.Advance(presence.PersistInterval)
+	if !tracker.Touch(userId) {
+		t.Error("activity after the persist interval should be persisted")
+	}
+}
+
+func TestStatusChangesAreBroadcastOnce(t *testing.T) {
+	tracker, c, changes := newTracker()
+	userId := uuid.New()
+
+	disconnect := tracker.Connect(userId)
+	tracker.Touch(userId)
+	c.Advance(presence.AwayAfter)
+	tracker.Sweep()
+	tracker.Sweep()
+	c.Advance(presence.OfflineAfter)
+	tracker.Sweep()
+	disconnect()
+	disconnect()
+
+	expected := []presence.Status{presence.Online, presence.Away, presence.Offline}
+	if len(*changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %+v", len(expected), *changes)
+	}
+	for i, status := range expected {
+		if (*changes)[i].Status != status || (*changes)[i].UserID != userId {
+			t.Errorf("change %d: expected %s, got %+v", i, status, (*changes)[i])
+		}
+	}
+}
+
+func TestStatusFallsBackToLastSeenAt(t *testing.T) {
+	tracker, c, _ := newTracker()
+
+	recent := c.now.Add(-time.Minute)
+	if status := tracker.Status(uuid.New(), &recent); status != presence.Online {
+		t.Errorf("expected online from a recent last_seen_at, got %s", status)
+	}
+	if status := tracker.Status(uuid.New(), nil); status != presence.Offline {
+		t.Errorf("expected offline without last_seen_at, got %s", status)
+	}
+
+	userId := uuid.New()
+	tracker.Touch(userId)
+	old := c.now.Add(-time.Hour)
+	if seen := tracker.LastSeen(userId, &old); seen == nil || !seen.Equal(c.now) {
+		t.Errorf("expected in-memory activity to override stale last_seen_at, got %v", seen)
+	}
+}
@@ -0,0 +1,44 @@
+package property_test
+
+import (
+	"backend/modules/property/models"
+	"backend/modules/property/service"
+	"testing"
+)
+
+func TestBuildValue(t *testing.T) {
+	minHeight, maxHeight := 0.0, 300.0
+	height := &models.Attribute{Code: "height", Type: models.AttributeNumber, Unit: "cm", Min: &minHeight, Max: &maxHeight}
+
+	value, err := service.BuildValue(height, "12,5")
+	if err != nil {
+		t.Fatalf("Error building number value: %v", err)
+	}
+	if value.NumberValue == nil || *value.NumberValue != 12.5 {
+		t.Fatalf("Unexpected number value: %+v", value)
+	}
+	if _, err = service.BuildValue(height, 301.0); err == nil {
+		t.Fatal("Expected error for value above max")
+	}
+
+	size := &models.Attribute{Code: "size", Type: models.AttributeEnum, Options: []string{"S", "M", "L"}}
+	if _, err = service.BuildValue(size, "M"); err != nil {
+		t.Fatalf("Error building enum value: %v", err)
+	}
+	if _, err = service.BuildValue(size, "XXL"); err == nil {
+		t.Fatal("Expected error for value outside enum options")
+	}
+
+	ean := &models.Attribute{Code: "ean", Type: models.AttributeText, Pattern: `^\d{13}$`}
+	if _, err = service.BuildValue(ean, "5901234123457"); err != nil {
+		t.Fatalf("Error building text value: %v", err)
+	}
+	if _, err = service.BuildValue(ean, "123"); err == nil {
+		t.Fatal("Expected error for text not matching pattern")
+	}
+
+	waterproof := &models.Attribute{Code: "waterproof", Type: models.AttributeBoolean}
+	if _, err = service.BuildValue(waterproof, "yes"); err == nil {
+		t.Fatal("Expected error for non-boolean value")
+	}
+}
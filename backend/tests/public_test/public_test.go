@@ -0,0 +1,82 @@
+package public_test
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/services/httpcache"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var modified = time.Date(2025, 3, 1, 10, 0, 0, 500, time.UTC)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/items", middleware.RateLimiter(2, time.Minute), func(ctx *gin.Context) {
+		httpcache.JSON(ctx, modified, gin.H{"title": "Item"})
+	})
+	return r
+}
+
+func get(r *gin.Engine, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestConditionalRequests(t *testing.T) {
+	r := gin.New()
+	r.GET("/items", func(ctx *gin.Context) {
+		httpcache.JSON(ctx, modified, gin.H{"title": "Item"})
+	})
+
+	first := get(r, nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with ETag, got %d %q", first.Code, etag)
+	}
+	if lastModified := first.Header().Get("Last-Modified"); lastModified != "Sat, 01 Mar 2025 10:00:00 GMT" {
+		t.Fatalf("unexpected Last-Modified %q", lastModified)
+	}
+
+	cases := []struct {
+		name     string
+		headers  map[string]string
+		expected int
+	}{
+		{"matching etag", map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified},
+		{"stale etag wins over date", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": "Sat, 01 Mar 2025 10:00:00 GMT"}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": "Sat, 01 Mar 2025 10:00:00 GMT"}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": "Sat, 01 Mar 2025 09:59:59 GMT"}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		if w := get(r, tc.headers); w.Code != tc.expected {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.expected, w.Code)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	r := newRouter()
+
+	for i := 0; i < 2; i++ {
+		if w := get(r, nil); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+
+	w := get(r, nil)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" || w.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Fatalf("expected rate limit headers, got %v", w.Header())
+	}
+}
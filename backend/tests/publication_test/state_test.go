@@ -0,0 +1,70 @@
+package publication_test
+
+import (
+	"backend/internal/entities"
+	"errors"
+	"testing"
+	"time"
+)
+
+func state(s entities.PublicationState) *entities.PublicationState {
+	return &s
+}
+
+func TestScheduleRequiresFutureDate(t *testing.T) {
+	now := time.Now()
+	publication := entities.Publication{State: entities.StateDraft}
+
+	past := now.Add(-time.Hour)
+	err := publication.Apply(&entities.PublicationUpdate{State: state(entities.StateScheduled), PublishAt: &past}, now)
+	if err == nil {
+		t.Fatal("Expected error for publish_at in the past")
+	}
+	if publication.State != entities.StateDraft {
+		t.Fatalf("State changed after failed transition: %s", publication.State)
+	}
+
+	future := now.Add(time.Hour)
+	err = publication.Apply(&entities.PublicationUpdate{State: state(entities.StateScheduled), PublishAt: &future}, now)
+	if err != nil {
+		t.Fatalf("Error scheduling: %v", err)
+	}
+	if publication.State != entities.StateScheduled || !publication.PublishAt.Equal(future) {
+		t.Fatalf("Unexpected publication: %+v", publication)
+	}
+}
+
+func TestInvalidTransition(t *testing.T) {
+	publication := entities.Publication{State: entities.StateArchived}
+	err := publication.Apply(&entities.PublicationUpdate{State: state(entities.StatePublished)}, time.Now())
+	if !errors.Is(err, entities.ErrInvalidTransition) {
+		t.Fatalf("Expected invalid transition, got %v", err)
+	}
+}
+
+func TestPublishSetsDateAndDraftClearsSchedule(t *testing.T) {
+	now := time.Now()
+	publication := entities.Publication{}
+	if err := publication.Init(now); err != nil {
+		t.Fatalf("Error initializing: %v", err)
+	}
+	if publication.State != entities.StateDraft {
+		t.Fatalf("Expected draft, got %s", publication.State)
+	}
+
+	unpublish := now.Add(24 * time.Hour)
+	err := publication.Apply(&entities.PublicationUpdate{State: state(entities.StatePublished), UnpublishAt: &unpublish}, now)
+	if err != nil {
+		t.Fatalf("Error publishing: %v", err)
+	}
+	if publication.PublishAt == nil || !publication.PublishAt.Equal(now) {
+		t.Fatalf("Expected publish_at to be set to now: %+v", publication)
+	}
+
+	if err = publication.Apply(&entities.PublicationUpdate{State: state(entities.StateDraft)}, now); err != nil {
+		t.Fatalf("Error unpublishing: %v", err)
+	}
+	if publication.PublishAt != nil || publication.UnpublishAt != nil {
+		t.Fatalf("Expected schedule to be cleared: %+v", publication)
+	}
+}
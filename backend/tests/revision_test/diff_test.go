@@ -0,0 +1,42 @@
+package revision_test
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/revision"
+	"testing"
+)
+
+func TestDiffReportsChangedFields(t *testing.T) {
+	from := entities.JSON(`{"title":"Old","price":100,"properties":{"color":"red"}}`)
+	to := entities.JSON(`{"title":"New","price":100,"properties":{"color":"blue"},"sku":"A-1"}`)
+
+	changes, err := revision.Diff(from, to)
+	if err != nil {
+		t.Fatalf("Error comparing snapshots: %v", err)
+	}
+
+	expected := []string{"properties", "sku", "title"}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, field := range expected {
+		if changes[i].Field != field {
+			t.Errorf("Expected change %d to be %s, got %s", i, field, changes[i].Field)
+		}
+	}
+	if changes[1].From != nil || changes[1].To != "A-1" {
+		t.Errorf("Unexpected sku change: %+v", changes[1])
+	}
+}
+
+func TestDiffOfEqualSnapshotsIsEmpty(t *testing.T) {
+	snapshot := entities.JSON(`{"title":"Same","price":100}`)
+
+	changes, err := revision.Diff(snapshot, snapshot)
+	if err != nil {
+		t.Fatalf("Error comparing snapshots: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %+v", changes)
+	}
+}
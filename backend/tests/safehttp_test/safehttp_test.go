@@ -0,0 +1,57 @@
+package safehttp_test
+
+import (
+	"backend/internal/services/safehttp"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAllowedIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":           true,
+		"2001:4860::8888":   true,
+		"127.0.0.1":         false,
+		"::1":               false,
+		"10.1.2.3":          false,
+		"172.16.0.1":        false,
+		"192.168.1.1":       false,
+		"169.254.169.254":   false,
+		"fe80::1":           false,
+		"fd00::1":           false,
+		"0.0.0.0":           false,
+		"0.1.2.3":           false,
+		"100.64.0.1":        false,
+		"100.127.255.254":   false,
+		"100.128.0.1":       true,
+		"::ffff:100.64.0.1": false,
+		"::":                false,
+	}
+	for address, expected := range cases {
+		if got := safehttp.AllowedIP(net.ParseIP(address)); got != expected {
+			t.Errorf("%s: expected %v, got %v", address, expected, got)
+		}
+	}
+}
+
+func TestClientRejectsInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := safehttp.NewClient(5 * time.Second).Get(server.URL)
+	if !errors.Is(err, safehttp.ErrForbiddenAddress) {
+		t.Fatalf("expected ErrForbiddenAddress, got %v", err)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	for _, rawURL := range []string{"http://127.0.0.1/hook", "https://localhost:8443", "ftp://example.com", "http:///path"} {
+		if err := safehttp.ValidateURL(context.Background(), rawURL); err == nil {
+			t.Errorf("%s: expected an error", rawURL)
+		}
+	}
+}
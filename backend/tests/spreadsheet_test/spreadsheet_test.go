@@ -0,0 +1,91 @@
+package spreadsheet_test
+
+import (
+	"backend/internal/services/spreadsheet"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestXLSXRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"sku", "title", "attr.color"},
+		{"A-1", "Krzesło <dębowe> & stół", "brązowy"},
+		{"A-2", "", "  spaces  "},
+	}
+
+	var buf bytes.Buffer
+	if err := spreadsheet.WriteXLSX(&buf, rows); err != nil {
+		t.Fatalf("Error writing xlsx: %v", err)
+	}
+
+	got, err := spreadsheet.ReadXLSX(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Error reading xlsx: %v", err)
+	}
+	// Порожні клітинки в кінці рядка не записуються, тому порівнюємо з доповненням
+	for i := range got {
+		for len(got[i]) < len(rows[i]) {
+			got[i] = append(got[i], "")
+		}
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Fatalf("Unexpected rows: %q", got)
+	}
+}
+
+func TestReadCSVStripsBOM(t *testing.T) {
+	rows, err := spreadsheet.ReadCSV(strings.NewReader("\ufeffsku,title\nA-1,Chair\n"))
+	if err != nil {
+		t.Fatalf("Error reading csv: %v", err)
+	}
+	if rows[0][0] != "sku" || rows[1][1] != "Chair" {
+		t.Fatalf("Unexpected rows: %q", rows)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := spreadsheet.ParseFormat("Catalogue.XLSX")
+	if err != nil || format != spreadsheet.FormatXLSX {
+		t.Fatalf("Unexpected format: %v, %v", format, err)
+	}
+	if _, err = spreadsheet.ParseFormat("items.ods"); err == nil {
+		t.Fatal("Expected error for unsupported format")
+	}
+}
+
+func TestFormulaEscaping(t *testing.T) {
+	rows := [][]string{
+		{"sku", "title"},
+		{"=HYPERLINK(\"http://evil\")", "+1"},
+		{"-5", "@SUM(A1)"},
+		{"\tcell", "'quoted"},
+	}
+
+	var buf bytes.Buffer
+	if err := spreadsheet.WriteCSV(&buf, rows); err != nil {
+		t.Fatalf("Error writing csv: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n")[1:] {
+		if first := strings.TrimPrefix(line, `"`)[0]; first != '\'' {
+			t.Errorf("Cell is not escaped: %q", line)
+		}
+	}
+
+	got, err := spreadsheet.ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("Error reading csv: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Fatalf("Unexpected rows after round trip: %q", got)
+	}
+
+	buf.Reset()
+	if err = spreadsheet.WriteXLSX(&buf, rows); err != nil {
+		t.Fatalf("Error writing xlsx: %v", err)
+	}
+	if got, err = spreadsheet.ReadXLSX(buf.Bytes()); err != nil || !reflect.DeepEqual(got, rows) {
+		t.Fatalf("Unexpected xlsx rows: %q (%v)", got, err)
+	}
+}
@@ -0,0 +1,34 @@
+package stock_test
+
+import (
+	"backend/modules/item/models"
+	"testing"
+)
+
+func TestMovementDelta(t *testing.T) {
+	cases := []struct {
+		movement models.MovementType
+		quantity int
+		delta    int
+		ok       bool
+	}{
+		{models.MovementReceipt, 5, 5, true},
+		{models.MovementReturn, 2, 2, true},
+		{models.MovementRelease, 3, 3, true},
+		{models.MovementSale, 4, -4, true},
+		{models.MovementReservation, 1, -1, true},
+		{models.MovementAdjustment, -7, -7, true},
+		{models.MovementAdjustment, 7, 7, true},
+		{models.MovementAdjustment, 0, 0, false},
+		{models.MovementReceipt, 0, 0, false},
+		{models.MovementReceipt, -1, 0, false},
+		{models.MovementSale, -4, 0, false},
+		{models.MovementType("unknown"), 5, 0, false},
+	}
+	for _, c := range cases {
+		delta, ok := c.movement.Delta(c.quantity)
+		if delta != c.delta || ok != c.ok {
+			t.Errorf("%s(%d): expected %d %v, got %d %v", c.movement, c.quantity, c.delta, c.ok, delta, ok)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package tag_test
+
+import (
+	"backend/modules/tag/repository"
+	"reflect"
+	"testing"
+)
+
+func TestParseTagSlugs(t *testing.T) {
+	tests := []struct {
+		param string
+		want  []string
+	}{
+		{"", nil},
+		{"go", []string{"go"}},
+		{"Go, Веб розробка ,go", []string{"go", "veb-rozrobka"}},
+		{" , ,", nil},
+	}
+
+	for _, tt := range tests {
+		if got := repository.ParseTagSlugs(tt.param); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTagSlugs(%q) = %v, want %v", tt.param, got, tt.want)
+		}
+	}
+}
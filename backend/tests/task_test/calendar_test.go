@@ -0,0 +1,82 @@
+package task_test
+
+import (
+	"backend/modules/task/models"
+	"backend/modules/task/service"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventAssignees(t *testing.T) {
+	due := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	assignees := []uuid.UUID{uuid.New(), uuid.New()}
+
+	cases := []struct {
+		name     string
+		card     models.TaskCard
+		expected int
+	}{
+		{"synced", models.TaskCard{DueDate: &due, CalendarSync: true}, 2},
+		{"sync disabled", models.TaskCard{DueDate: &due}, 0},
+		{"no due date", models.TaskCard{CalendarSync: true}, 0},
+		{"completed", models.TaskCard{DueDate: &due, CalendarSync: true, CompletedAt: &due}, 0},
+	}
+	for _, tc := range cases {
+		if got := service.EventAssignees(&tc.card, assignees); len(got) != tc.expected {
+			t.Errorf("%s: expected %d assignees, got %d", tc.name, tc.expected, len(got))
+		}
+	}
+}
+
+func TestEventFor(t *testing.T) {
+	due := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	userId := uuid.New()
+	card := models.TaskCard{Title: "Send offer", DueDate: &due, ReminderOffset: 30, CalendarSync: true}
+
+	event := service.EventFor(&card, userId)
+	if event.UserID != userId || !event.StartDate.Equal(due) || !event.EndDate.Equal(due) {
+		t.Errorf("unexpected event time or owner: %+v", event)
+	}
+	if event.Title != "Task: Send offer" || event.ReminderOffset != 30 || !event.SendEmail {
+		t.Errorf("expected an email reminder 30 minutes before, got %+v", event)
+	}
+}
+
+func TestPlanCardEvents(t *testing.T) {
+	due := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	card := models.TaskCard{ID: uuid.New(), DueDate: &due, CalendarSync: true}
+	kept, added, removed := uuid.New(), uuid.New(), uuid.New()
+	keptEvent, removedEvent := uuid.New(), uuid.New()
+	links := []models.TaskCardEvent{
+		{CardID: card.ID, UserID: kept, EventID: keptEvent},
+		{CardID: card.ID, UserID: removed, EventID: removedEvent},
+	}
+
+	linked, missing, stale := service.PlanCardEvents(&card, []uuid.UUID{kept, added, added}, links)
+	if !reflect.DeepEqual(linked, map[uuid.UUID]uuid.UUID{kept: keptEvent}) {
+		t.Errorf("expected the event of the remaining assignee to be updated, got %v", linked)
+	}
+	if !reflect.DeepEqual(missing, []uuid.UUID{added}) {
+		t.Errorf("expected one event for the new assignee, got %v", missing)
+	}
+	if !reflect.DeepEqual(stale, []uuid.UUID{removedEvent}) {
+		t.Errorf("expected the event of the removed assignee to be deleted, got %v", stale)
+	}
+}
+
+func TestPlanCardEventsWithoutSync(t *testing.T) {
+	due := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	card := models.TaskCard{ID: uuid.New(), DueDate: &due, CompletedAt: &due, CalendarSync: true}
+	userId, eventId := uuid.New(), uuid.New()
+	links := []models.TaskCardEvent{{CardID: card.ID, UserID: userId, EventID: eventId}}
+
+	linked, missing, stale := service.PlanCardEvents(&card, []uuid.UUID{userId}, links)
+	if len(linked) != 0 || len(missing) != 0 {
+		t.Errorf("expected no events for a completed card, got %v and %v", linked, missing)
+	}
+	if !reflect.DeepEqual(stale, []uuid.UUID{eventId}) {
+		t.Errorf("expected the existing event to be deleted, got %v", stale)
+	}
+}
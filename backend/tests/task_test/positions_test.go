@@ -0,0 +1,175 @@
+package task_test
+
+import (
+	"backend/internal/repository"
+	"backend/modules/task/models"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+)
+
+const (
+	maxPositionQuery = `SELECT MAX\(position\) FROM "task_columns" WHERE board_id = \$1`
+	followingQuery   = `SELECT .+ FROM "task_columns" WHERE position >= \$1 AND board_id = \$2 ORDER BY position ASC`
+	updateQuery      = `UPDATE "task_columns" SET`
+)
+
+func mockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+// expectShift очікує вибірку записів групи від позиції position і збереження кожного по черзі на одну позицію далі
+func expectShift(mock sqlmock.Sqlmock, position int, boardId uuid.UUID, rows ...models.TaskColumn) {
+	result := sqlmock.NewRows([]string{"id", "board_id", "title", "position"})
+	for _, row := range rows {
+		result.AddRow(row.ID, row.BoardID, row.Title, row.Position)
+	}
+	mock.ExpectQuery(followingQuery).WithArgs(position, boardId).WillReturnRows(result)
+	for _, row := range rows {
+		mock.ExpectExec(updateQuery).
+			WithArgs(row.BoardID, row.Title, row.Position+1, sqlmock.AnyArg(), sqlmock.AnyArg(), row.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+}
+
+func TestPlaceAppendsNewRecords(t *testing.T) {
+	boardId := uuid.New()
+	cases := []struct {
+		name     string
+		last     any
+		expected int
+	}{
+		{"empty group", nil, 0},
+		{"after the last record", 4, 5},
+	}
+	for _, tc := range cases {
+		db, mock := mockDB(t)
+		mock.ExpectQuery(maxPositionQuery).WithArgs(boardId).
+			WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(tc.last))
+
+		position, err := repository.PlaceBy[models.TaskColumn](db, nil, "board_id", boardId)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if position != tc.expected {
+			t.Errorf("%s: expected position %d, got %d", tc.name, tc.expected, position)
+		}
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+	}
+}
+
+func TestPlaceRejectsNegativePosition(t *testing.T) {
+	db, mock := mockDB(t)
+	position := -1
+
+	_, err := repository.PlaceBy[models.TaskColumn](db, &position, "board_id", uuid.New())
+	if !errors.Is(err, repository.ErrInvalidPosition) {
+		t.Errorf("expected ErrInvalidPosition, got %v", err)
+	}
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPlaceShiftsFollowingRecords(t *testing.T) {
+	db, mock := mockDB(t)
+	boardId := uuid.New()
+	second := models.TaskColumn{ID: uuid.New(), BoardID: boardId, Title: "In progress", Position: 1}
+	third := models.TaskColumn{ID: uuid.New(), BoardID: boardId, Title: "Done", Position: 2}
+	expectShift(mock, 1, boardId, second, third)
+
+	position := 1
+	placed, err := repository.PlaceBy[models.TaskColumn](db, &position, "board_id", boardId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed != 1 {
+		t.Errorf("expected position 1, got %d", placed)
+	}
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// Переміщення в іншу групу зсуває лише записи цільової групи
+func TestPlaceMovedRecordShiftsTargetGroup(t *testing.T) {
+	db, mock := mockDB(t)
+	target := uuid.New()
+	first := models.TaskColumn{ID: uuid.New(), BoardID: target, Title: "Backlog", Position: 0}
+	expectShift(mock, 0, target, first)
+
+	position := 0
+	placed, err := repository.PlaceBy[models.TaskColumn](db, &position, "board_id", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed != 0 {
+		t.Errorf("expected position 0, got %d", placed)
+	}
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// Переміщення вгору в тій самій групі зсуває й сам запис, але викликач одразу записує йому нову позицію,
+// тож порядок решти не змінюється; переміщення вниз зсуває лише записи від нової позиції
+func TestPlaceSameGroupMoves(t *testing.T) {
+	boardId := uuid.New()
+	first := models.TaskColumn{ID: uuid.New(), BoardID: boardId, Title: "To do", Position: 0}
+	second := models.TaskColumn{ID: uuid.New(), BoardID: boardId, Title: "In progress", Position: 1}
+	moved := models.TaskColumn{ID: uuid.New(), BoardID: boardId, Title: "Done", Position: 2}
+
+	t.Run("up", func(t *testing.T) {
+		db, mock := mockDB(t)
+		expectShift(mock, 0, boardId, first, second, moved)
+
+		position := 0
+		placed, err := repository.PlaceBy[models.TaskColumn](db, &position, "board_id", boardId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if placed != 0 {
+			t.Errorf("expected position 0, got %d", placed)
+		}
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("down", func(t *testing.T) {
+		db, mock := mockDB(t)
+		expectShift(mock, 2, boardId, moved)
+
+		position := 2
+		placed, err := repository.PlaceBy[models.TaskColumn](db, &position, "board_id", boardId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if placed != 2 {
+			t.Errorf("expected position 2, got %d", placed)
+		}
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
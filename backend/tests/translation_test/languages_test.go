@@ -0,0 +1,50 @@
+package translation_test
+
+import (
+	"backend/internal/repository"
+	"backend/internal/services/utils"
+	"reflect"
+	"testing"
+)
+
+func TestParseLanguages(t *testing.T) {
+	t.Setenv("CONTENT_LANGUAGES", " pl, en ,,uk ")
+
+	cases := map[string][]string{
+		"":                {"pl", "en", "uk"},
+		" , ":             {"pl", "en", "uk"},
+		"en":              {"en"},
+		" en , de ,en,, ": {"en", "de"},
+	}
+	for param, expected := range cases {
+		if languages := utils.ParseLanguages(param); !reflect.DeepEqual(languages, expected) {
+			t.Errorf("%q: expected %v, got %v", param, expected, languages)
+		}
+	}
+}
+
+func TestEnabledLanguagesDefault(t *testing.T) {
+	t.Setenv("CONTENT_LANGUAGES", " , ")
+
+	expected := []string{"pl", "en", "uk"}
+	if languages := utils.EnabledLanguages(); !reflect.DeepEqual(languages, expected) {
+		t.Errorf("expected %v, got %v", expected, languages)
+	}
+}
+
+func TestMissingLanguages(t *testing.T) {
+	cases := []struct {
+		present  []string
+		expected []string
+	}{
+		{[]string{"pl"}, []string{"en", "uk"}},
+		{[]string{"en", "pl", "uk"}, nil},
+		{[]string{"de"}, []string{"pl", "en", "uk"}},
+		{nil, []string{"pl", "en", "uk"}},
+	}
+	for _, c := range cases {
+		if missing := repository.MissingLanguages(c.present, []string{"pl", "en", "uk"}); !reflect.DeepEqual(missing, c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.present, c.expected, missing)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package utils_test
+
+import (
+	"backend/internal/services/utils"
+	"testing"
+)
+
+func TestSlugifyTransliteration(t *testing.T) {
+	cases := map[string]string{
+		"Hello, World!":           "hello-world",
+		"Згорана Юлія":            "zghorana-yuliia",
+		"Ящик для м'яти":          "yashchyk-dlia-miaty",
+		"Їжак і Євген":            "yizhak-i-yevhen",
+		"Żółta łódź w Gdańsku":    "zolta-lodz-w-gdansku",
+		"  Щука — 2024 / ґанок  ": "shchuka-2024-ganok",
+		"Київська область":        "kyivska-oblast",
+		"中文":                      "",
+	}
+
+	for input, expected := range cases {
+		if slug := utils.Slugify(input); slug != expected {
+			t.Errorf("Slugify(%q) = %q, expected %q", input, slug, expected)
+		}
+	}
+}
@@ -14,7 +14,7 @@ func TestGenerateJWTToken(t *testing.T) {
 	testEmail := "test@example.com"
 
 	// Викликаємо функцію
-	token, err := utils.GenerateJWTToken(testEmail, testID)
+	token, err := utils.GenerateJWTToken(testEmail, "Test User", testID)
 	if err != nil {
 		t.Fatalf("Error generating JWT token: %v", err)
 	}
@@ -0,0 +1,91 @@
+package webhook_test
+
+import (
+	"backend/internal/entities"
+	"backend/internal/services/safehttp"
+	"backend/modules/webhook/models"
+	"backend/modules/webhook/service"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"event":"ping"}`)
+	at := time.Unix(1767225600, 0)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1767225600." + string(payload)))
+	expected := "t=1767225600,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	if signature := service.Sign("whsec_test", payload, at); signature != expected {
+		t.Errorf("expected %s, got %s", expected, signature)
+	}
+	if service.Sign("other", payload, at) == expected {
+		t.Error("signature must depend on the secret")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		9:  128 * time.Minute,
+		10: 256 * time.Minute,
+		20: 6 * time.Hour,
+	}
+	for attempt, expected := range cases {
+		if delay := service.RetryDelay(attempt); delay != expected {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, expected, delay)
+		}
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	endpoint := models.WebhookEndpoint{Events: entities.StringList{models.EventItemCreated}}
+	if !endpoint.Subscribed(models.EventItemCreated) || endpoint.Subscribed(models.EventItemUpdated) {
+		t.Error("endpoint must receive only subscribed events")
+	}
+
+	endpoint.Events = entities.StringList{models.EventAll}
+	for _, event := range models.Events() {
+		if !endpoint.Subscribed(event) {
+			t.Errorf("wildcard endpoint must receive %s", event)
+		}
+	}
+
+	if models.ValidEvent(models.EventPing) || models.ValidEvent("item.deleted") {
+		t.Error("ping and unknown events cannot be subscribed to")
+	}
+}
+
+func TestClientRejectsInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request must not reach a loopback address")
+	}))
+	defer server.Close()
+
+	resp, err := service.Client.Post(server.URL, "application/json", nil)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, safehttp.ErrForbiddenAddress) {
+		t.Errorf("expected ErrForbiddenAddress, got %v", err)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	if service.Client.CheckRedirect == nil {
+		t.Fatal("expected a redirect policy")
+	}
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/next", nil)
+	if err := service.Client.CheckRedirect(req, []*http.Request{req}); !errors.Is(err, http.ErrUseLastResponse) {
+		t.Errorf("expected http.ErrUseLastResponse, got %v", err)
+	}
+}